@@ -0,0 +1,175 @@
+package go_hubspot
+
+import (
+	"fmt"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// associationTypeSpec is a representation of an association type used when creating associations inline
+type associationTypeSpec struct {
+	AssociationCategory string `json:"associationCategory"`
+	AssociationTypeId   int    `json:"associationTypeId"`
+}
+
+type inlineAssociationTo struct {
+	Id string `json:"id"`
+}
+
+// inlineAssociation is a representation of an association sent along with an object creation request
+type inlineAssociation struct {
+	To    inlineAssociationTo   `json:"to"`
+	Types []associationTypeSpec `json:"types"`
+}
+
+// objectCreationRequest is a representation of a request to create a CRM object with inline associations
+type objectCreationRequest struct {
+	Properties   map[string]string   `json:"properties"`
+	Associations []inlineAssociation `json:"associations,omitempty"`
+}
+
+// hubspotDefinedAssociationTypes maps association labels to the IDs of HubSpot defined association types,
+// inline associations can only be made with the numeric IDs
+var hubspotDefinedAssociationTypes = map[string]int{
+	"deal_to_contact":    3,
+	"deal_to_company":    5,
+	"deal_to_line_item":  19,
+	"deal_to_ticket":     27,
+	"line_item_to_deal":  20,
+	"ticket_to_contact":  16,
+	"ticket_to_deal":     28,
+	"ticket_to_company":  339,
+	"call_to_company":    182,
+	"call_to_contact":    194,
+	"call_to_deal":       206,
+	"call_to_ticket":     220,
+	"email_to_company":   186,
+	"email_to_contact":   198,
+	"email_to_deal":      210,
+	"email_to_ticket":    224,
+	"meeting_to_company": 188,
+	"meeting_to_contact": 200,
+	"meeting_to_deal":    212,
+	"meeting_to_ticket":  226,
+	"note_to_company":    190,
+	"note_to_contact":    202,
+	"note_to_deal":       214,
+	"note_to_ticket":     228,
+	"task_to_company":    192,
+	"task_to_contact":    204,
+	"task_to_deal":       216,
+	"task_to_ticket":     230,
+}
+
+// HubSpotDefinedAssociationLabel returns the label of a HubSpot defined association type given its id, e.g. "deal_to_company" for 5,
+// returns false if the association type is not known
+func HubSpotDefinedAssociationLabel(typeId int) (string, bool) {
+	for label, id := range hubspotDefinedAssociationTypes {
+		if id == typeId {
+			return label, true
+		}
+	}
+	return "", false
+}
+
+// ObjectAssociation is an object that a new object is associated with on creation
+// ObjectType is the type of the associated object, e.g. "contacts", "companies", "line_items" or "tickets".
+// Label is the association label, e.g. "deal_to_company", when it is empty the default label for the object types is used.
+// TypeId can be set instead of Label for labels defined in the portal, together with Category "USER_DEFINED".
+type ObjectAssociation struct {
+	ObjectType string
+	Id         string
+	Label      string
+	TypeId     int
+	Category   string
+}
+
+// label returns the association label from an object of fromObjectType
+func (association ObjectAssociation) label(fromObjectType string) string {
+	if association.Label != "" {
+		return association.Label
+	}
+	return crm.SingularObjectType(fromObjectType) + "_to_" + crm.SingularObjectType(association.ObjectType)
+}
+
+// inline returns the association to send with the creation request of an object of fromObjectType,
+// returns false if the association type can only be referred to by its label
+func (association ObjectAssociation) inline(fromObjectType string) (inlineAssociation, bool) {
+	if association.TypeId != 0 {
+		category := association.Category
+		if category == "" {
+			category = "HUBSPOT_DEFINED"
+		}
+		return newInlineAssociationWithType(association.Id, category, association.TypeId), true
+	}
+	return newInlineAssociation(association.Id, association.label(fromObjectType))
+}
+
+// newInlineAssociation creates an inline association to the object with the given id,
+// returns false if the association label is not a known HubSpot defined association type
+func newInlineAssociation(toId, assocType string) (inlineAssociation, bool) {
+	typeId, ok := hubspotDefinedAssociationTypes[assocType]
+	if !ok {
+		return inlineAssociation{}, false
+	}
+
+	return newInlineAssociationWithType(toId, "HUBSPOT_DEFINED", typeId), true
+}
+
+// newInlineAssociationWithType creates an inline association to the object with the given id and association type
+func newInlineAssociationWithType(toId, category string, typeId int) inlineAssociation {
+	return inlineAssociation{
+		To: inlineAssociationTo{Id: toId},
+		Types: []associationTypeSpec{
+			{
+				AssociationCategory: category,
+				AssociationTypeId:   typeId,
+			},
+		},
+	}
+}
+
+type associationBatchFrom struct {
+	Id string `json:"id"`
+}
+
+type associationBatchResult struct {
+	From associationBatchFrom `json:"from"`
+	To   []Association        `json:"to"`
+}
+
+type associationBatchReadResponse struct {
+	Results []associationBatchResult `json:"results"`
+}
+
+// batchReadAssociations returns the ids of the objects of toObjectType associated with each of the given objects
+func batchReadAssociations(httpClient IHTTPClient, apiKey, fromObjectType, toObjectType string, ids []string) (map[string][]string, error) {
+	url := fmt.Sprintf(
+		"https://api.hubapi.com/crm/v3/associations/%s/%s/batch/read?hapikey=%s",
+		fromObjectType,
+		toObjectType,
+		apiKey,
+	)
+
+	associations := map[string][]string{}
+	for _, chunk := range chunkIds(ids) {
+		request := batchReadRequest{Inputs: make([]batchId, len(chunk))}
+		for i, id := range chunk {
+			request.Inputs[i] = batchId{Id: id}
+		}
+
+		var response associationBatchReadResponse
+		err := doJSONRequest(httpClient, "POST", url, request, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, result := range response.Results {
+			for _, to := range result.To {
+				associations[result.From.Id] = append(associations[result.From.Id], to.Id)
+			}
+		}
+	}
+
+	return associations, nil
+}
@@ -0,0 +1,52 @@
+package go_hubspot
+
+import (
+	"fmt"
+)
+
+// batchLimit is the maximum number of inputs HubSpot accepts in a single batch request
+const batchLimit = 100
+
+type batchId struct {
+	Id string `json:"id"`
+}
+
+type batchReadRequest struct {
+	Properties []string  `json:"properties,omitempty"`
+	Inputs     []batchId `json:"inputs"`
+}
+
+// BatchUpdateInput is the update of a single object in a batch update request
+type BatchUpdateInput struct {
+	Id         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+}
+
+type batchUpdateRequest struct {
+	Inputs []BatchUpdateInput `json:"inputs"`
+}
+
+// BatchError is an error reported by HubSpot for some of the inputs of a batch request
+type BatchError struct {
+	Status   string              `json:"status"`
+	Category string              `json:"category"`
+	Message  string              `json:"message"`
+	Context  map[string][]string `json:"context"`
+}
+
+func (e BatchError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Category, e.Message)
+}
+
+// chunkIds splits ids into chunks that fit in a single batch request
+func chunkIds(ids []string) [][]string {
+	chunks := [][]string{}
+	for start := 0; start < len(ids); start += batchLimit {
+		end := start + batchLimit
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
@@ -0,0 +1,506 @@
+package go_hubspot
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// redacted replaces the values of API keys, credentials and redacted fields in cassettes
+const redacted = "REDACTED"
+
+// multipartBoundary replaces the random boundaries of multipart bodies in cassettes, so that they can be matched
+const multipartBoundary = "CASSETTE-BOUNDARY"
+
+// CassetteMode is whether a cassette records the interactions with HubSpot or replays recorded ones
+type CassetteMode int
+
+const (
+	CassetteReplay CassetteMode = iota
+	CassetteRecord
+)
+
+// CassetteRequest is a recorded request, with its URL and body redacted
+type CassetteRequest struct {
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	Body       string `json:"body,omitempty"`
+	BodyBase64 bool   `json:"bodyBase64,omitempty"`
+}
+
+// CassetteResponse is a recorded response, with its headers and body redacted
+type CassetteResponse struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 bool        `json:"bodyBase64,omitempty"`
+}
+
+// CassetteInteraction is a request made to HubSpot and its response
+type CassetteInteraction struct {
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// cassetteFile is the representation of a cassette file, with the fields redacted when recording it
+type cassetteFile struct {
+	RedactedFields []string              `json:"redactedFields"`
+	Interactions   []CassetteInteraction `json:"interactions"`
+}
+
+// Cassette is an HTTP client recording the requests made to HubSpot and their responses to a JSON file,
+// and replaying them later, e.g. to run tests against real HubSpot interactions without calling HubSpot:
+//
+//	cassette, err := hubspot.LoadCassette("testdata/deals.json")
+//	api := hubspot.NewHubspotDealFlowAPIWithClient("api_key", cassette)
+//
+// When recording, requests are made with Client and every interaction is written to the file as it happens.
+// API keys, the Authorization header, cookies, the signatures and credentials of pre-signed URLs
+// and the values of RedactedFields are redacted from the file,
+// RedactedFields are the names of query parameters, JSON fields and HubSpot properties, e.g. "email",
+// and are redacted from both requests and responses, so replayed responses contain "REDACTED" in their place.
+// The contents of the files uploaded in multipart requests, e.g. by StartImport, are always redacted, so they are not matched on replay.
+//
+// When replaying, requests are matched to the recorded ones on their method, path, query and body,
+// redacted the same way, with the RedactedFields stored in the file when it was recorded,
+// each recorded interaction is replayed once, in order. Requests that match no recorded interaction fail with a *CassetteMismatchError.
+type Cassette struct {
+	Path           string
+	Mode           CassetteMode
+	Client         IHTTPClient
+	RedactedFields []string
+
+	mu           sync.Mutex
+	interactions []CassetteInteraction
+	replayed     []bool
+}
+
+// CassetteMismatchError is returned when replaying a request that matches no recorded interaction
+type CassetteMismatchError struct {
+	Path    string
+	Request CassetteRequest
+}
+
+func (e *CassetteMismatchError) Error() string {
+	return fmt.Sprintf(
+		"No interaction recorded in cassette '%s' matches %s %s with body '%s', record the cassette again if the request changed",
+		e.Path,
+		e.Request.Method,
+		e.Request.URL,
+		e.Request.Body,
+	)
+}
+
+// NewCassetteRecorder creates a cassette recording the interactions with HubSpot to path, replacing any recorded before,
+// with the values of the given fields redacted
+func NewCassetteRecorder(path string, redactedFields ...string) *Cassette {
+	return &Cassette{
+		Path:           path,
+		Mode:           CassetteRecord,
+		Client:         HTTPClient{},
+		RedactedFields: redactedFields,
+	}
+}
+
+// LoadCassette loads the interactions recorded in path to replay them, with the fields that were redacted when recording them
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while reading cassette '%s': %s", path, err.Error()))
+	}
+
+	var file cassetteFile
+	err = json.Unmarshal(data, &file)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while reading cassette '%s': %s", path, err.Error()))
+	}
+
+	return &Cassette{
+		Path:           path,
+		Mode:           CassetteReplay,
+		RedactedFields: file.RedactedFields,
+		interactions:   file.Interactions,
+		replayed:       make([]bool, len(file.Interactions)),
+	}, nil
+}
+
+// Interactions returns the interactions recorded in the cassette
+func (c *Cassette) Interactions() []CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	interactions := make([]CassetteInteraction, len(c.interactions))
+	copy(interactions, c.interactions)
+	return interactions
+}
+
+// Unplayed returns the recorded interactions that have not been replayed, e.g. to check that a test made all the requests it recorded
+func (c *Cassette) Unplayed() []CassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unplayed := []CassetteInteraction{}
+	for i, interaction := range c.interactions {
+		if !c.replayed[i] {
+			unplayed = append(unplayed, interaction)
+		}
+	}
+	return unplayed
+}
+
+// Get makes a GET request to a given URL
+func (c *Cassette) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(req)
+}
+
+// Do records or replays a request, depending on the mode of the cassette
+func (c *Cassette) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	request := c.recordRequest(req, body)
+
+	if c.Mode == CassetteRecord {
+		return c.record(req, request)
+	}
+	return c.replay(req, request)
+}
+
+// record makes the request and appends it with its response to the cassette file, the response is returned unredacted
+func (c *Cassette) record(req *http.Request, request CassetteRequest) (*http.Response, error) {
+	client := c.Client
+	if client == nil {
+		client = HTTPClient{}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	response := CassetteResponse{StatusCode: resp.StatusCode, Header: http.Header{}}
+	for name, values := range resp.Header {
+		if http.CanonicalHeaderKey(name) == "Content-Length" {
+			// The length of the body changes when it is redacted
+			continue
+		}
+		if isCredentialHeader(name) {
+			response.Header[name] = []string{redacted}
+		} else {
+			response.Header[name] = values
+		}
+	}
+	response.Body, response.BodyBase64 = encodeCassetteBody(c.redactBody(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.interactions = append(c.interactions, CassetteInteraction{Request: request, Response: response})
+	c.replayed = append(c.replayed, false)
+
+	data, err := json.MarshalIndent(cassetteFile{RedactedFields: c.RedactedFields, Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	err = writeFileAtomic(c.Path, data)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while writing cassette '%s': %s", c.Path, err.Error()))
+	}
+
+	return resp, nil
+}
+
+// replay returns the response of the first recorded interaction matching the request that has not been replayed yet
+func (c *Cassette) replay(req *http.Request, request CassetteRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, interaction := range c.interactions {
+		if c.replayed[i] || !interaction.Request.matches(request) {
+			continue
+		}
+		c.replayed[i] = true
+
+		body, err := decodeCassetteBody(interaction.Response.Body, interaction.Response.BodyBase64)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Error while reading the response of %s %s from cassette '%s': %s", request.Method, request.URL, c.Path, err.Error()))
+		}
+
+		header := http.Header{}
+		for name, values := range interaction.Response.Header {
+			header[name] = values
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	err := &CassetteMismatchError{Path: c.Path, Request: request}
+	log.Errorf("%s", err.Error())
+	return nil, err
+}
+
+// recordRequest returns the redacted representation of a request, as it is recorded and matched
+func (c *Cassette) recordRequest(req *http.Request, body []byte) CassetteRequest {
+	requestUrl := c.redactURL(*req.URL)
+
+	mediaType, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err == nil && strings.HasPrefix(mediaType, "multipart/") && params["boundary"] != "" {
+		multipartBody, err := c.redactMultipartBody(body, params["boundary"])
+		if err != nil {
+			log.Warnf("Redacting the whole body of %s %s, it could not be read as multipart: %s", req.Method, requestUrl.Path, err.Error())
+			multipartBody = []byte(redacted)
+		}
+		body = multipartBody
+	} else {
+		body = c.redactBody(body)
+	}
+
+	request := CassetteRequest{Method: req.Method, URL: requestUrl.String()}
+	request.Body, request.BodyBase64 = encodeCassetteBody(body)
+
+	return request
+}
+
+// matches returns whether a request matches the recorded one, on their method, path, query and body
+func (r CassetteRequest) matches(request CassetteRequest) bool {
+	if r.Method != request.Method || r.Body != request.Body || r.BodyBase64 != request.BodyBase64 {
+		return false
+	}
+
+	recordedUrl, err := url.Parse(r.URL)
+	if err != nil {
+		return false
+	}
+	requestUrl, err := url.Parse(request.URL)
+	if err != nil {
+		return false
+	}
+
+	return recordedUrl.Path == requestUrl.Path && recordedUrl.Query().Encode() == requestUrl.Query().Encode()
+}
+
+// redactURL redacts the user, the credential parameters and the redacted fields from the query of a URL
+func (c *Cassette) redactURL(u url.URL) url.URL {
+	query := u.Query()
+	for name := range query {
+		if isCredentialParameter(name) || c.isRedacted(name) {
+			for i := range query[name] {
+				query[name][i] = redacted
+			}
+		}
+	}
+	u.RawQuery = query.Encode()
+	u.User = nil
+	return u
+}
+
+// isCredentialParameter returns whether a query parameter carries credentials, the API key of HubSpot requests,
+// or the signature, credential and token of pre-signed URLs to other hosts, e.g. the export files DownloadExport requests
+func isCredentialParameter(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "hapikey", "sig", "key-pair-id", "policy":
+		return true
+	}
+	for _, credential := range []string{"signature", "credential", "token"} {
+		if strings.Contains(name, credential) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCredentialHeader returns whether a header carries credentials
+func isCredentialHeader(name string) bool {
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Cookie", "Set-Cookie":
+		return true
+	}
+	return false
+}
+
+func (c *Cassette) isRedacted(name string) bool {
+	for _, field := range c.RedactedFields {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBody redacts the fields of a JSON body, and returns it in a canonical form so that equal bodies can be matched,
+// other bodies are returned unchanged
+func (c *Cassette) redactBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return body
+	}
+
+	redactedBody, err := json.Marshal(c.redactValue(value))
+	if err != nil {
+		return body
+	}
+	return redactedBody
+}
+
+// redactMultipartBody redacts the parts of a multipart body, and writes them again separated by multipartBoundary
+// so that equal bodies can be matched whatever their random boundary.
+// The contents of files, e.g. the CSV files of imports, are always redacted, the form fields named after a redacted field
+// are redacted, and the JSON of other form fields is redacted like a JSON body, e.g. the "importRequest" of imports.
+func (c *Cassette) redactMultipartBody(body []byte, boundary string) ([]byte, error) {
+	var data bytes.Buffer
+	writer := multipart.NewWriter(&data)
+	err := writer.SetBoundary(multipartBoundary)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		content, err := ioutil.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		if part.FileName() != "" || c.isRedacted(part.FormName()) {
+			content = []byte(redacted)
+		} else {
+			content = c.redactBody(content)
+		}
+
+		partWriter, err := writer.CreatePart(part.Header)
+		if err != nil {
+			return nil, err
+		}
+		_, err = partWriter.Write(content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return data.Bytes(), nil
+}
+
+// redactValue redacts the JSON fields named after a redacted field, and the values of the HubSpot properties,
+// filters and form values named after one, e.g. {"name": "email", "value": "..."} or {"propertyName": "email", "value": "..."}
+// URLs are redacted like request URLs, e.g. the pre-signed URL of a complete export.
+func (c *Cassette) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if !strings.HasPrefix(v, "https://") && !strings.HasPrefix(v, "http://") || !strings.Contains(v, "?") {
+			return v
+		}
+		u, err := url.Parse(v)
+		if err != nil {
+			return v
+		}
+		redactedUrl := c.redactURL(*u)
+		if redactedUrl.RawQuery == u.Query().Encode() && u.User == nil {
+			return v
+		}
+		return redactedUrl.String()
+	case map[string]interface{}:
+		namedRedacted := false
+		for _, key := range []string{"name", "propertyName"} {
+			if name, ok := v[key].(string); ok && c.isRedacted(name) {
+				namedRedacted = true
+			}
+		}
+
+		for key, field := range v {
+			switch {
+			case c.isRedacted(key):
+				v[key] = redacted
+			case namedRedacted && (key == "value" || key == "values" || key == "highValue"):
+				v[key] = redacted
+			default:
+				v[key] = c.redactValue(field)
+			}
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = c.redactValue(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// encodeCassetteBody returns a body as a string, base64 encoded unless it is valid UTF-8
+func encodeCassetteBody(body []byte) (string, bool) {
+	if utf8.Valid(body) {
+		return string(body), false
+	}
+	return base64.StdEncoding.EncodeToString(body), true
+}
+
+func decodeCassetteBody(body string, isBase64 bool) ([]byte, error) {
+	if isBase64 {
+		return base64.StdEncoding.DecodeString(body)
+	}
+	return []byte(body), nil
+}
@@ -0,0 +1,311 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "contacts.json")
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "POST https://api.hubapi.com/crm/v3/objects/contacts/search?hapikey=secret_key":
+				w.Header().Set("Set-Cookie", "session=secret_session")
+				writeJSONResponse(t, w, 200, HubSpotSearchResponse{
+					Total:   1,
+					Results: []HubSpotSearchResult{{Id: "101", Properties: map[string]string{"email": "alice@example.com", "firstname": "Alice"}}},
+				})
+			case "GET https://api.hubapi.com/form-integrations/v1/submissions/forms/form_id?hapikey=secret_key&limit=50&after=":
+				writeJSONResponse(t, w, 200, HubspotResponse{Results: []Submission{
+					{SubmittedAt: 1, Values: []FormValue{{Name: "email", Value: "bob@example.com"}, {Name: "company", Value: "Globex"}}},
+				}})
+			case "POST https://api.hubapi.com/files/v3/files?hapikey=secret_key":
+				writeJSONResponse(t, w, 201, FileUploadResponse{Id: "42"})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	recorder := NewCassetteRecorder(path, "email")
+	recorder.Client = &mockHubspotHTTPClient
+
+	crm := NewHubspotCRMAPIWithClient("secret_key", recorder)
+	contacts, err := crm.SearchContacts(map[string]string{"email": "alice@example.com"}, []string{"email", "firstname"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if contacts[0].Properties["email"] != "alice@example.com" {
+		t.Errorf("Expected recorded responses to be returned unredacted, got %v", contacts)
+	}
+
+	_, err = NewHubspotFormAPIWithClient("form_id", "secret_key", recorder).Query("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	_, err = NewHubspotFileAPIWithClient("secret_key", "portal_id", recorder).UploadFile([]byte("content"), "/folder", "file.txt")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	for _, secret := range []string{"secret_key", "secret_session", "alice@example.com", "bob@example.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %s to be redacted from the cassette:\n%s", secret, data)
+		}
+	}
+
+	// Replaying with another API key returns the recorded responses, with the redacted fields
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if !cmp.Equal([]string{"email"}, cassette.RedactedFields) {
+		t.Errorf("Expected the redacted fields to be loaded from the cassette, got %v", cassette.RedactedFields)
+	}
+
+	crm = NewHubspotCRMAPIWithClient("other_key", cassette)
+	contacts, err = crm.SearchContacts(map[string]string{"email": "alice@example.com"}, []string{"email", "firstname"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	expectedContacts := []HubSpotSearchResult{{Id: "101", Properties: map[string]string{"email": "REDACTED", "firstname": "Alice"}}}
+	if !cmp.Equal(expectedContacts, contacts) {
+		t.Errorf("Unexpected contacts, expected:\n%v\ngot:\n%v", expectedContacts, contacts)
+	}
+
+	submissions, err := NewHubspotFormAPIWithClient("form_id", "other_key", cassette).Query("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	expectedValues := []FormValue{{Name: "email", Value: "REDACTED"}, {Name: "company", Value: "Globex"}}
+	if !cmp.Equal(expectedValues, submissions.Results[0].Values) {
+		t.Errorf("Unexpected submission values, expected:\n%v\ngot:\n%v", expectedValues, submissions.Results[0].Values)
+	}
+
+	if len(cassette.Unplayed()) != 1 {
+		t.Errorf("Unexpected unplayed interactions %v", cassette.Unplayed())
+	}
+
+	// Multipart bodies match despite their random boundaries
+	url, err := NewHubspotFileAPIWithClient("other_key", "portal_id", cassette).UploadFile([]byte("content"), "/folder", "file.txt")
+	if err != nil || url != "https://app.hubspot.com/file-preview/portal_id/file/42" {
+		t.Errorf("Unexpected upload result %s, %v", url, err)
+	}
+
+	// Each interaction is replayed once, and unmatched requests fail
+	_, err = crm.SearchContacts(map[string]string{"email": "alice@example.com"}, []string{"email", "firstname"})
+	var mismatchErr *CassetteMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Errorf("Expected a mismatch error, got %v", err)
+	}
+
+	_, err = crm.SearchContacts(map[string]string{"firstname": "Alice"}, []string{"email", "firstname"})
+	if !errors.As(err, &mismatchErr) || mismatchErr.Request.Method != "POST" {
+		t.Errorf("Expected a mismatch error, got %v", err)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 3 {
+		t.Errorf("Expected HubSpot to be called only while recording, got %d calls", len(mockHubspotHTTPClient.DoCalls()))
+	}
+}
+
+func TestCassetteRequestMatches(t *testing.T) {
+	recorded := CassetteRequest{Method: "GET", URL: "https://api.hubapi.com/crm/v3/objects/deals?hapikey=REDACTED&limit=100&properties=dealname"}
+
+	matching := []CassetteRequest{
+		recorded,
+		{Method: "GET", URL: "https://api.hubapi.com/crm/v3/objects/deals?properties=dealname&limit=100&hapikey=REDACTED"},
+	}
+	for _, request := range matching {
+		if !recorded.matches(request) {
+			t.Errorf("Expected %v to match", request)
+		}
+	}
+
+	notMatching := []CassetteRequest{
+		{Method: "POST", URL: recorded.URL},
+		{Method: "GET", URL: "https://api.hubapi.com/crm/v3/objects/companies?hapikey=REDACTED&limit=100&properties=dealname"},
+		{Method: "GET", URL: "https://api.hubapi.com/crm/v3/objects/deals?hapikey=REDACTED&limit=100&properties=amount"},
+		{Method: "GET", URL: recorded.URL, Body: "{}"},
+	}
+	for _, request := range notMatching {
+		if recorded.matches(request) {
+			t.Errorf("Expected %v not to match", request)
+		}
+	}
+}
+
+func TestCassetteRedactsMultipartBodies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "imports.json")
+
+	boundaries := []string{}
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+			if err != nil {
+				t.Errorf("Invalid content type: %s", err.Error())
+			}
+			boundaries = append(boundaries, params["boundary"])
+
+			w := httptest.NewRecorder()
+			_, _ = fmt.Fprint(w, `{"id": "importId", "state": "STARTED"}`)
+			return w.Result(), nil
+		},
+	}
+
+	importRequest := func(name, content, operation string) ImportRequest {
+		return ImportRequest{
+			Name:       name,
+			Operations: map[string]string{"contacts": operation},
+			Files: []ImportFile{{
+				FileName:       "contacts.csv",
+				Content:        strings.NewReader(content),
+				ColumnMappings: []ImportColumnMapping{{ColumnName: "Email", ObjectType: "contacts", PropertyName: "email"}},
+			}},
+		}
+	}
+
+	recorder := NewCassetteRecorder(path, "name")
+	recorder.Client = &mockHubspotHTTPClient
+	_, err = NewHubspotImportAPIWithClient("secret_key", recorder).StartImport(importRequest("Contacts of Carol", "Email\ncarol@example.com\n", "CREATE"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	for _, secret := range []string{"carol@example.com", "Contacts of Carol", boundaries[0]} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %s to be redacted from the cassette:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), multipartBoundary) || !strings.Contains(string(data), "contacts.csv") {
+		t.Errorf("Expected the parts to be recorded with the cassette boundary:\n%s", data)
+	}
+
+	// Bodies with another boundary, import name and file content match, other import requests don't
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	api := NewHubspotImportAPIWithClient("other_key", cassette)
+
+	_, err = api.StartImport(importRequest("Contacts of Carol", "Email\ncarol@example.com\n", "UPSERT"))
+	var mismatchErr *CassetteMismatchError
+	if !errors.As(err, &mismatchErr) {
+		t.Errorf("Expected a mismatch error, got %v", err)
+	}
+
+	started, err := api.StartImport(importRequest("Contacts of Dave", "Email\ndave@example.com\n", "CREATE"))
+	if err != nil || started.Id != "importId" {
+		t.Errorf("Unexpected import %v, %v", started, err)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 1 {
+		t.Errorf("Expected HubSpot to be called only while recording, got %d calls", len(mockHubspotHTTPClient.DoCalls()))
+	}
+}
+
+func TestCassetteRedactsPresignedURLs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "exports.json")
+	downloadUrl := "https://hubspot-exports.s3.amazonaws.com/export.csv?X-Amz-Algorithm=AWS4-HMAC-SHA256&X-Amz-Credential=AKIASECRET%2F20210630&X-Amz-Date=20210630T120000Z&X-Amz-Expires=3600&X-Amz-Security-Token=secret_token&X-Amz-SignedHeaders=host&X-Amz-Signature=secret_signature"
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/exports/export/async/tasks/taskId/status?hapikey=secret_key":
+				writeJSONResponse(t, w, 200, ExportTask{Id: "taskId", Status: ExportComplete, Result: downloadUrl})
+			case "GET " + downloadUrl:
+				_, _ = fmt.Fprint(w, "Name\nAcme\n")
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	download := func(api HubspotExportAPI) string {
+		task, err := api.GetExport("taskId")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+
+		var file strings.Builder
+		_, err = api.DownloadExport(*task, &file)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err.Error())
+		}
+		return file.String()
+	}
+
+	recorder := NewCassetteRecorder(path)
+	recorder.Client = &mockHubspotHTTPClient
+	download(NewHubspotExportAPIWithClient("secret_key", recorder))
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	for _, secret := range []string{"secret_key", "AKIASECRET", "secret_token", "secret_signature"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %s to be redacted from the cassette:\n%s", secret, data)
+		}
+	}
+
+	// The redacted URL of the replayed export matches the recorded download
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if file := download(NewHubspotExportAPIWithClient("other_key", cassette)); file != "Name\nAcme\n" {
+		t.Errorf("Unexpected export file %s", file)
+	}
+}
@@ -0,0 +1,292 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	log "github.com/sirupsen/logrus"
+)
+
+// Defaults of ChangeFeed
+const (
+	defaultChangeFeedClockSkew    = time.Minute
+	defaultChangeFeedPollInterval = time.Minute
+)
+
+// Change is a record created or updated since the previous poll of a change feed
+// Created is true when the record was created after the checkpoint of the feed, i.e. it was never emitted before.
+type Change struct {
+	Record       HubSpotSearchResult
+	Created      bool
+	LastModified time.Time
+}
+
+// ChangeFeed polls HubSpot search for the records of an object type modified since its checkpoint,
+// and emits them in the order they were modified. The checkpoint, the modification time and id of the last record
+// emitted, is saved in Store under Name, so that the feed resumes where it stopped. Without a checkpoint the feed
+// starts from Since, or from the first record when Since is zero.
+//
+// HubSpot indexes records for search with a delay and the clocks of HubSpot and the caller may differ,
+// so only the records modified more than ClockSkew ago are emitted, later ones are emitted by the next polls.
+// Searches return at most 10,000 results, the feed searches again from the last modification time it reached
+// until it catches up.
+type ChangeFeed struct {
+	Name         string
+	ObjectType   string
+	Properties   []string
+	Store        CheckpointStore
+	Since        time.Time
+	ClockSkew    time.Duration
+	PollInterval time.Duration
+
+	apiKey     string
+	httpClient IHTTPClient
+	now        func() time.Time
+	// flush is called before each checkpoint is saved, the checkpoint is not saved when it fails
+	flush func() error
+}
+
+// ChangeFeed creates a feed of the records of an object type created or updated in HubSpot, with the given properties,
+// keeping its checkpoint in store
+func (api HubspotCRMAPI) ChangeFeed(objectType string, properties []string, store CheckpointStore) *ChangeFeed {
+	return &ChangeFeed{
+		Name:         objectType,
+		ObjectType:   objectType,
+		Properties:   properties,
+		Store:        store,
+		ClockSkew:    defaultChangeFeedClockSkew,
+		PollInterval: defaultChangeFeedPollInterval,
+		apiKey:       api.APIKey,
+		httpClient:   api.httpClient,
+		now:          time.Now,
+	}
+}
+
+// compareRecordIds compares numeric record ids by value, and other ids as strings
+func compareRecordIds(a, b string) int {
+	if len(a) != len(b) && isDigits(a) && isDigits(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+
+	return strings.Compare(a, b)
+}
+
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// after returns whether a change comes after the checkpoint
+func (checkpoint ChangeCheckpoint) after(change Change) bool {
+	if !change.LastModified.Equal(checkpoint.LastModified) {
+		return change.LastModified.After(checkpoint.LastModified)
+	}
+	return compareRecordIds(change.Record.Id, checkpoint.Id) > 0
+}
+
+// Poll emits the records modified since the checkpoint to callback, in the order they were modified, and returns
+// the number of records emitted. The checkpoint advances past each record callback succeeds for, so the record
+// callback fails for is emitted again by the next poll.
+func (f *ChangeFeed) Poll(callback func(Change) error) (int, error) {
+	now := time.Now
+	if f.now != nil {
+		now = f.now
+	}
+
+	stored, err := f.Store.LoadCheckpoint(f.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	checkpoint := ChangeCheckpoint{LastModified: f.Since}
+	if stored != nil {
+		checkpoint = *stored
+	}
+	start := checkpoint
+	// saved is the checkpoint held by the store, if any
+	saved, persisted := checkpoint, stored != nil
+	cutoff := now().Add(-f.ClockSkew)
+
+	modified := crm.LastModifiedProperty(f.ObjectType)
+	properties := []string{}
+	seen := map[string]bool{}
+	for _, name := range append(append([]string{}, f.Properties...), modified, "createdate") {
+		if !seen[name] {
+			seen[name] = true
+			properties = append(properties, name)
+		}
+	}
+
+	lower := checkpoint.LastModified
+	emitted := 0
+	for {
+		changes, next, err := f.search(modified, properties, lower, cutoff)
+		if err != nil {
+			return emitted, err
+		}
+
+		for _, change := range changes {
+			if !checkpoint.after(change) {
+				continue
+			}
+
+			created, err := parseHubSpotTime(change.Record.Properties["createdate"])
+			change.Created = (stored == nil && f.Since.IsZero()) || (err == nil && start.after(Change{Record: change.Record, LastModified: created}))
+
+			err = callback(change)
+			if err != nil {
+				return emitted, f.save(persisted, saved, checkpoint, err)
+			}
+
+			checkpoint = ChangeCheckpoint{LastModified: change.LastModified, Id: change.Record.Id}
+			emitted++
+		}
+
+		err = f.save(persisted, saved, checkpoint, nil)
+		if err != nil {
+			return emitted, err
+		}
+		saved, persisted = checkpoint, true
+
+		if next.IsZero() {
+			return emitted, nil
+		}
+
+		log.Infof("Reached the search limit of %d %s, searching again from %s", crm.SearchResultLimit, f.ObjectType, next)
+		lower = next
+	}
+}
+
+// save stores the checkpoint unless the store already holds it, and returns callbackErr unless saving failed
+func (f *ChangeFeed) save(persisted bool, saved, checkpoint ChangeCheckpoint, callbackErr error) error {
+	if !persisted || !saved.equal(checkpoint) {
+		if f.flush != nil {
+			err := f.flush()
+			if err != nil {
+				return err
+			}
+		}
+
+		err := f.Store.SaveCheckpoint(f.Name, checkpoint)
+		if err != nil {
+			return err
+		}
+	}
+
+	return callbackErr
+}
+
+// search returns the records modified between lower and upper, sorted by modification time and id
+// When the search limit is reached, the records modified at the last time reached are left out,
+// and that time is returned to search again from it, otherwise the returned time is zero.
+func (f *ChangeFeed) search(modified string, properties []string, lower, upper time.Time) ([]Change, time.Time, error) {
+	filters := []SearchFilter{{PropertyName: modified, Operator: "LTE", Value: formatHubSpotTime(upper)}}
+	if !lower.IsZero() {
+		filters = append(filters, SearchFilter{PropertyName: modified, Operator: "GTE", Value: formatHubSpotTime(lower)})
+	}
+
+	it := newSearchIterator(f.apiKey, f.httpClient, f.ObjectType, SearchQuery{
+		Filters:    filters,
+		Sorts:      []SearchSort{{PropertyName: modified, Direction: "ASCENDING"}},
+		Properties: properties,
+	})
+
+	changes := []Change{}
+	truncated := false
+	for it.Next() {
+		record := it.Result()
+		lastModified, err := parseHubSpotTime(record.Properties[modified])
+		if err != nil {
+			return nil, time.Time{}, errors.New(fmt.Sprintf("Invalid %s '%s' of %s %s", modified, record.Properties[modified], f.ObjectType, record.Id))
+		}
+
+		changes = append(changes, Change{Record: record, LastModified: lastModified})
+		if len(changes) >= crm.SearchResultLimit {
+			truncated = true
+			break
+		}
+	}
+	if it.Err() != nil {
+		return nil, time.Time{}, it.Err()
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		if !changes[i].LastModified.Equal(changes[j].LastModified) {
+			return changes[i].LastModified.Before(changes[j].LastModified)
+		}
+		return compareRecordIds(changes[i].Record.Id, changes[j].Record.Id) < 0
+	})
+
+	if !truncated {
+		return changes, time.Time{}, nil
+	}
+
+	// The records modified at the last time reached may continue past the limit, they are searched again from that time
+	last := changes[len(changes)-1].LastModified
+	end := len(changes)
+	for end > 0 && changes[end-1].LastModified.Equal(last) {
+		end--
+	}
+	if end == 0 {
+		return nil, time.Time{}, errors.New(fmt.Sprintf("More than %d %s were modified at %s", crm.SearchResultLimit, f.ObjectType, last))
+	}
+
+	return changes[:end], last, nil
+}
+
+// Run polls the feed every PollInterval and emits the changes to callback, until stop is closed
+// Errors are logged, and the records that failed are emitted again by the next poll.
+func (f *ChangeFeed) Run(callback func(Change) error, stop <-chan struct{}) {
+	interval := f.PollInterval
+	if interval <= 0 {
+		interval = defaultChangeFeedPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := f.Poll(callback)
+		if err != nil {
+			log.Errorf("Failed to poll the %s change feed: %s", f.Name, err.Error())
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Changes runs the feed in the background and sends the changes to the returned channel until stop is closed,
+// the channel is then closed. The checkpoint advances once a change is received from the channel.
+func (f *ChangeFeed) Changes(stop <-chan struct{}) <-chan Change {
+	changes := make(chan Change)
+
+	go func() {
+		defer close(changes)
+
+		f.Run(func(change Change) error {
+			select {
+			case changes <- change:
+				return nil
+			case <-stop:
+				return errors.New("The change feed was stopped")
+			}
+		}, stop)
+	}()
+
+	return changes
+}
@@ -0,0 +1,132 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+// ChangeCheckpoint is the position of a change feed, the last modification time and id of the last record emitted
+type ChangeCheckpoint struct {
+	LastModified time.Time `json:"lastModified"`
+	Id           string    `json:"id"`
+}
+
+// equal returns whether two checkpoints are at the same record, whatever the locations of their times
+func (c ChangeCheckpoint) equal(other ChangeCheckpoint) bool {
+	return c.Id == other.Id && c.LastModified.Equal(other.LastModified)
+}
+
+// CheckpointStore persists the checkpoints of change feeds by name, implementations must be safe for concurrent use
+type CheckpointStore interface {
+	// LoadCheckpoint returns the checkpoint of the named feed, or nil if it has none
+	LoadCheckpoint(name string) (*ChangeCheckpoint, error)
+	// SaveCheckpoint stores the checkpoint of the named feed
+	SaveCheckpoint(name string, checkpoint ChangeCheckpoint) error
+}
+
+// MemoryCheckpointStore is a CheckpointStore keeping checkpoints in memory, feeds start over when the process restarts
+type MemoryCheckpointStore struct {
+	mu          sync.Mutex
+	checkpoints map[string]ChangeCheckpoint
+}
+
+// NewMemoryCheckpointStore creates an empty MemoryCheckpointStore
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{checkpoints: map[string]ChangeCheckpoint{}}
+}
+
+// LoadCheckpoint returns the checkpoint of the named feed, or nil if it has none
+func (s *MemoryCheckpointStore) LoadCheckpoint(name string) (*ChangeCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint, ok := s.checkpoints[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint stores the checkpoint of the named feed
+func (s *MemoryCheckpointStore) SaveCheckpoint(name string, checkpoint ChangeCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkpoints[name] = checkpoint
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore keeping the checkpoints of all feeds in a JSON file
+type FileCheckpointStore struct {
+	mu          sync.Mutex
+	path        string
+	checkpoints map[string]ChangeCheckpoint
+}
+
+// NewFileCheckpointStore creates a FileCheckpointStore with the checkpoints stored in the file at path, if it exists
+func NewFileCheckpointStore(path string) (*FileCheckpointStore, error) {
+	store := FileCheckpointStore{path: path, checkpoints: map[string]ChangeCheckpoint{}}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &store.checkpoints)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while reading checkpoints from '%s': %s", path, err.Error()))
+	}
+	if store.checkpoints == nil {
+		store.checkpoints = map[string]ChangeCheckpoint{}
+	}
+
+	return &store, nil
+}
+
+// LoadCheckpoint returns the checkpoint of the named feed, or nil if it has none
+func (s *FileCheckpointStore) LoadCheckpoint(name string) (*ChangeCheckpoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkpoint, ok := s.checkpoints[name]
+	if !ok {
+		return nil, nil
+	}
+
+	return &checkpoint, nil
+}
+
+// SaveCheckpoint stores the checkpoint of the named feed
+func (s *FileCheckpointStore) SaveCheckpoint(name string, checkpoint ChangeCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The checkpoint is kept in memory only once it is written
+	checkpoints := make(map[string]ChangeCheckpoint, len(s.checkpoints)+1)
+	for feed, saved := range s.checkpoints {
+		checkpoints[feed] = saved
+	}
+	checkpoints[name] = checkpoint
+
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(s.path, data)
+	if err != nil {
+		return err
+	}
+
+	s.checkpoints = checkpoints
+	return nil
+}
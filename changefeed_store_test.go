@@ -0,0 +1,92 @@
+package go_hubspot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "checkpoints.json")
+
+	store, err := NewFileCheckpointStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	checkpoint, err := store.LoadCheckpoint("companies")
+	if err != nil || checkpoint != nil {
+		t.Errorf("Unexpected checkpoint of a new feed %v, %v", checkpoint, err)
+	}
+
+	expected := ChangeCheckpoint{LastModified: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC), Id: "42"}
+	if err := store.SaveCheckpoint("companies", expected); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if err := store.SaveCheckpoint("deals", ChangeCheckpoint{Id: "1"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	reopened, err := NewFileCheckpointStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	checkpoint, err = reopened.LoadCheckpoint("companies")
+	if err != nil || !cmp.Equal(&expected, checkpoint) {
+		t.Errorf("Unexpected checkpoint, expected:\n%v\ngot:\n%v", expected, checkpoint)
+	}
+}
+
+func TestFileCheckpointStoreWriteFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "checkpoints")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileCheckpointStore(filepath.Join(dir, "checkpoints.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	saved := ChangeCheckpoint{LastModified: time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC), Id: "42"}
+	if err := store.SaveCheckpoint("companies", saved); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	// The store file cannot be written while its directory is missing
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveCheckpoint("companies", ChangeCheckpoint{LastModified: saved.LastModified.Add(time.Hour), Id: "43"}); err == nil {
+		t.Errorf("Expected an error saving the checkpoint")
+	}
+
+	checkpoint, err := store.LoadCheckpoint("companies")
+	if err != nil || !cmp.Equal(&saved, checkpoint) {
+		t.Errorf("Expected the checkpoint that failed to be written to be discarded, got %v, %v", checkpoint, err)
+	}
+}
+
+func TestChangeCheckpointEqual(t *testing.T) {
+	modified := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	checkpoint := ChangeCheckpoint{LastModified: modified, Id: "42"}
+
+	if !checkpoint.equal(ChangeCheckpoint{LastModified: modified.In(time.FixedZone("CET", 3600)), Id: "42"}) {
+		t.Errorf("Expected checkpoints at the same time in other locations to be equal")
+	}
+	if checkpoint.equal(ChangeCheckpoint{LastModified: modified, Id: "43"}) || checkpoint.equal(ChangeCheckpoint{LastModified: modified.Add(time.Millisecond), Id: "42"}) {
+		t.Errorf("Expected checkpoints at other records not to be equal")
+	}
+}
@@ -0,0 +1,233 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	"github.com/google/go-cmp/cmp"
+)
+
+// mockSearchClient serves HubSpot searches of companies from records, applying filters and sorts as HubSpot does,
+// and fails requests for results past the search limit
+func mockSearchClient(t *testing.T, records *[]HubSpotSearchResult, requests *int) *IHTTPClientMock {
+	return &IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url != "POST https://api.hubapi.com/crm/v3/objects/companies/search?hapikey=api_key" {
+				t.Errorf("Unexpected url %s", url)
+				return w.Result(), nil
+			}
+			*requests++
+
+			var request searchPageRequest
+			readJSONRequest(t, req, &request)
+
+			query := SearchQuery{Sorts: request.Sorts}
+			for _, group := range request.FilterGroups {
+				query.Filters = append(query.Filters, group.Filters...)
+			}
+
+			matches := []HubSpotSearchResult{}
+			for _, record := range *records {
+				if query.matches(record.Properties) {
+					matches = append(matches, record)
+				}
+			}
+			query.sortResults(matches)
+
+			offset := 0
+			if request.After != "" {
+				offset, _ = strconv.Atoi(request.After)
+			}
+			if offset >= crm.SearchResultLimit {
+				writeJSONResponse(t, w, 400, map[string]string{"message": "paging past the result limit"})
+				return w.Result(), nil
+			}
+
+			end := offset + request.Limit
+			response := searchPageResponse{Total: len(matches)}
+			if end < len(matches) {
+				response.Paging = &Paging{Next: map[string]string{"after": strconv.Itoa(end)}}
+			} else {
+				end = len(matches)
+			}
+			response.Results = matches[offset:end]
+
+			writeJSONResponse(t, w, 200, response)
+			return w.Result(), nil
+		},
+	}
+}
+
+func company(id string, created, modified time.Time) HubSpotSearchResult {
+	return HubSpotSearchResult{
+		Id: id,
+		Properties: map[string]string{
+			"name":                "Company " + id,
+			"createdate":          created.Format(time.RFC3339),
+			"hs_lastmodifieddate": modified.Format(time.RFC3339),
+		},
+	}
+}
+
+func TestChangeFeedPoll(t *testing.T) {
+	base := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := base.Add(time.Hour)
+
+	records := []HubSpotSearchResult{
+		company("10", base, base.Add(10*time.Minute)),
+		company("9", base, base.Add(10*time.Minute)),
+		company("2", base, base.Add(5*time.Minute)),
+		// Modified within the clock skew, emitted by a later poll
+		company("3", base, now.Add(-30*time.Second)),
+	}
+	requests := 0
+
+	api := HubspotCRMAPI{APIKey: "api_key", httpClient: mockSearchClient(t, &records, &requests)}
+	store := NewMemoryCheckpointStore()
+	feed := api.ChangeFeed("companies", []string{"name"}, store)
+	feed.now = func() time.Time { return now }
+
+	ids := func(changes []Change) []string {
+		result := []string{}
+		for _, change := range changes {
+			result = append(result, change.Record.Id)
+		}
+		return result
+	}
+
+	var changes []Change
+	collect := func(change Change) error {
+		changes = append(changes, change)
+		return nil
+	}
+
+	count, err := feed.Poll(collect)
+	if err != nil || count != 3 {
+		t.Fatalf("Unexpected Poll result %d, %v", count, err)
+	}
+	if !cmp.Equal([]string{"2", "9", "10"}, ids(changes)) {
+		t.Errorf("Unexpected changes %v", ids(changes))
+	}
+	if !changes[0].Created || changes[0].Record.Properties["name"] != "Company 2" {
+		t.Errorf("Unexpected change %v", changes[0])
+	}
+
+	checkpoint, _ := store.LoadCheckpoint("companies")
+	expectedCheckpoint := ChangeCheckpoint{LastModified: base.Add(10 * time.Minute), Id: "10"}
+	if !cmp.Equal(&expectedCheckpoint, checkpoint) {
+		t.Errorf("Unexpected checkpoint, expected:\n%v\ngot:\n%v", expectedCheckpoint, checkpoint)
+	}
+
+	// Record 9 is updated and record 11 created, both at the time of the checkpoint, the tie is broken by id
+	records[1] = company("9", base, base.Add(20*time.Minute))
+	records = append(records, company("11", base.Add(10*time.Minute), base.Add(10*time.Minute)))
+	now = now.Add(time.Minute)
+
+	changes = nil
+	count, err = feed.Poll(collect)
+	if err != nil || count != 3 {
+		t.Fatalf("Unexpected Poll result %d, %v", count, err)
+	}
+	if !cmp.Equal([]string{"11", "9", "3"}, ids(changes)) {
+		t.Errorf("Unexpected changes %v", ids(changes))
+	}
+	if !changes[0].Created || changes[1].Created {
+		t.Errorf("Unexpected created flags %v", changes)
+	}
+
+	// A failing callback leaves the checkpoint before the record, so that it is emitted again
+	records = append(records, company("12", base, now))
+	now = now.Add(time.Minute)
+
+	_, err = feed.Poll(func(change Change) error { return errors.New("unavailable") })
+	if err == nil {
+		t.Errorf("Expected the error of the callback")
+	}
+
+	changes = nil
+	count, err = feed.Poll(collect)
+	if err != nil || count != 1 || changes[0].Record.Id != "12" {
+		t.Errorf("Unexpected Poll result %d, %v, %v", count, err, ids(changes))
+	}
+}
+
+func TestChangeFeedSearchLimit(t *testing.T) {
+	base := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// More records than a single search can return, by groups modified at the same time
+	records := []HubSpotSearchResult{}
+	for i := 0; i < crm.SearchResultLimit+250; i++ {
+		records = append(records, company(strconv.Itoa(i+1), base, base.Add(time.Duration(i/7)*time.Second)))
+	}
+	requests := 0
+
+	api := HubspotCRMAPI{APIKey: "api_key", httpClient: mockSearchClient(t, &records, &requests)}
+	store := NewMemoryCheckpointStore()
+	feed := api.ChangeFeed("companies", nil, store)
+	feed.now = func() time.Time { return base.Add(24 * time.Hour) }
+
+	seen := map[string]bool{}
+	previous := ChangeCheckpoint{}
+	count, err := feed.Poll(func(change Change) error {
+		if seen[change.Record.Id] {
+			t.Errorf("Record %s emitted twice", change.Record.Id)
+		}
+		seen[change.Record.Id] = true
+
+		if !previous.after(change) {
+			t.Errorf("Record %s emitted out of order", change.Record.Id)
+		}
+		previous = ChangeCheckpoint{LastModified: change.LastModified, Id: change.Record.Id}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if count != len(records) || len(seen) != len(records) {
+		t.Errorf("Unexpected number of records emitted %d, expected %d", count, len(records))
+	}
+	if requests <= crm.SearchResultLimit/searchPageLimit {
+		t.Errorf("Expected the feed to search again past the limit, made %d requests", requests)
+	}
+
+	// The checkpoint is saved after every search, not only the first one
+	checkpoint, _ := store.LoadCheckpoint(feed.Name)
+	if checkpoint == nil || *checkpoint != previous {
+		t.Errorf("Unexpected checkpoint %v, expected %v", checkpoint, previous)
+	}
+}
+
+func TestChangeFeedChanges(t *testing.T) {
+	base := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	records := []HubSpotSearchResult{company("1", base, base), company("2", base, base.Add(time.Second))}
+	requests := 0
+
+	api := HubspotCRMAPI{APIKey: "api_key", httpClient: mockSearchClient(t, &records, &requests)}
+	feed := api.ChangeFeed("companies", nil, NewMemoryCheckpointStore())
+	feed.now = func() time.Time { return base.Add(time.Hour) }
+	feed.PollInterval = time.Millisecond
+
+	stop := make(chan struct{})
+	changes := feed.Changes(stop)
+
+	first := <-changes
+	second := <-changes
+	if first.Record.Id != "1" || second.Record.Id != "2" {
+		t.Errorf("Unexpected changes %v, %v", first, second)
+	}
+
+	close(stop)
+	for range changes {
+	}
+}
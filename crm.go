@@ -16,6 +16,18 @@ type IHubspotCRMAPI interface {
 	GetDealForCompany(companyID string) (string, error)
 	SearchContacts(filterMap map[string]string, properties []string) ([]HubSpotSearchResult, error)
 	SearchCompanies(filterMap map[string]string, properties []string) ([]HubSpotSearchResult, error)
+	Search(objectType string, query SearchQuery) *SearchIterator
+	MergeObjects(objectType, primaryId, mergeId string) (*HubSpotSearchResult, error)
+	FindDuplicateContacts() (*MergePlan, error)
+	FindDuplicateCompanies() (*MergePlan, error)
+	ExecuteMergePlan(plan MergePlan) []MergeOutcome
+	ListArchived(objectType string, properties []string) ([]ArchivedObject, error)
+	SearchArchived(objectType string, query SearchQuery) ([]ArchivedObject, error)
+	RecreateArchived(objectType string, ids []string) ([]RecreateOutcome, error)
+	GetPropertyHistory(objectType, objectId string, properties []string) (*PropertyHistory, error)
+	ChangeFeed(objectType string, properties []string, store CheckpointStore) *ChangeFeed
+	Mirror(store MirrorStore, checkpoints CheckpointStore, objects ...MirrorObject) *Mirror
+	SyncObjects(local LocalSystem, baseline MirrorStore, mapping SyncMapping, dryRun bool) (*SyncReport, error)
 }
 
 type HubspotCRMAPI struct {
@@ -60,9 +72,14 @@ type hubSpotSearchRequest struct {
 
 // NewHubspotCRMAPI creates new HubspotCRMAPI with form ID and API key
 func NewHubspotCRMAPI(apiKey string) HubspotCRMAPI {
+	return NewHubspotCRMAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotCRMAPIWithClient creates new HubspotCRMAPI with API key and HTTP client
+func NewHubspotCRMAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotCRMAPI {
 	return HubspotCRMAPI{
 		APIKey:     apiKey,
-		httpClient: HTTPClient{},
+		httpClient: httpClient,
 	}
 }
 
@@ -260,3 +277,8 @@ func (api HubspotCRMAPI) SearchHubSpot(objectType string, filterMap map[string]s
 
 	return hubspotResp.Results, nil
 }
+
+// Search returns an iterator over all the objects of an object type matching the search query
+func (api HubspotCRMAPI) Search(objectType string, query SearchQuery) *SearchIterator {
+	return newSearchIterator(api.APIKey, api.httpClient, objectType, query)
+}
@@ -0,0 +1,163 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	log "github.com/sirupsen/logrus"
+)
+
+// ArchivedObject is a representation of an archived CRM object
+type ArchivedObject struct {
+	Id         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+	ArchivedAt string            `json:"archivedAt"`
+	Archived   bool              `json:"archived"`
+}
+
+// RecreateOutcome is the result of recreating a single archived object, RecreatedId is the id of the object created in its place
+type RecreateOutcome struct {
+	ArchivedId  string
+	RecreatedId string
+	Err         error
+}
+
+// ListArchived returns all the archived objects of an object type with the given properties
+func (api HubspotCRMAPI) ListArchived(objectType string, properties []string) ([]ArchivedObject, error) {
+	log.Infof("Listing archived %s", objectType)
+
+	objects := crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: objectType}
+
+	raw, err := objects.list(properties, true)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := []ArchivedObject{}
+	err = unmarshalAll(raw, &archived)
+	if err != nil {
+		return nil, err
+	}
+
+	return archived, nil
+}
+
+// SearchArchived returns the archived objects of an object type matching the search query
+// HubSpot search does not cover archived objects, so all of them are listed and the query is applied to them locally.
+// The "archivedAt" pseudo property can be filtered and sorted on, e.g. to find the objects archived by a bulk operation.
+func (api HubspotCRMAPI) SearchArchived(objectType string, query SearchQuery) ([]ArchivedObject, error) {
+	names := append([]string{}, query.Properties...)
+	for _, filter := range query.Filters {
+		names = append(names, filter.PropertyName)
+	}
+	for _, searchSort := range query.Sorts {
+		names = append(names, searchSort.PropertyName)
+	}
+
+	properties := []string{}
+	seen := map[string]bool{"archivedAt": true}
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			properties = append(properties, name)
+		}
+	}
+
+	archived, err := api.ListArchived(objectType, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []HubSpotSearchResult{}
+	byId := map[string]ArchivedObject{}
+	for _, object := range archived {
+		searchable := map[string]string{"archivedAt": object.ArchivedAt}
+		for key, value := range object.Properties {
+			searchable[key] = value
+		}
+
+		if query.matches(searchable) {
+			byId[object.Id] = object
+			results = append(results, HubSpotSearchResult{Id: object.Id, Properties: searchable})
+		}
+	}
+
+	query.sortResults(results)
+
+	matching := make([]ArchivedObject, len(results))
+	for i, result := range results {
+		matching[i] = byId[result.Id]
+	}
+
+	return matching, nil
+}
+
+// RecreateArchived creates new objects of an object type with the writable properties of archived objects
+// HubSpot does not provide a way to unarchive objects through its API, so this is not a restore: the new objects
+// get new ids, which are returned in the outcomes, in order, for callers to update their references, and the
+// associations of the archived objects are not recreated.
+func (api HubspotCRMAPI) RecreateArchived(objectType string, ids []string) ([]RecreateOutcome, error) {
+	log.Infof("Recreating %d archived %s", len(ids), objectType)
+
+	definitions, err := getPropertyDefinitions(api.httpClient, api.APIKey, objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	writable := []string{}
+	for _, definition := range definitions {
+		if definition.writable() {
+			writable = append(writable, definition.Name)
+		}
+	}
+
+	objects := crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: objectType}
+
+	raw, _, err := objects.batchReadArchived(ids, writable)
+	if err != nil {
+		return nil, err
+	}
+
+	archived := map[string]ArchivedObject{}
+	for _, rawObject := range raw {
+		var object ArchivedObject
+		err = json.Unmarshal(rawObject, &object)
+		if err != nil {
+			return nil, err
+		}
+		archived[object.Id] = object
+	}
+
+	outcomes := make([]RecreateOutcome, len(ids))
+	for i, id := range ids {
+		outcomes[i].ArchivedId = id
+
+		object, ok := archived[id]
+		if !ok {
+			outcomes[i].Err = errors.New(fmt.Sprintf("There is no archived %s with id '%s'", crm.SingularObjectType(objectType), id))
+			continue
+		}
+
+		properties := map[string]string{}
+		for _, name := range writable {
+			if value := object.Properties[name]; value != "" {
+				properties[name] = value
+			}
+		}
+
+		var recreated HubSpotSearchResult
+		err = objects.create(objectCreationRequest{Properties: properties}, &recreated)
+		if err != nil {
+			outcomes[i].Err = fmt.Errorf("Failed to recreate %s '%s': %w", crm.SingularObjectType(objectType), id, err)
+			continue
+		}
+
+		outcomes[i].RecreatedId = recreated.Id
+	}
+
+	return outcomes, nil
+}
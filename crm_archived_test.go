@@ -0,0 +1,132 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchArchived(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "https://api.hubapi.com/crm/v3/objects/companies?archived=true&hapikey=api_key&limit=100&properties=name":
+				writeJSONResponse(t, w, 200, map[string]interface{}{
+					"results": []ArchivedObject{
+						{Id: "1", Properties: map[string]string{"name": "Old"}, ArchivedAt: "2021-05-01T10:00:00Z", Archived: true},
+						{Id: "2", Properties: map[string]string{"name": "Acme"}, ArchivedAt: "2021-06-01T10:00:05Z", Archived: true},
+					},
+					"paging": Paging{Next: map[string]string{"after": "2"}},
+				})
+			case "https://api.hubapi.com/crm/v3/objects/companies?after=2&archived=true&hapikey=api_key&limit=100&properties=name":
+				writeJSONResponse(t, w, 200, map[string]interface{}{
+					"results": []ArchivedObject{
+						{Id: "3", Properties: map[string]string{"name": "Globex"}, ArchivedAt: "2021-06-01T10:00:00Z", Archived: true},
+					},
+				})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockCRMAPI(&mockHubspotHTTPClient)
+
+	archived, err := api.SearchArchived("companies", SearchQuery{
+		Filters:    []SearchFilter{{PropertyName: "archivedAt", Operator: "GTE", Value: "2021-06-01T00:00:00Z"}},
+		Sorts:      []SearchSort{{PropertyName: "archivedAt", Direction: "ASCENDING"}},
+		Properties: []string{"name"},
+	})
+	if err != nil {
+		t.Errorf("SearchArchived returned an error: %s", err.Error())
+		return
+	}
+
+	ids := []string{}
+	for _, object := range archived {
+		ids = append(ids, object.Id)
+	}
+
+	if !cmp.Equal([]string{"3", "2"}, ids) {
+		t.Errorf("Expected the companies archived in June, oldest first, got: %v", ids)
+	}
+}
+
+func TestRecreateArchived(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/properties/companies?hapikey=api_key":
+				writeJSONResponse(t, w, 200, map[string]interface{}{
+					"results": []map[string]interface{}{
+						{"name": "name"},
+						{"name": "domain"},
+						{"name": "hs_object_id", "modificationMetadata": map[string]bool{"readOnlyValue": true}},
+						{"name": "num_associated_deals", "calculated": true},
+					},
+				})
+			case "POST https://api.hubapi.com/crm/v3/objects/companies/batch/read?archived=true&hapikey=api_key":
+				var request batchReadRequest
+				readJSONRequest(t, req, &request)
+
+				if !cmp.Equal([]string{"name", "domain"}, request.Properties) {
+					t.Errorf("Expected the writable properties to be read, got: %v", request.Properties)
+				}
+
+				writeJSONResponse(t, w, 200, map[string]interface{}{
+					"results": []ArchivedObject{
+						{Id: "1", Properties: map[string]string{"name": "Acme", "domain": "", "hs_object_id": "1"}, Archived: true},
+					},
+				})
+			case "POST https://api.hubapi.com/crm/v3/objects/companies?hapikey=api_key":
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				expectedProperties := map[string]string{"name": "Acme"}
+				if !cmp.Equal(expectedProperties, request.Properties) {
+					t.Errorf("Unexpected recreated properties, expected:\n%v\ngot:\n%v", expectedProperties, request.Properties)
+				}
+
+				writeJSONResponse(t, w, 201, HubSpotSearchResult{Id: "10"})
+			default:
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockCRMAPI(&mockHubspotHTTPClient)
+
+	outcomes, err := api.RecreateArchived("companies", []string{"1", "2"})
+	if err != nil {
+		t.Errorf("RecreateArchived returned an error: %s", err.Error())
+		return
+	}
+
+	if len(outcomes) != 2 {
+		t.Errorf("Expected 2 outcomes, got: %v", outcomes)
+		return
+	}
+
+	if outcomes[0].ArchivedId != "1" || outcomes[0].RecreatedId != "10" || outcomes[0].Err != nil {
+		t.Errorf("Unexpected outcome for the archived company: %v", outcomes[0])
+	}
+
+	if outcomes[1].ArchivedId != "2" || outcomes[1].Err == nil {
+		t.Errorf("Expected an error for the company that is not archived: %v", outcomes[1])
+	}
+}
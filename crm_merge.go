@@ -0,0 +1,304 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	log "github.com/sirupsen/logrus"
+)
+
+// mergeRequest is a representation of a request to merge two objects in HubSpot
+type mergeRequest struct {
+	PrimaryObjectId string `json:"primaryObjectId"`
+	ObjectIdToMerge string `json:"objectIdToMerge"`
+}
+
+// DuplicateGroup is a set of records that share at least one normalised key, directly or through other records of the group
+// Keys are the normalised values the records were matched on, e.g. "email:jane@example.com".
+// Records are ordered from the oldest, PrimaryId is the record the others are merged into and can be changed before executing the plan.
+type DuplicateGroup struct {
+	Keys      []string
+	Records   []HubSpotSearchResult
+	PrimaryId string
+}
+
+// MergeIds returns the ids of the records of the group that are merged into the primary record
+func (group DuplicateGroup) MergeIds() []string {
+	ids := []string{}
+	for _, record := range group.Records {
+		if record.Id != group.PrimaryId {
+			ids = append(ids, record.Id)
+		}
+	}
+	return ids
+}
+
+// MergePlan is the set of merges that would remove the duplicates of an object type
+// Groups can be reviewed, removed or have their primary record changed before the plan is executed with ExecuteMergePlan.
+type MergePlan struct {
+	ObjectType string
+	Groups     []DuplicateGroup
+}
+
+// MergeOutcome is the result of merging a single record into the primary record of its group
+type MergeOutcome struct {
+	PrimaryId string
+	MergedId  string
+	Err       error
+}
+
+// duplicateKey extracts the normalised value of a property that identifies duplicates, "" if the value cannot be matched on
+type duplicateKey struct {
+	property  string
+	normalise func(string) string
+}
+
+var contactDuplicateKeys = []duplicateKey{
+	{"email", normaliseEmail},
+	{"phone", normalisePhone},
+	{"mobilephone", normalisePhone},
+}
+
+var companyDuplicateKeys = []duplicateKey{
+	{"domain", normaliseDomain},
+	{"company_number", normaliseCompanyNumber},
+}
+
+var nonDigits = regexp.MustCompile(`[^0-9]`)
+var nonAlphanumerics = regexp.MustCompile(`[^A-Z0-9]`)
+
+// normalisePhone keeps the digits of a phone number, numbers too short to be told apart are ignored
+func normalisePhone(phone string) string {
+	digits := nonDigits.ReplaceAllString(phone, "")
+	digits = strings.TrimPrefix(digits, "00")
+	if len(digits) < 7 {
+		return ""
+	}
+	return digits
+}
+
+// normaliseDomain strips the scheme, "www." prefix, port and path of a domain or website URL
+func normaliseDomain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if index := strings.Index(domain, "://"); index >= 0 {
+		domain = domain[index+3:]
+	}
+	if index := strings.IndexAny(domain, "/?#:"); index >= 0 {
+		domain = domain[:index]
+	}
+	domain = strings.TrimPrefix(domain, "www.")
+	return strings.TrimSuffix(domain, ".")
+}
+
+// normaliseCompanyNumber uppercases a company registration number and strips anything but letters and digits
+func normaliseCompanyNumber(number string) string {
+	return nonAlphanumerics.ReplaceAllString(strings.ToUpper(number), "")
+}
+
+// MergeObjects merges the object with mergeId into the object with primaryId, and returns the merged object
+func (api HubspotCRMAPI) MergeObjects(objectType, primaryId, mergeId string) (*HubSpotSearchResult, error) {
+	log.Infof("Merging %s '%s' into '%s'", crm.SingularObjectType(objectType), mergeId, primaryId)
+
+	objects := crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: objectType}
+
+	var merged HubSpotSearchResult
+	err := doJSONRequest(api.httpClient, "POST", objects.url("/merge", nil), mergeRequest{primaryId, mergeId}, &merged)
+	if err != nil {
+		return nil, err
+	}
+
+	return &merged, nil
+}
+
+// FindDuplicateContacts plans the merges of the contacts sharing a normalised email, phone or mobile phone number
+func (api HubspotCRMAPI) FindDuplicateContacts() (*MergePlan, error) {
+	return api.findDuplicates("contacts", contactDuplicateKeys, []string{"firstname", "lastname"})
+}
+
+// FindDuplicateCompanies plans the merges of the companies sharing a normalised domain or company number
+func (api HubspotCRMAPI) FindDuplicateCompanies() (*MergePlan, error) {
+	return api.findDuplicates("companies", companyDuplicateKeys, []string{"name"})
+}
+
+// findDuplicates searches all the objects of a type, oldest first, and groups together the ones sharing any of the keys
+func (api HubspotCRMAPI) findDuplicates(objectType string, keys []duplicateKey, otherProperties []string) (*MergePlan, error) {
+	log.Infof("Looking for duplicate %s", objectType)
+
+	properties := append([]string{"createdate"}, otherProperties...)
+	for _, key := range keys {
+		properties = append(properties, key.property)
+	}
+
+	all, err := api.searchByCreation(objectType, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []HubSpotSearchResult{}
+	parent := []int{}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	firstWithKey := map[string]int{}
+	matches := map[string]int{}
+	for _, record := range all {
+		index := len(records)
+		records = append(records, record)
+		parent = append(parent, index)
+
+		for _, key := range keys {
+			value := key.normalise(record.Properties[key.property])
+			if value == "" {
+				continue
+			}
+
+			// Phone and mobile phone numbers are matched against each other
+			name := key.property
+			if name == "mobilephone" {
+				name = "phone"
+			}
+			matchKey := name + ":" + value
+			matches[matchKey]++
+
+			if first, ok := firstWithKey[matchKey]; ok {
+				parent[find(index)] = find(first)
+			} else {
+				firstWithKey[matchKey] = index
+			}
+		}
+	}
+
+	groupIndex := map[int]int{}
+	plan := MergePlan{ObjectType: objectType, Groups: []DuplicateGroup{}}
+	for index, record := range records {
+		root := find(index)
+		i, ok := groupIndex[root]
+		if !ok {
+			i = len(plan.Groups)
+			groupIndex[root] = i
+			plan.Groups = append(plan.Groups, DuplicateGroup{Keys: []string{}, PrimaryId: record.Id})
+		}
+		plan.Groups[i].Records = append(plan.Groups[i].Records, record)
+	}
+
+	for matchKey, first := range firstWithKey {
+		if matches[matchKey] < 2 {
+			continue
+		}
+		i := groupIndex[find(first)]
+		plan.Groups[i].Keys = append(plan.Groups[i].Keys, matchKey)
+	}
+
+	duplicates := []DuplicateGroup{}
+	for _, group := range plan.Groups {
+		if len(group.Records) > 1 {
+			sort.Strings(group.Keys)
+			duplicates = append(duplicates, group)
+		}
+	}
+	plan.Groups = duplicates
+
+	return &plan, nil
+}
+
+// searchByCreation returns all the objects of a type, oldest first
+// HubSpot search returns at most 10,000 results per query, so larger accounts are searched again from the last creation time reached.
+func (api HubspotCRMAPI) searchByCreation(objectType string, properties []string) ([]HubSpotSearchResult, error) {
+	records := []HubSpotSearchResult{}
+	var lower time.Time
+	for {
+		query := SearchQuery{
+			Sorts:      []SearchSort{{PropertyName: "createdate", Direction: "ASCENDING"}},
+			Properties: properties,
+		}
+		if !lower.IsZero() {
+			query.Filters = []SearchFilter{{PropertyName: "createdate", Operator: "GTE", Value: formatHubSpotTime(lower)}}
+		}
+
+		window := []HubSpotSearchResult{}
+		it := api.Search(objectType, query)
+		for it.Next() {
+			window = append(window, it.Result())
+			if len(window) >= crm.SearchResultLimit {
+				break
+			}
+		}
+		if it.Err() != nil {
+			return nil, it.Err()
+		}
+
+		if len(window) < crm.SearchResultLimit {
+			return append(records, window...), nil
+		}
+
+		// The objects created at the last time reached may continue past the limit, they are searched again from that time
+		last := window[len(window)-1]
+		created, err := parseHubSpotTime(last.Properties["createdate"])
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Invalid createdate '%s' of %s %s", last.Properties["createdate"], objectType, last.Id))
+		}
+		end := len(window)
+		for end > 0 {
+			previous, err := parseHubSpotTime(window[end-1].Properties["createdate"])
+			if err != nil || !previous.Equal(created) {
+				break
+			}
+			end--
+		}
+		if end == 0 {
+			return nil, errors.New(fmt.Sprintf("More than %d %s were created at %s", crm.SearchResultLimit, objectType, created))
+		}
+
+		log.Infof("Reached the search limit of %d %s, searching again from %s", crm.SearchResultLimit, objectType, created)
+		records = append(records, window[:end]...)
+		lower = created
+	}
+}
+
+// ExecuteMergePlan merges the records of each group of the plan into the group's primary record
+// Merges carry on after a failure, the outcome of each merge is returned in order.
+func (api HubspotCRMAPI) ExecuteMergePlan(plan MergePlan) []MergeOutcome {
+	outcomes := []MergeOutcome{}
+	for _, group := range plan.Groups {
+		primaryId := group.PrimaryId
+		for _, mergeId := range group.MergeIds() {
+			outcome := MergeOutcome{PrimaryId: primaryId, MergedId: mergeId}
+
+			merged, err := api.MergeObjects(plan.ObjectType, primaryId, mergeId)
+			if err != nil {
+				outcome.Err = fmt.Errorf("Failed to merge %s '%s' into '%s': %w", crm.SingularObjectType(plan.ObjectType), mergeId, primaryId, err)
+			} else if merged.Id != "" {
+				// HubSpot may give the merged record a new id, the remaining records are merged into it
+				primaryId = merged.Id
+			}
+
+			outcomes = append(outcomes, outcome)
+		}
+	}
+
+	return outcomes
+}
+
+// String describes the plan for review, one group per line
+func (plan MergePlan) String() string {
+	var b strings.Builder
+	for _, group := range plan.Groups {
+		fmt.Fprintf(&b, "%s: merge %s into %s (%s)\n",
+			crm.SingularObjectType(plan.ObjectType),
+			strings.Join(group.MergeIds(), ", "),
+			group.PrimaryId,
+			strings.Join(group.Keys, ", "),
+		)
+	}
+	return b.String()
+}
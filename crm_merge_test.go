@@ -0,0 +1,184 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormaliseDuplicateKeys(t *testing.T) {
+	cases := []struct {
+		normalise func(string) string
+		value     string
+		expected  string
+	}{
+		{normaliseEmail, " Jane@Example.COM ", "jane@example.com"},
+		{normalisePhone, "+44 (0)20 7946-0958", "4402079460958"},
+		{normalisePhone, "0044 20 7946 0958", "442079460958"},
+		{normalisePhone, "123", ""},
+		{normaliseDomain, "https://www.Example.com/about", "example.com"},
+		{normaliseDomain, "example.com.", "example.com"},
+		{normaliseDomain, "www.example.com:8080", "example.com"},
+		{normaliseCompanyNumber, "sc 123-456", "SC123456"},
+	}
+
+	for _, c := range cases {
+		if actual := c.normalise(c.value); actual != c.expected {
+			t.Errorf("Normalising '%s', expected '%s', got '%s'", c.value, c.expected, actual)
+		}
+	}
+}
+
+func TestFindDuplicateContacts(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/contacts/search?hapikey=api_key" {
+				var request searchPageRequest
+				readJSONRequest(t, req, &request)
+
+				expectedSorts := []SearchSort{{PropertyName: "createdate", Direction: "ASCENDING"}}
+				if !cmp.Equal(expectedSorts, request.Sorts) {
+					t.Errorf("Expected contacts to be searched oldest first, got: %v", request.Sorts)
+				}
+
+				writeJSONResponse(t, w, 200, searchPageResponse{
+					Total: 5,
+					Results: []HubSpotSearchResult{
+						{Id: "1", Properties: map[string]string{"email": "jane@example.com", "phone": "020 7946 0958"}},
+						{Id: "2", Properties: map[string]string{"email": "john@example.com"}},
+						{Id: "3", Properties: map[string]string{"email": "JANE@example.com "}},
+						{Id: "4", Properties: map[string]string{"email": "j.doe@example.com", "mobilephone": "(020) 79460958"}},
+						{Id: "5", Properties: map[string]string{"email": "", "phone": "123"}},
+					},
+				})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockCRMAPI(&mockHubspotHTTPClient)
+
+	plan, err := api.FindDuplicateContacts()
+	if err != nil {
+		t.Errorf("FindDuplicateContacts returned an error: %s", err.Error())
+		return
+	}
+
+	if plan.ObjectType != "contacts" || len(plan.Groups) != 1 {
+		t.Errorf("Expected a single group of duplicate contacts, got: %v", plan)
+		return
+	}
+
+	group := plan.Groups[0]
+	expectedKeys := []string{"email:jane@example.com", "phone:02079460958"}
+	if !cmp.Equal(expectedKeys, group.Keys) {
+		t.Errorf("Unexpected group keys, expected:\n%v\ngot:\n%v", expectedKeys, group.Keys)
+	}
+
+	if group.PrimaryId != "1" || !cmp.Equal([]string{"3", "4"}, group.MergeIds()) {
+		t.Errorf("Expected contacts 3 and 4 to be merged into 1, got: %v", group)
+	}
+}
+
+func TestFindDuplicateCompaniesPastSearchLimit(t *testing.T) {
+	base := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	records := []HubSpotSearchResult{}
+	for i := 1; i <= crm.SearchResultLimit+50; i++ {
+		created := base.Add(time.Duration(i) * time.Second)
+		// Companies created at the same time straddle the limit
+		if i >= crm.SearchResultLimit-1 && i <= crm.SearchResultLimit+2 {
+			created = base.Add(time.Duration(crm.SearchResultLimit) * time.Second)
+		}
+		records = append(records, company(strconv.Itoa(i), created, created))
+	}
+	records[0].Properties["domain"] = "acme.com"
+	records[crm.SearchResultLimit+1].Properties["domain"] = "https://www.acme.com/"
+	records[crm.SearchResultLimit+39].Properties["domain"] = "ACME.com"
+
+	requests := 0
+	api := getMockCRMAPI(mockSearchClient(t, &records, &requests))
+
+	plan, err := api.FindDuplicateCompanies()
+	if err != nil {
+		t.Fatalf("FindDuplicateCompanies returned an error: %s", err.Error())
+	}
+
+	expectedMergeIds := []string{strconv.Itoa(crm.SearchResultLimit + 2), strconv.Itoa(crm.SearchResultLimit + 40)}
+	if len(plan.Groups) != 1 {
+		t.Fatalf("Expected a single group of duplicate companies, got: %v", plan.Groups)
+	}
+	group := plan.Groups[0]
+	if group.PrimaryId != "1" || !cmp.Equal(expectedMergeIds, group.MergeIds()) {
+		t.Errorf("Expected companies %v to be merged into 1, got: %v", expectedMergeIds, group)
+	}
+}
+
+func TestExecuteMergePlan(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/companies/merge?hapikey=api_key" {
+				var request mergeRequest
+				readJSONRequest(t, req, &request)
+
+				switch request.ObjectIdToMerge {
+				case "2":
+					writeJSONResponse(t, w, 200, HubSpotSearchResult{Id: "10"})
+				case "3":
+					if request.PrimaryObjectId != "10" {
+						t.Errorf("Expected company 3 to be merged into the merged company 10, got: %v", request)
+					}
+					w.WriteHeader(400)
+				default:
+					t.Errorf("Unexpected merge request: %v", request)
+				}
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockCRMAPI(&mockHubspotHTTPClient)
+
+	outcomes := api.ExecuteMergePlan(MergePlan{
+		ObjectType: "companies",
+		Groups: []DuplicateGroup{
+			{
+				Keys:      []string{"domain:example.com"},
+				Records:   []HubSpotSearchResult{{Id: "1"}, {Id: "2"}, {Id: "3"}},
+				PrimaryId: "1",
+			},
+		},
+	})
+
+	if len(outcomes) != 2 {
+		t.Errorf("Expected 2 merge outcomes, got: %v", outcomes)
+		return
+	}
+
+	if outcomes[0].Err != nil || outcomes[0].PrimaryId != "1" || outcomes[0].MergedId != "2" {
+		t.Errorf("Unexpected first outcome: %v", outcomes[0])
+	}
+
+	if outcomes[1].Err == nil || outcomes[1].PrimaryId != "10" || outcomes[1].MergedId != "3" {
+		t.Errorf("Unexpected second outcome: %v", outcomes[1])
+	}
+}
@@ -18,18 +18,54 @@ var _ IHubspotCRMAPI = &IHubspotCRMAPIMock{}
 //
 // 		// make and configure a mocked IHubspotCRMAPI
 // 		mockedIHubspotCRMAPI := &IHubspotCRMAPIMock{
+// 			ChangeFeedFunc: func(objectType string, properties []string, store CheckpointStore) *ChangeFeed {
+// 				panic("mock out the ChangeFeed method")
+// 			},
+// 			ExecuteMergePlanFunc: func(plan MergePlan) []MergeOutcome {
+// 				panic("mock out the ExecuteMergePlan method")
+// 			},
+// 			FindDuplicateCompaniesFunc: func() (*MergePlan, error) {
+// 				panic("mock out the FindDuplicateCompanies method")
+// 			},
+// 			FindDuplicateContactsFunc: func() (*MergePlan, error) {
+// 				panic("mock out the FindDuplicateContacts method")
+// 			},
 // 			GetCompanyForContactFunc: func(contactID string) (string, error) {
 // 				panic("mock out the GetCompanyForContact method")
 // 			},
 // 			GetDealForCompanyFunc: func(companyID string) (string, error) {
 // 				panic("mock out the GetDealForCompany method")
 // 			},
+// 			GetPropertyHistoryFunc: func(objectType string, objectId string, properties []string) (*PropertyHistory, error) {
+// 				panic("mock out the GetPropertyHistory method")
+// 			},
+// 			ListArchivedFunc: func(objectType string, properties []string) ([]ArchivedObject, error) {
+// 				panic("mock out the ListArchived method")
+// 			},
+// 			MergeObjectsFunc: func(objectType string, primaryId string, mergeId string) (*HubSpotSearchResult, error) {
+// 				panic("mock out the MergeObjects method")
+// 			},
+// 			MirrorFunc: func(store MirrorStore, checkpoints CheckpointStore, objects ...MirrorObject) *Mirror {
+// 				panic("mock out the Mirror method")
+// 			},
+// 			RecreateArchivedFunc: func(objectType string, ids []string) ([]RecreateOutcome, error) {
+// 				panic("mock out the RecreateArchived method")
+// 			},
+// 			SearchFunc: func(objectType string, query SearchQuery) *SearchIterator {
+// 				panic("mock out the Search method")
+// 			},
+// 			SearchArchivedFunc: func(objectType string, query SearchQuery) ([]ArchivedObject, error) {
+// 				panic("mock out the SearchArchived method")
+// 			},
 // 			SearchCompaniesFunc: func(filterMap map[string]string, properties []string) ([]HubSpotSearchResult, error) {
 // 				panic("mock out the SearchCompanies method")
 // 			},
 // 			SearchContactsFunc: func(filterMap map[string]string, properties []string) ([]HubSpotSearchResult, error) {
 // 				panic("mock out the SearchContacts method")
 // 			},
+// 			SyncObjectsFunc: func(local LocalSystem, baseline MirrorStore, mapping SyncMapping, dryRun bool) (*SyncReport, error) {
+// 				panic("mock out the SyncObjects method")
+// 			},
 // 			UpdateCompanyFunc: func(companyID string, jsonPayload *bytes.Buffer) error {
 // 				panic("mock out the UpdateCompany method")
 // 			},
@@ -40,23 +76,79 @@ var _ IHubspotCRMAPI = &IHubspotCRMAPIMock{}
 //
 // 	}
 type IHubspotCRMAPIMock struct {
+	// ChangeFeedFunc mocks the ChangeFeed method.
+	ChangeFeedFunc func(objectType string, properties []string, store CheckpointStore) *ChangeFeed
+
+	// ExecuteMergePlanFunc mocks the ExecuteMergePlan method.
+	ExecuteMergePlanFunc func(plan MergePlan) []MergeOutcome
+
+	// FindDuplicateCompaniesFunc mocks the FindDuplicateCompanies method.
+	FindDuplicateCompaniesFunc func() (*MergePlan, error)
+
+	// FindDuplicateContactsFunc mocks the FindDuplicateContacts method.
+	FindDuplicateContactsFunc func() (*MergePlan, error)
+
 	// GetCompanyForContactFunc mocks the GetCompanyForContact method.
 	GetCompanyForContactFunc func(contactID string) (string, error)
 
 	// GetDealForCompanyFunc mocks the GetDealForCompany method.
 	GetDealForCompanyFunc func(companyID string) (string, error)
 
+	// GetPropertyHistoryFunc mocks the GetPropertyHistory method.
+	GetPropertyHistoryFunc func(objectType string, objectId string, properties []string) (*PropertyHistory, error)
+
+	// ListArchivedFunc mocks the ListArchived method.
+	ListArchivedFunc func(objectType string, properties []string) ([]ArchivedObject, error)
+
+	// MergeObjectsFunc mocks the MergeObjects method.
+	MergeObjectsFunc func(objectType string, primaryId string, mergeId string) (*HubSpotSearchResult, error)
+
+	// MirrorFunc mocks the Mirror method.
+	MirrorFunc func(store MirrorStore, checkpoints CheckpointStore, objects ...MirrorObject) *Mirror
+
+	// RecreateArchivedFunc mocks the RecreateArchived method.
+	RecreateArchivedFunc func(objectType string, ids []string) ([]RecreateOutcome, error)
+
+	// SearchFunc mocks the Search method.
+	SearchFunc func(objectType string, query SearchQuery) *SearchIterator
+
+	// SearchArchivedFunc mocks the SearchArchived method.
+	SearchArchivedFunc func(objectType string, query SearchQuery) ([]ArchivedObject, error)
+
 	// SearchCompaniesFunc mocks the SearchCompanies method.
 	SearchCompaniesFunc func(filterMap map[string]string, properties []string) ([]HubSpotSearchResult, error)
 
 	// SearchContactsFunc mocks the SearchContacts method.
 	SearchContactsFunc func(filterMap map[string]string, properties []string) ([]HubSpotSearchResult, error)
 
+	// SyncObjectsFunc mocks the SyncObjects method.
+	SyncObjectsFunc func(local LocalSystem, baseline MirrorStore, mapping SyncMapping, dryRun bool) (*SyncReport, error)
+
 	// UpdateCompanyFunc mocks the UpdateCompany method.
 	UpdateCompanyFunc func(companyID string, jsonPayload *bytes.Buffer) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// ChangeFeed holds details about calls to the ChangeFeed method.
+		ChangeFeed []struct {
+			// ObjectType is the objectType argument value.
+			ObjectType string
+			// Properties is the properties argument value.
+			Properties []string
+			// Store is the store argument value.
+			Store CheckpointStore
+		}
+		// ExecuteMergePlan holds details about calls to the ExecuteMergePlan method.
+		ExecuteMergePlan []struct {
+			// Plan is the plan argument value.
+			Plan MergePlan
+		}
+		// FindDuplicateCompanies holds details about calls to the FindDuplicateCompanies method.
+		FindDuplicateCompanies []struct {
+		}
+		// FindDuplicateContacts holds details about calls to the FindDuplicateContacts method.
+		FindDuplicateContacts []struct {
+		}
 		// GetCompanyForContact holds details about calls to the GetCompanyForContact method.
 		GetCompanyForContact []struct {
 			// ContactID is the contactID argument value.
@@ -67,6 +159,61 @@ type IHubspotCRMAPIMock struct {
 			// CompanyID is the companyID argument value.
 			CompanyID string
 		}
+		// GetPropertyHistory holds details about calls to the GetPropertyHistory method.
+		GetPropertyHistory []struct {
+			// ObjectType is the objectType argument value.
+			ObjectType string
+			// ObjectId is the objectId argument value.
+			ObjectId string
+			// Properties is the properties argument value.
+			Properties []string
+		}
+		// ListArchived holds details about calls to the ListArchived method.
+		ListArchived []struct {
+			// ObjectType is the objectType argument value.
+			ObjectType string
+			// Properties is the properties argument value.
+			Properties []string
+		}
+		// MergeObjects holds details about calls to the MergeObjects method.
+		MergeObjects []struct {
+			// ObjectType is the objectType argument value.
+			ObjectType string
+			// PrimaryId is the primaryId argument value.
+			PrimaryId string
+			// MergeId is the mergeId argument value.
+			MergeId string
+		}
+		// Mirror holds details about calls to the Mirror method.
+		Mirror []struct {
+			// Store is the store argument value.
+			Store MirrorStore
+			// Checkpoints is the checkpoints argument value.
+			Checkpoints CheckpointStore
+			// Objects is the objects argument value.
+			Objects []MirrorObject
+		}
+		// RecreateArchived holds details about calls to the RecreateArchived method.
+		RecreateArchived []struct {
+			// ObjectType is the objectType argument value.
+			ObjectType string
+			// Ids is the ids argument value.
+			Ids []string
+		}
+		// Search holds details about calls to the Search method.
+		Search []struct {
+			// ObjectType is the objectType argument value.
+			ObjectType string
+			// Query is the query argument value.
+			Query SearchQuery
+		}
+		// SearchArchived holds details about calls to the SearchArchived method.
+		SearchArchived []struct {
+			// ObjectType is the objectType argument value.
+			ObjectType string
+			// Query is the query argument value.
+			Query SearchQuery
+		}
 		// SearchCompanies holds details about calls to the SearchCompanies method.
 		SearchCompanies []struct {
 			// FilterMap is the filterMap argument value.
@@ -81,6 +228,17 @@ type IHubspotCRMAPIMock struct {
 			// Properties is the properties argument value.
 			Properties []string
 		}
+		// SyncObjects holds details about calls to the SyncObjects method.
+		SyncObjects []struct {
+			// Local is the local argument value.
+			Local LocalSystem
+			// Baseline is the baseline argument value.
+			Baseline MirrorStore
+			// Mapping is the mapping argument value.
+			Mapping SyncMapping
+			// DryRun is the dryRun argument value.
+			DryRun bool
+		}
 		// UpdateCompany holds details about calls to the UpdateCompany method.
 		UpdateCompany []struct {
 			// CompanyID is the companyID argument value.
@@ -89,11 +247,145 @@ type IHubspotCRMAPIMock struct {
 			JsonPayload *bytes.Buffer
 		}
 	}
-	lockGetCompanyForContact sync.RWMutex
-	lockGetDealForCompany    sync.RWMutex
-	lockSearchCompanies      sync.RWMutex
-	lockSearchContacts       sync.RWMutex
-	lockUpdateCompany        sync.RWMutex
+	lockChangeFeed             sync.RWMutex
+	lockExecuteMergePlan       sync.RWMutex
+	lockFindDuplicateCompanies sync.RWMutex
+	lockFindDuplicateContacts  sync.RWMutex
+	lockGetCompanyForContact   sync.RWMutex
+	lockGetDealForCompany      sync.RWMutex
+	lockGetPropertyHistory     sync.RWMutex
+	lockListArchived           sync.RWMutex
+	lockMergeObjects           sync.RWMutex
+	lockMirror                 sync.RWMutex
+	lockRecreateArchived       sync.RWMutex
+	lockSearch                 sync.RWMutex
+	lockSearchArchived         sync.RWMutex
+	lockSearchCompanies        sync.RWMutex
+	lockSearchContacts         sync.RWMutex
+	lockSyncObjects            sync.RWMutex
+	lockUpdateCompany          sync.RWMutex
+}
+
+// ChangeFeed calls ChangeFeedFunc.
+func (mock *IHubspotCRMAPIMock) ChangeFeed(objectType string, properties []string, store CheckpointStore) *ChangeFeed {
+	if mock.ChangeFeedFunc == nil {
+		panic("IHubspotCRMAPIMock.ChangeFeedFunc: method is nil but IHubspotCRMAPI.ChangeFeed was just called")
+	}
+	callInfo := struct {
+		ObjectType string
+		Properties []string
+		Store      CheckpointStore
+	}{
+		ObjectType: objectType,
+		Properties: properties,
+		Store:      store,
+	}
+	mock.lockChangeFeed.Lock()
+	mock.calls.ChangeFeed = append(mock.calls.ChangeFeed, callInfo)
+	mock.lockChangeFeed.Unlock()
+	return mock.ChangeFeedFunc(objectType, properties, store)
+}
+
+// ChangeFeedCalls gets all the calls that were made to ChangeFeed.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.ChangeFeedCalls())
+func (mock *IHubspotCRMAPIMock) ChangeFeedCalls() []struct {
+	ObjectType string
+	Properties []string
+	Store      CheckpointStore
+} {
+	var calls []struct {
+		ObjectType string
+		Properties []string
+		Store      CheckpointStore
+	}
+	mock.lockChangeFeed.RLock()
+	calls = mock.calls.ChangeFeed
+	mock.lockChangeFeed.RUnlock()
+	return calls
+}
+
+// ExecuteMergePlan calls ExecuteMergePlanFunc.
+func (mock *IHubspotCRMAPIMock) ExecuteMergePlan(plan MergePlan) []MergeOutcome {
+	if mock.ExecuteMergePlanFunc == nil {
+		panic("IHubspotCRMAPIMock.ExecuteMergePlanFunc: method is nil but IHubspotCRMAPI.ExecuteMergePlan was just called")
+	}
+	callInfo := struct {
+		Plan MergePlan
+	}{
+		Plan: plan,
+	}
+	mock.lockExecuteMergePlan.Lock()
+	mock.calls.ExecuteMergePlan = append(mock.calls.ExecuteMergePlan, callInfo)
+	mock.lockExecuteMergePlan.Unlock()
+	return mock.ExecuteMergePlanFunc(plan)
+}
+
+// ExecuteMergePlanCalls gets all the calls that were made to ExecuteMergePlan.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.ExecuteMergePlanCalls())
+func (mock *IHubspotCRMAPIMock) ExecuteMergePlanCalls() []struct {
+	Plan MergePlan
+} {
+	var calls []struct {
+		Plan MergePlan
+	}
+	mock.lockExecuteMergePlan.RLock()
+	calls = mock.calls.ExecuteMergePlan
+	mock.lockExecuteMergePlan.RUnlock()
+	return calls
+}
+
+// FindDuplicateCompanies calls FindDuplicateCompaniesFunc.
+func (mock *IHubspotCRMAPIMock) FindDuplicateCompanies() (*MergePlan, error) {
+	if mock.FindDuplicateCompaniesFunc == nil {
+		panic("IHubspotCRMAPIMock.FindDuplicateCompaniesFunc: method is nil but IHubspotCRMAPI.FindDuplicateCompanies was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockFindDuplicateCompanies.Lock()
+	mock.calls.FindDuplicateCompanies = append(mock.calls.FindDuplicateCompanies, callInfo)
+	mock.lockFindDuplicateCompanies.Unlock()
+	return mock.FindDuplicateCompaniesFunc()
+}
+
+// FindDuplicateCompaniesCalls gets all the calls that were made to FindDuplicateCompanies.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.FindDuplicateCompaniesCalls())
+func (mock *IHubspotCRMAPIMock) FindDuplicateCompaniesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockFindDuplicateCompanies.RLock()
+	calls = mock.calls.FindDuplicateCompanies
+	mock.lockFindDuplicateCompanies.RUnlock()
+	return calls
+}
+
+// FindDuplicateContacts calls FindDuplicateContactsFunc.
+func (mock *IHubspotCRMAPIMock) FindDuplicateContacts() (*MergePlan, error) {
+	if mock.FindDuplicateContactsFunc == nil {
+		panic("IHubspotCRMAPIMock.FindDuplicateContactsFunc: method is nil but IHubspotCRMAPI.FindDuplicateContacts was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockFindDuplicateContacts.Lock()
+	mock.calls.FindDuplicateContacts = append(mock.calls.FindDuplicateContacts, callInfo)
+	mock.lockFindDuplicateContacts.Unlock()
+	return mock.FindDuplicateContactsFunc()
+}
+
+// FindDuplicateContactsCalls gets all the calls that were made to FindDuplicateContacts.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.FindDuplicateContactsCalls())
+func (mock *IHubspotCRMAPIMock) FindDuplicateContactsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockFindDuplicateContacts.RLock()
+	calls = mock.calls.FindDuplicateContacts
+	mock.lockFindDuplicateContacts.RUnlock()
+	return calls
 }
 
 // GetCompanyForContact calls GetCompanyForContactFunc.
@@ -158,6 +450,263 @@ func (mock *IHubspotCRMAPIMock) GetDealForCompanyCalls() []struct {
 	return calls
 }
 
+// GetPropertyHistory calls GetPropertyHistoryFunc.
+func (mock *IHubspotCRMAPIMock) GetPropertyHistory(objectType string, objectId string, properties []string) (*PropertyHistory, error) {
+	if mock.GetPropertyHistoryFunc == nil {
+		panic("IHubspotCRMAPIMock.GetPropertyHistoryFunc: method is nil but IHubspotCRMAPI.GetPropertyHistory was just called")
+	}
+	callInfo := struct {
+		ObjectType string
+		ObjectId   string
+		Properties []string
+	}{
+		ObjectType: objectType,
+		ObjectId:   objectId,
+		Properties: properties,
+	}
+	mock.lockGetPropertyHistory.Lock()
+	mock.calls.GetPropertyHistory = append(mock.calls.GetPropertyHistory, callInfo)
+	mock.lockGetPropertyHistory.Unlock()
+	return mock.GetPropertyHistoryFunc(objectType, objectId, properties)
+}
+
+// GetPropertyHistoryCalls gets all the calls that were made to GetPropertyHistory.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.GetPropertyHistoryCalls())
+func (mock *IHubspotCRMAPIMock) GetPropertyHistoryCalls() []struct {
+	ObjectType string
+	ObjectId   string
+	Properties []string
+} {
+	var calls []struct {
+		ObjectType string
+		ObjectId   string
+		Properties []string
+	}
+	mock.lockGetPropertyHistory.RLock()
+	calls = mock.calls.GetPropertyHistory
+	mock.lockGetPropertyHistory.RUnlock()
+	return calls
+}
+
+// ListArchived calls ListArchivedFunc.
+func (mock *IHubspotCRMAPIMock) ListArchived(objectType string, properties []string) ([]ArchivedObject, error) {
+	if mock.ListArchivedFunc == nil {
+		panic("IHubspotCRMAPIMock.ListArchivedFunc: method is nil but IHubspotCRMAPI.ListArchived was just called")
+	}
+	callInfo := struct {
+		ObjectType string
+		Properties []string
+	}{
+		ObjectType: objectType,
+		Properties: properties,
+	}
+	mock.lockListArchived.Lock()
+	mock.calls.ListArchived = append(mock.calls.ListArchived, callInfo)
+	mock.lockListArchived.Unlock()
+	return mock.ListArchivedFunc(objectType, properties)
+}
+
+// ListArchivedCalls gets all the calls that were made to ListArchived.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.ListArchivedCalls())
+func (mock *IHubspotCRMAPIMock) ListArchivedCalls() []struct {
+	ObjectType string
+	Properties []string
+} {
+	var calls []struct {
+		ObjectType string
+		Properties []string
+	}
+	mock.lockListArchived.RLock()
+	calls = mock.calls.ListArchived
+	mock.lockListArchived.RUnlock()
+	return calls
+}
+
+// MergeObjects calls MergeObjectsFunc.
+func (mock *IHubspotCRMAPIMock) MergeObjects(objectType string, primaryId string, mergeId string) (*HubSpotSearchResult, error) {
+	if mock.MergeObjectsFunc == nil {
+		panic("IHubspotCRMAPIMock.MergeObjectsFunc: method is nil but IHubspotCRMAPI.MergeObjects was just called")
+	}
+	callInfo := struct {
+		ObjectType string
+		PrimaryId  string
+		MergeId    string
+	}{
+		ObjectType: objectType,
+		PrimaryId:  primaryId,
+		MergeId:    mergeId,
+	}
+	mock.lockMergeObjects.Lock()
+	mock.calls.MergeObjects = append(mock.calls.MergeObjects, callInfo)
+	mock.lockMergeObjects.Unlock()
+	return mock.MergeObjectsFunc(objectType, primaryId, mergeId)
+}
+
+// MergeObjectsCalls gets all the calls that were made to MergeObjects.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.MergeObjectsCalls())
+func (mock *IHubspotCRMAPIMock) MergeObjectsCalls() []struct {
+	ObjectType string
+	PrimaryId  string
+	MergeId    string
+} {
+	var calls []struct {
+		ObjectType string
+		PrimaryId  string
+		MergeId    string
+	}
+	mock.lockMergeObjects.RLock()
+	calls = mock.calls.MergeObjects
+	mock.lockMergeObjects.RUnlock()
+	return calls
+}
+
+// Mirror calls MirrorFunc.
+func (mock *IHubspotCRMAPIMock) Mirror(store MirrorStore, checkpoints CheckpointStore, objects ...MirrorObject) *Mirror {
+	if mock.MirrorFunc == nil {
+		panic("IHubspotCRMAPIMock.MirrorFunc: method is nil but IHubspotCRMAPI.Mirror was just called")
+	}
+	callInfo := struct {
+		Store       MirrorStore
+		Checkpoints CheckpointStore
+		Objects     []MirrorObject
+	}{
+		Store:       store,
+		Checkpoints: checkpoints,
+		Objects:     objects,
+	}
+	mock.lockMirror.Lock()
+	mock.calls.Mirror = append(mock.calls.Mirror, callInfo)
+	mock.lockMirror.Unlock()
+	return mock.MirrorFunc(store, checkpoints, objects...)
+}
+
+// MirrorCalls gets all the calls that were made to Mirror.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.MirrorCalls())
+func (mock *IHubspotCRMAPIMock) MirrorCalls() []struct {
+	Store       MirrorStore
+	Checkpoints CheckpointStore
+	Objects     []MirrorObject
+} {
+	var calls []struct {
+		Store       MirrorStore
+		Checkpoints CheckpointStore
+		Objects     []MirrorObject
+	}
+	mock.lockMirror.RLock()
+	calls = mock.calls.Mirror
+	mock.lockMirror.RUnlock()
+	return calls
+}
+
+// RecreateArchived calls RecreateArchivedFunc.
+func (mock *IHubspotCRMAPIMock) RecreateArchived(objectType string, ids []string) ([]RecreateOutcome, error) {
+	if mock.RecreateArchivedFunc == nil {
+		panic("IHubspotCRMAPIMock.RecreateArchivedFunc: method is nil but IHubspotCRMAPI.RecreateArchived was just called")
+	}
+	callInfo := struct {
+		ObjectType string
+		Ids        []string
+	}{
+		ObjectType: objectType,
+		Ids:        ids,
+	}
+	mock.lockRecreateArchived.Lock()
+	mock.calls.RecreateArchived = append(mock.calls.RecreateArchived, callInfo)
+	mock.lockRecreateArchived.Unlock()
+	return mock.RecreateArchivedFunc(objectType, ids)
+}
+
+// RecreateArchivedCalls gets all the calls that were made to RecreateArchived.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.RecreateArchivedCalls())
+func (mock *IHubspotCRMAPIMock) RecreateArchivedCalls() []struct {
+	ObjectType string
+	Ids        []string
+} {
+	var calls []struct {
+		ObjectType string
+		Ids        []string
+	}
+	mock.lockRecreateArchived.RLock()
+	calls = mock.calls.RecreateArchived
+	mock.lockRecreateArchived.RUnlock()
+	return calls
+}
+
+// Search calls SearchFunc.
+func (mock *IHubspotCRMAPIMock) Search(objectType string, query SearchQuery) *SearchIterator {
+	if mock.SearchFunc == nil {
+		panic("IHubspotCRMAPIMock.SearchFunc: method is nil but IHubspotCRMAPI.Search was just called")
+	}
+	callInfo := struct {
+		ObjectType string
+		Query      SearchQuery
+	}{
+		ObjectType: objectType,
+		Query:      query,
+	}
+	mock.lockSearch.Lock()
+	mock.calls.Search = append(mock.calls.Search, callInfo)
+	mock.lockSearch.Unlock()
+	return mock.SearchFunc(objectType, query)
+}
+
+// SearchCalls gets all the calls that were made to Search.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.SearchCalls())
+func (mock *IHubspotCRMAPIMock) SearchCalls() []struct {
+	ObjectType string
+	Query      SearchQuery
+} {
+	var calls []struct {
+		ObjectType string
+		Query      SearchQuery
+	}
+	mock.lockSearch.RLock()
+	calls = mock.calls.Search
+	mock.lockSearch.RUnlock()
+	return calls
+}
+
+// SearchArchived calls SearchArchivedFunc.
+func (mock *IHubspotCRMAPIMock) SearchArchived(objectType string, query SearchQuery) ([]ArchivedObject, error) {
+	if mock.SearchArchivedFunc == nil {
+		panic("IHubspotCRMAPIMock.SearchArchivedFunc: method is nil but IHubspotCRMAPI.SearchArchived was just called")
+	}
+	callInfo := struct {
+		ObjectType string
+		Query      SearchQuery
+	}{
+		ObjectType: objectType,
+		Query:      query,
+	}
+	mock.lockSearchArchived.Lock()
+	mock.calls.SearchArchived = append(mock.calls.SearchArchived, callInfo)
+	mock.lockSearchArchived.Unlock()
+	return mock.SearchArchivedFunc(objectType, query)
+}
+
+// SearchArchivedCalls gets all the calls that were made to SearchArchived.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.SearchArchivedCalls())
+func (mock *IHubspotCRMAPIMock) SearchArchivedCalls() []struct {
+	ObjectType string
+	Query      SearchQuery
+} {
+	var calls []struct {
+		ObjectType string
+		Query      SearchQuery
+	}
+	mock.lockSearchArchived.RLock()
+	calls = mock.calls.SearchArchived
+	mock.lockSearchArchived.RUnlock()
+	return calls
+}
+
 // SearchCompanies calls SearchCompaniesFunc.
 func (mock *IHubspotCRMAPIMock) SearchCompanies(filterMap map[string]string, properties []string) ([]HubSpotSearchResult, error) {
 	if mock.SearchCompaniesFunc == nil {
@@ -228,6 +777,49 @@ func (mock *IHubspotCRMAPIMock) SearchContactsCalls() []struct {
 	return calls
 }
 
+// SyncObjects calls SyncObjectsFunc.
+func (mock *IHubspotCRMAPIMock) SyncObjects(local LocalSystem, baseline MirrorStore, mapping SyncMapping, dryRun bool) (*SyncReport, error) {
+	if mock.SyncObjectsFunc == nil {
+		panic("IHubspotCRMAPIMock.SyncObjectsFunc: method is nil but IHubspotCRMAPI.SyncObjects was just called")
+	}
+	callInfo := struct {
+		Local    LocalSystem
+		Baseline MirrorStore
+		Mapping  SyncMapping
+		DryRun   bool
+	}{
+		Local:    local,
+		Baseline: baseline,
+		Mapping:  mapping,
+		DryRun:   dryRun,
+	}
+	mock.lockSyncObjects.Lock()
+	mock.calls.SyncObjects = append(mock.calls.SyncObjects, callInfo)
+	mock.lockSyncObjects.Unlock()
+	return mock.SyncObjectsFunc(local, baseline, mapping, dryRun)
+}
+
+// SyncObjectsCalls gets all the calls that were made to SyncObjects.
+// Check the length with:
+//     len(mockedIHubspotCRMAPI.SyncObjectsCalls())
+func (mock *IHubspotCRMAPIMock) SyncObjectsCalls() []struct {
+	Local    LocalSystem
+	Baseline MirrorStore
+	Mapping  SyncMapping
+	DryRun   bool
+} {
+	var calls []struct {
+		Local    LocalSystem
+		Baseline MirrorStore
+		Mapping  SyncMapping
+		DryRun   bool
+	}
+	mock.lockSyncObjects.RLock()
+	calls = mock.calls.SyncObjects
+	mock.lockSyncObjects.RUnlock()
+	return calls
+}
+
 // UpdateCompany calls UpdateCompanyFunc.
 func (mock *IHubspotCRMAPIMock) UpdateCompany(companyID string, jsonPayload *bytes.Buffer) error {
 	if mock.UpdateCompanyFunc == nil {
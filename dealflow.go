@@ -3,10 +3,11 @@ package go_hubspot
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	log "github.com/sirupsen/logrus"
-	"io/ioutil"
 	"net/http"
+	"time"
 )
 
 type IHubspotDealFlowAPI interface {
@@ -21,21 +22,48 @@ type IHubspotDealFlowAPI interface {
 		ownerId string,
 		otherProperties map[string]string,
 	) (*DealCreationResponse, error)
+	CreateDeal(request DealCreateRequest) (*Deal, error)
 	UpdateDealFlowCard(
 		dealId string,
 		properties map[string]string,
 	) error
+	SearchDeals(query SearchQuery) *SearchIterator
+	GetDealPipelines() ([]Pipeline, error)
+	BulkMoveDeals(dealIds []string, stageId string) ([]DealMoveOutcome, error)
+	BulkMoveDealsMatching(query SearchQuery, stageId string) ([]DealMoveOutcome, error)
+	SnapshotPipeline(pipelineId string, properties []string) (*PipelineSnapshot, error)
+	GetDealStageHistory(dealId string) ([]StageTransition, error)
+	GetStageAnalytics(pipelineId string, from, to time.Time) (*PipelineStageAnalytics, error)
+	FindStaleDeals(options StaleDealOptions) (*StaleDealReport, error)
+	AddLineItemToDeal(dealId string, properties map[string]string) (*LineItem, error)
+	AddProductToDeal(dealId, productId string, quantity int) (*LineItem, error)
+	GetDealLineItems(dealId string) ([]LineItem, error)
+	RemoveLineItemFromDeal(dealId, lineItemId string) error
+	RecomputeDealAmount(dealId string) (string, error)
+	MoveDealFlowCardWithNote(dealId, stageId, note, ownerId string) error
+	LogDealNote(dealId, note, ownerId string) (*Engagement, error)
+	CreateDealTask(dealId, subject, body, ownerId string, dueDate time.Time) (*Engagement, error)
+	CreateDealFlowCardForOwner(
+		cardName string,
+		contactID string,
+		contactAssocType string,
+		companyID string,
+		stageName string,
+		pipeline string,
+		ownerEmail string,
+		otherProperties map[string]string,
+	) (*DealCreationResponse, error)
+	AssignDealOwner(dealId, ownerEmail string) error
 }
 
 type HubspotDealFlowAPI struct {
 	APIKey     string
 	httpClient IHTTPClient
+	owners     *ownerCache
 }
 
 // dealCreationRequest is a representation of the deal creation request to HubSpot
-type dealCreationRequest struct {
-	Properties map[string]string `json:"properties"`
-}
+type dealCreationRequest = objectCreationRequest
 
 // DealCreationResponseProperties is a representation of the deal creation response from HubSpot
 type DealCreationResponseProperties struct {
@@ -58,6 +86,79 @@ type DealCreationResponse struct {
 	Archived   bool                           `json:"archived"`
 }
 
+// Deal is a representation of a deal in HubSpot
+type Deal struct {
+	Id         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+	Archived   bool              `json:"archived"`
+}
+
+// DealCreateAssociation is an object that a deal is associated with on creation, see ObjectAssociation
+type DealCreateAssociation = ObjectAssociation
+
+// DealCreateRequest describes a deal to create, with any number of associations
+type DealCreateRequest struct {
+	Name         string
+	Pipeline     string
+	Stage        string
+	OwnerId      string
+	Amount       string
+	CloseDate    time.Time
+	Properties   map[string]string
+	Associations []DealCreateAssociation
+}
+
+// properties returns the HubSpot properties of the deal to create, fields that are not set are omitted
+func (request DealCreateRequest) properties() map[string]string {
+	properties := map[string]string{}
+	for key, value := range request.Properties {
+		properties[key] = value
+	}
+
+	fields := map[string]string{
+		"dealname":         request.Name,
+		"pipeline":         request.Pipeline,
+		"dealstage":        request.Stage,
+		"hubspot_owner_id": request.OwnerId,
+		"amount":           request.Amount,
+	}
+	for key, value := range fields {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+
+	if !request.CloseDate.IsZero() {
+		properties["closedate"] = request.CloseDate.UTC().Format(time.RFC3339)
+	}
+
+	return properties
+}
+
+// DealFlowCardPartialFailure is returned when a deal flow card was created but could not be associated,
+// and archiving the deal afterwards failed too, leaving the deal in the pipeline
+type DealFlowCardPartialFailure struct {
+	DealId         string
+	AssociationErr error
+	RollbackErr    error
+}
+
+func (e *DealFlowCardPartialFailure) Error() string {
+	return fmt.Sprintf(
+		"Deal '%s' was created but could not be associated (%s), and archiving it failed: %s",
+		e.DealId,
+		e.AssociationErr.Error(),
+		e.RollbackErr.Error(),
+	)
+}
+
+// Unwrap returns the association error that caused the failure
+func (e *DealFlowCardPartialFailure) Unwrap() error {
+	return e.AssociationErr
+}
+
 type dealUpdateRequest struct {
 	Properties map[string]string `json:"properties"`
 }
@@ -80,12 +181,22 @@ type DealAssociationBatchRequest struct {
 
 // NewHubspotDealFlowAPI creates new HubspotDealFlowAPI with form ID and API key
 func NewHubspotDealFlowAPI(apiKey string) HubspotDealFlowAPI {
+	return NewHubspotDealFlowAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotDealFlowAPIWithClient creates new HubspotDealFlowAPI with API key and HTTP client
+func NewHubspotDealFlowAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotDealFlowAPI {
 	return HubspotDealFlowAPI{
 		APIKey:     apiKey,
-		httpClient: HTTPClient{},
+		httpClient: httpClient,
+		owners:     newOwnerCache(),
 	}
 }
 
+func (api HubspotDealFlowAPI) objects() crmObjects {
+	return crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: "deals"}
+}
+
 // AssociateDealFlowCard associates a deal flow card with a company or contact using the internal HubSpot dealId and companyId/contactId
 // Choose whether to associate a company or contact by setting assocType to "contact" or "company"
 func (api HubspotDealFlowAPI) AssociateDealFlowCard(dealId, assocId, objectType, assocType string) error {
@@ -104,29 +215,12 @@ func (api HubspotDealFlowAPI) AssociateDealFlowCard(dealId, assocId, objectType,
 		},
 	}
 
-	payloadBuf := new(bytes.Buffer)
-	err := json.NewEncoder(payloadBuf).Encode(associationRequest)
-	if err != nil {
-		return err
-	}
-
-	req, err := http.NewRequest("POST", url, payloadBuf)
-	if err != nil {
-		return err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-
-	_, err = api.httpClient.Do(req)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return doJSONRequest(api.httpClient, "POST", url, associationRequest, nil)
 }
 
 // CreateDealFlowCard creates a deal flow card with the given parameters in HubSpot,
 // and associates it with a company and contact
+// See CreateDeal for how the associations are made
 func (api HubspotDealFlowAPI) CreateDealFlowCard(
 	cardName string,
 	contactID string,
@@ -140,67 +234,61 @@ func (api HubspotDealFlowAPI) CreateDealFlowCard(
 
 	log.Infof("Creating a deal flow card")
 
-	url := fmt.Sprintf("https://api.hubapi.com/crm/v3/objects/deals?hapikey=%s", api.APIKey)
-
-	creationRequest := dealCreationRequest{
-		map[string]string{
-			"dealname":         cardName,
-			"dealstage":        stageName,
-			"pipeline":         pipeline,
-			"hubspot_owner_id": ownerId,
-		},
+	properties := map[string]string{
+		"dealname":         cardName,
+		"dealstage":        stageName,
+		"pipeline":         pipeline,
+		"hubspot_owner_id": ownerId,
 	}
 
 	for key, value := range otherProperties {
-		creationRequest.Properties[key] = value
-	}
-
-	payloadBuf := new(bytes.Buffer)
-	err := json.NewEncoder(payloadBuf).Encode(creationRequest)
-	if err != nil {
-		return nil, err
+		properties[key] = value
 	}
 
-	req, err := http.NewRequest("POST", url, payloadBuf)
-	req.Header.Set("Content-Type", "application/json")
-	if err != nil {
-		return nil, err
+	associations := []DealCreateAssociation{
+		{ObjectType: "company", Id: companyID, Label: "deal_to_company"},
+		{ObjectType: "contact", Id: contactID, Label: contactAssocType},
 	}
 
-	resp, err := api.httpClient.Do(req)
+	var hubspotResp DealCreationResponse
+	err := api.createDeal(properties, associations, &hubspotResp)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+	return &hubspotResp, nil
+}
 
-	var hubspotResp DealCreationResponse
+// CreateDeal creates a deal from the given request, and returns the deal created in HubSpot
+// When all association types are known, the associations are made in the same request as the deal creation.
+// Otherwise the associations are made separately, and if any of them fails the deal is archived,
+// so that no half-built cards are left in the pipeline. If archiving fails as well, a *DealFlowCardPartialFailure is returned.
+func (api HubspotDealFlowAPI) CreateDeal(request DealCreateRequest) (*Deal, error) {
+	log.Infof("Creating deal '%s'", request.Name)
 
-	log.Infof("Raw response: %s", string(body))
-	err = json.Unmarshal(body, &hubspotResp)
+	var deal Deal
+	err := api.createDeal(request.properties(), request.Associations, &deal)
 	if err != nil {
 		return nil, err
 	}
 
-	// Associate the deal with a company based on the application id
-	err = api.AssociateDealFlowCard(hubspotResp.Id, companyID, "company", "deal_to_company")
-	if err != nil {
-		return nil, err
-	}
+	return &deal, nil
+}
 
-	// Associate the deal with a contact based on the application id
-	err = api.AssociateDealFlowCard(hubspotResp.Id, contactID, "contact", contactAssocType)
-	if err != nil {
-		return nil, err
+// createDeal creates a deal with the given properties and associations, and unmarshals the created deal into result
+func (api HubspotDealFlowAPI) createDeal(properties map[string]string, associations []DealCreateAssociation, result interface{}) error {
+	err := api.objects().createAssociated(properties, associations, result)
+
+	var partialFailure *PartialCreationFailure
+	if errors.As(err, &partialFailure) {
+		return &DealFlowCardPartialFailure{
+			DealId:         partialFailure.ObjectId,
+			AssociationErr: partialFailure.AssociationErr,
+			RollbackErr:    partialFailure.RollbackErr,
+		}
 	}
 
-	return &hubspotResp, nil
-
+	return err
 }
 
 // UpdateDealFlowCard updates the deal flow card attached to the given id with the given information
@@ -236,3 +324,13 @@ func (api HubspotDealFlowAPI) UpdateDealFlowCard(
 
 	return nil
 }
+
+// SearchDeals returns an iterator over all the deals matching the search query
+func (api HubspotDealFlowAPI) SearchDeals(query SearchQuery) *SearchIterator {
+	return newSearchIterator(api.APIKey, api.httpClient, "deals", query)
+}
+
+// GetDealPipelines returns all the deal pipelines with their stages
+func (api HubspotDealFlowAPI) GetDealPipelines() ([]Pipeline, error) {
+	return getPipelines(api.httpClient, api.APIKey, "deals")
+}
@@ -0,0 +1,145 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DealMoveOutcome is the outcome of moving a single deal in a bulk move
+// Moved is false without an error when the deal was already in the target stage.
+type DealMoveOutcome struct {
+	DealId string
+	Moved  bool
+	Err    error
+}
+
+// dealStage is the pipeline and stage a deal is currently in
+type dealStage struct {
+	dealId   string
+	pipeline string
+	stage    string
+}
+
+// BulkMoveDeals moves the deals with the given ids to the target stage, using batch updates
+// The target stage is validated against the pipeline of every deal, deals in other pipelines are not moved.
+// An error is only returned when the move could not be attempted at all, failures of single deals are reported in their outcome.
+func (api HubspotDealFlowAPI) BulkMoveDeals(dealIds []string, stageId string) ([]DealMoveOutcome, error) {
+	log.Infof("Moving %d deals to stage '%s'", len(dealIds), stageId)
+
+	raw, readErrs, err := api.objects().batchRead(dealIds, []string{"pipeline", "dealstage"})
+	if err != nil {
+		return nil, err
+	}
+
+	deals := []HubSpotSearchResult{}
+	err = unmarshalAll(raw, &deals)
+	if err != nil {
+		return nil, err
+	}
+
+	foundDeals := map[string]HubSpotSearchResult{}
+	for _, deal := range deals {
+		foundDeals[deal.Id] = deal
+	}
+
+	found := []dealStage{}
+	for _, dealId := range dealIds {
+		if deal, ok := foundDeals[dealId]; ok {
+			found = append(found, dealStage{deal.Id, deal.Properties["pipeline"], deal.Properties["dealstage"]})
+		}
+	}
+
+	outcomes, err := api.moveDeals(found, stageId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]DealMoveOutcome, len(dealIds))
+	for i, dealId := range dealIds {
+		if outcome, ok := outcomes[dealId]; ok {
+			results[i] = outcome
+		} else if readErr, ok := readErrs[dealId]; ok {
+			results[i] = DealMoveOutcome{DealId: dealId, Err: readErr}
+		} else {
+			results[i] = DealMoveOutcome{DealId: dealId, Err: errors.New(fmt.Sprintf("Deal '%s' not found", dealId))}
+		}
+	}
+
+	return results, nil
+}
+
+// BulkMoveDealsMatching moves all the deals matching the search query to the target stage, see BulkMoveDeals
+func (api HubspotDealFlowAPI) BulkMoveDealsMatching(query SearchQuery, stageId string) ([]DealMoveOutcome, error) {
+	query.Properties = append(append([]string{}, query.Properties...), "pipeline", "dealstage")
+
+	dealIds := []string{}
+	found := []dealStage{}
+	it := api.SearchDeals(query)
+	for it.Next() {
+		deal := it.Result()
+		dealIds = append(dealIds, deal.Id)
+		found = append(found, dealStage{deal.Id, deal.Properties["pipeline"], deal.Properties["dealstage"]})
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	log.Infof("Moving %d deals to stage '%s'", len(dealIds), stageId)
+
+	outcomes, err := api.moveDeals(found, stageId)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]DealMoveOutcome, len(dealIds))
+	for i, dealId := range dealIds {
+		results[i] = outcomes[dealId]
+	}
+
+	return results, nil
+}
+
+// moveDeals validates the target stage for each of the deals and moves them with batch updates
+func (api HubspotDealFlowAPI) moveDeals(deals []dealStage, stageId string) (map[string]DealMoveOutcome, error) {
+	pipelines, err := api.GetDealPipelines()
+	if err != nil {
+		return nil, err
+	}
+
+	pipelinesById := map[string]Pipeline{}
+	for _, pipeline := range pipelines {
+		pipelinesById[pipeline.Id] = pipeline
+	}
+
+	outcomes := map[string]DealMoveOutcome{}
+	inputs := []BatchUpdateInput{}
+	for _, deal := range deals {
+		dealId := deal.dealId
+		pipeline, ok := pipelinesById[deal.pipeline]
+		if !ok {
+			outcomes[dealId] = DealMoveOutcome{DealId: dealId, Err: errors.New(fmt.Sprintf("Pipeline '%s' of deal '%s' not found", deal.pipeline, dealId))}
+		} else if _, ok := pipeline.Stage(stageId); !ok {
+			outcomes[dealId] = DealMoveOutcome{DealId: dealId, Err: errors.New(fmt.Sprintf("Stage '%s' is not in pipeline '%s' of deal '%s'", stageId, deal.pipeline, dealId))}
+		} else if deal.stage == stageId {
+			outcomes[dealId] = DealMoveOutcome{DealId: dealId}
+		} else {
+			inputs = append(inputs, BatchUpdateInput{
+				Id:         dealId,
+				Properties: map[string]string{"dealstage": stageId},
+			})
+		}
+	}
+
+	_, updateErrs := api.objects().batchUpdate(inputs)
+	for _, input := range inputs {
+		if updateErr, ok := updateErrs[input.Id]; ok {
+			outcomes[input.Id] = DealMoveOutcome{DealId: input.Id, Err: updateErr}
+		} else {
+			outcomes[input.Id] = DealMoveOutcome{DealId: input.Id, Moved: true}
+		}
+	}
+
+	return outcomes, nil
+}
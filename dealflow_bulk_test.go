@@ -0,0 +1,122 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var dealPipelinesResponse = pipelinesResponse{
+	Results: []Pipeline{
+		{
+			Id:    "default",
+			Label: "Deal flow",
+			Stages: []PipelineStage{
+				{Id: "applied", Label: "Applied", DisplayOrder: 0},
+				{Id: "interview", Label: "Interview", DisplayOrder: 1},
+				{Id: "selected", Label: "Selected", DisplayOrder: 2},
+			},
+		},
+		{
+			Id:    "other",
+			Label: "Other",
+			Stages: []PipelineStage{
+				{Id: "other-stage", Label: "Other stage", DisplayOrder: 0},
+			},
+		},
+	},
+}
+
+func TestBulkMoveDeals(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/deals/batch/read?hapikey=api_key" {
+				var request batchReadRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := batchReadRequest{
+					Properties: []string{"pipeline", "dealstage"},
+					Inputs:     []batchId{{"1"}, {"2"}, {"3"}, {"4"}, {"5"}},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected batch read request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 207, batchResponse{
+					Status: "COMPLETE",
+					Results: []HubSpotSearchResult{
+						{Id: "1", Properties: map[string]string{"pipeline": "default", "dealstage": "applied"}},
+						{Id: "2", Properties: map[string]string{"pipeline": "default", "dealstage": "selected"}},
+						{Id: "3", Properties: map[string]string{"pipeline": "other", "dealstage": "other-stage"}},
+						{Id: "4", Properties: map[string]string{"pipeline": "default", "dealstage": "interview"}},
+					},
+					Errors: []BatchError{
+						{Status: "error", Category: "OBJECT_NOT_FOUND", Context: map[string][]string{"ids": {"5"}}},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/pipelines/deals?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, dealPipelinesResponse)
+			} else if url == "https://api.hubapi.com/crm/v3/objects/deals/batch/update?hapikey=api_key" {
+				var request batchUpdateRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := batchUpdateRequest{
+					Inputs: []BatchUpdateInput{
+						{Id: "1", Properties: map[string]string{"dealstage": "selected"}},
+						{Id: "4", Properties: map[string]string{"dealstage": "selected"}},
+					},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected batch update request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 207, batchResponse{
+					Status:  "COMPLETE",
+					Results: []HubSpotSearchResult{{Id: "1"}},
+					Errors: []BatchError{
+						{Status: "error", Category: "VALIDATION_ERROR", Context: map[string][]string{"ids": {"4"}}},
+					},
+				})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	outcomes, err := api.BulkMoveDeals([]string{"1", "2", "3", "4", "5"}, "selected")
+	if err != nil {
+		t.Errorf("BulkMoveDeals returned an error: %s", err.Error())
+		return
+	}
+
+	if len(outcomes) != 5 {
+		t.Errorf("Expected 5 outcomes, got: %v", outcomes)
+		return
+	}
+
+	expectedMoved := []bool{true, false, false, false, false}
+	expectedErr := []bool{false, false, true, true, true}
+	for i, outcome := range outcomes {
+		if outcome.DealId != fmt.Sprintf("%d", i+1) {
+			t.Errorf("Unexpected order of outcomes: %v", outcomes)
+		}
+		if outcome.Moved != expectedMoved[i] || (outcome.Err != nil) != expectedErr[i] {
+			t.Errorf("Unexpected outcome for deal %s: %v", outcome.DealId, outcome)
+		}
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 3 {
+		t.Errorf("Expected 3 calls to HubSpot API")
+	}
+}
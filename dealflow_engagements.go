@@ -0,0 +1,30 @@
+package go_hubspot
+
+import (
+	"time"
+)
+
+func (api HubspotDealFlowAPI) engagementAPI() HubspotEngagementAPI {
+	return HubspotEngagementAPI{APIKey: api.APIKey, httpClient: api.httpClient}
+}
+
+// MoveDealFlowCardWithNote moves a deal flow card to another stage, and logs a note explaining the move against the deal
+func (api HubspotDealFlowAPI) MoveDealFlowCardWithNote(dealId, stageId, note, ownerId string) error {
+	err := api.objects().update(dealId, map[string]string{"dealstage": stageId}, nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = api.LogDealNote(dealId, note, ownerId)
+	return err
+}
+
+// LogDealNote logs a note against a deal
+func (api HubspotDealFlowAPI) LogDealNote(dealId, note, ownerId string) (*Engagement, error) {
+	return api.engagementAPI().CreateNote(note, ownerId, []ObjectAssociation{{ObjectType: "deals", Id: dealId}})
+}
+
+// CreateDealTask creates a task for the given owner on a deal
+func (api HubspotDealFlowAPI) CreateDealTask(dealId, subject, body, ownerId string, dueDate time.Time) (*Engagement, error) {
+	return api.engagementAPI().CreateTask(subject, body, ownerId, dueDate, []ObjectAssociation{{ObjectType: "deals", Id: dealId}})
+}
@@ -0,0 +1,57 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMoveDealFlowCardWithNote(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "PATCH https://api.hubapi.com/crm/v3/objects/deals/dealId?hapikey=api_key" {
+				var request dealUpdateRequest
+				readJSONRequest(t, req, &request)
+
+				if request.Properties["dealstage"] != "selected" {
+					t.Errorf("Unexpected deal update: %v", request.Properties)
+				}
+
+				w.WriteHeader(200)
+			} else if url == "POST https://api.hubapi.com/crm/v3/objects/notes?hapikey=api_key" {
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				if request.Properties["hs_note_body"] != "Selected by the panel" || request.Properties["hubspot_owner_id"] != "ownerId" {
+					t.Errorf("Unexpected note properties: %v", request.Properties)
+				}
+
+				if len(request.Associations) != 1 || request.Associations[0].Types[0].AssociationTypeId != 214 {
+					t.Errorf("Unexpected note associations: %v", request.Associations)
+				}
+
+				writeJSONResponse(t, w, 201, HubSpotSearchResult{Id: "noteId"})
+			} else {
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	err := api.MoveDealFlowCardWithNote("dealId", "selected", "Selected by the panel", "ownerId")
+	if err != nil {
+		t.Errorf("MoveDealFlowCardWithNote returned an error: %s", err.Error())
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 2 {
+		t.Errorf("Expected 2 calls to HubSpot API")
+	}
+}
@@ -0,0 +1,193 @@
+package go_hubspot
+
+import (
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StageTransition is a deal entering a stage, ExitedAt is zero while the deal is still in the stage
+type StageTransition struct {
+	StageId         string
+	EnteredAt       time.Time
+	ExitedAt        time.Time
+	SourceType      string
+	UpdatedByUserId int64
+}
+
+// TimeInStage returns how long the deal spent in the stage, up to now if the deal is still in the stage
+func (transition StageTransition) TimeInStage(now time.Time) time.Duration {
+	if transition.ExitedAt.IsZero() {
+		return now.Sub(transition.EnteredAt)
+	}
+	return transition.ExitedAt.Sub(transition.EnteredAt)
+}
+
+// StageAnalytics is how deals moved through a single stage of a pipeline
+// TimeInStage statistics only include deals that have left the stage.
+// ConversionRate is the share of the deals that entered the stage which went on to a later stage in display order.
+type StageAnalytics struct {
+	StageId            string
+	Label              string
+	Entered            int
+	Exited             int
+	Current            int
+	AverageTimeInStage time.Duration
+	MedianTimeInStage  time.Duration
+	ConversionRate     float64
+}
+
+// PipelineStageAnalytics is how the deals created in a date range moved through the stages of a pipeline
+type PipelineStageAnalytics struct {
+	PipelineId string
+	From       time.Time
+	To         time.Time
+	Deals      int
+	Stages     []StageAnalytics
+}
+
+// GetDealStageHistory returns the stages the deal has been in, ordered from oldest to newest
+func (api HubspotDealFlowAPI) GetDealStageHistory(dealId string) ([]StageTransition, error) {
+	deal, err := getObjectWithHistory(api.httpClient, api.APIKey, "deals", dealId, []string{"dealstage"})
+	if err != nil {
+		return nil, err
+	}
+
+	history := deal.PropertiesWithHistory["dealstage"]
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Timestamp.Before(history[j].Timestamp)
+	})
+
+	transitions := []StageTransition{}
+	for _, value := range history {
+		if len(transitions) > 0 {
+			last := &transitions[len(transitions)-1]
+			if last.StageId == value.Value {
+				// The stage was set again without changing
+				continue
+			}
+			last.ExitedAt = value.Timestamp
+		}
+
+		transitions = append(transitions, StageTransition{
+			StageId:         value.Value,
+			EnteredAt:       value.Timestamp,
+			SourceType:      value.SourceType,
+			UpdatedByUserId: value.UpdatedByUserId,
+		})
+	}
+
+	return transitions, nil
+}
+
+// GetStageAnalytics aggregates time in stage and conversion rates for the deals of a pipeline created between from and to,
+// using the hs_date_entered_* and hs_date_exited_* properties HubSpot maintains for every stage
+func (api HubspotDealFlowAPI) GetStageAnalytics(pipelineId string, from, to time.Time) (*PipelineStageAnalytics, error) {
+	log.Infof("Computing stage analytics for pipeline '%s' between %s and %s", pipelineId, from, to)
+
+	pipeline, err := getPipeline(api.httpClient, api.APIKey, "deals", pipelineId)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]PipelineStage, len(pipeline.Stages))
+	copy(stages, pipeline.Stages)
+	sort.SliceStable(stages, func(i, j int) bool {
+		return stages[i].DisplayOrder < stages[j].DisplayOrder
+	})
+
+	properties := []string{}
+	for _, stage := range stages {
+		properties = append(properties, "hs_date_entered_"+stage.Id, "hs_date_exited_"+stage.Id)
+	}
+
+	deals, err := api.SearchDeals(SearchQuery{
+		Filters: []SearchFilter{
+			{PropertyName: "pipeline", Operator: "EQ", Value: pipelineId},
+			{PropertyName: "createdate", Operator: "GTE", Value: formatHubSpotTime(from)},
+			{PropertyName: "createdate", Operator: "LTE", Value: formatHubSpotTime(to)},
+		},
+		Properties: properties,
+	}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := PipelineStageAnalytics{
+		PipelineId: pipelineId,
+		From:       from,
+		To:         to,
+		Deals:      len(deals),
+		Stages:     make([]StageAnalytics, len(stages)),
+	}
+
+	durations := make([][]time.Duration, len(stages))
+	converted := make([]int, len(stages))
+	for _, deal := range deals {
+		entered := make([]bool, len(stages))
+		for i, stage := range stages {
+			enteredAt, err := parseHubSpotTime(deal.Properties["hs_date_entered_"+stage.Id])
+			if err != nil {
+				continue
+			}
+			entered[i] = true
+			analytics.Stages[i].Entered++
+
+			exitedAt, err := parseHubSpotTime(deal.Properties["hs_date_exited_"+stage.Id])
+			if err != nil {
+				analytics.Stages[i].Current++
+				continue
+			}
+			analytics.Stages[i].Exited++
+			durations[i] = append(durations[i], exitedAt.Sub(enteredAt))
+		}
+
+		for i := range stages {
+			if !entered[i] {
+				continue
+			}
+			for j := i + 1; j < len(stages); j++ {
+				if entered[j] {
+					converted[i]++
+					break
+				}
+			}
+		}
+	}
+
+	for i, stage := range stages {
+		stageAnalytics := &analytics.Stages[i]
+		stageAnalytics.StageId = stage.Id
+		stageAnalytics.Label = stage.Label
+		stageAnalytics.AverageTimeInStage, stageAnalytics.MedianTimeInStage = durationStatistics(durations[i])
+		if stageAnalytics.Entered > 0 {
+			stageAnalytics.ConversionRate = float64(converted[i]) / float64(stageAnalytics.Entered)
+		}
+	}
+
+	return &analytics, nil
+}
+
+// durationStatistics returns the average and median of the durations, or zero if there are none
+func durationStatistics(durations []time.Duration) (time.Duration, time.Duration) {
+	if len(durations) == 0 {
+		return 0, 0
+	}
+
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, duration := range sorted {
+		total += duration
+	}
+
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+
+	return total / time.Duration(len(sorted)), median
+}
@@ -0,0 +1,171 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetDealStageHistory(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/deals/dealId?propertiesWithHistory=dealstage&hapikey=api_key" {
+				w.WriteHeader(200)
+				_, _ = w.Write([]byte(`{
+					"id": "dealId",
+					"propertiesWithHistory": {
+						"dealstage": [
+							{"value": "selected", "timestamp": "2021-06-10T00:00:00.000Z", "sourceType": "CRM_UI", "updatedByUserId": 42},
+							{"value": "interview", "timestamp": "2021-06-05T00:00:00.000Z", "sourceType": "INTEGRATION"},
+							{"value": "interview", "timestamp": "2021-06-03T00:00:00.000Z", "sourceType": "INTEGRATION"},
+							{"value": "applied", "timestamp": "2021-06-01T00:00:00.000Z", "sourceType": "INTEGRATION"}
+						]
+					}
+				}`))
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	transitions, err := api.GetDealStageHistory("dealId")
+	if err != nil {
+		t.Errorf("GetDealStageHistory returned an error: %s", err.Error())
+		return
+	}
+
+	day := func(d int) time.Time { return time.Date(2021, 6, d, 0, 0, 0, 0, time.UTC) }
+	expected := []StageTransition{
+		{StageId: "applied", EnteredAt: day(1), ExitedAt: day(3), SourceType: "INTEGRATION"},
+		{StageId: "interview", EnteredAt: day(3), ExitedAt: day(10), SourceType: "INTEGRATION"},
+		{StageId: "selected", EnteredAt: day(10), SourceType: "CRM_UI", UpdatedByUserId: 42},
+	}
+
+	if !cmp.Equal(expected, transitions) {
+		t.Errorf("GetDealStageHistory returned incorrect transitions, expected:\n%v\ngot:\n%v", expected, transitions)
+	}
+
+	if transitions[0].TimeInStage(day(30)) != 48*time.Hour {
+		t.Errorf("Expected 48h in the first stage, got %s", transitions[0].TimeInStage(day(30)))
+	}
+}
+
+func TestGetStageAnalytics(t *testing.T) {
+	from := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/pipelines/deals/default?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, Pipeline{
+					Id: "default",
+					Stages: []PipelineStage{
+						{Id: "applied", Label: "Applied", DisplayOrder: 0},
+						{Id: "interview", Label: "Interview", DisplayOrder: 1},
+						{Id: "selected", Label: "Selected", DisplayOrder: 2},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/deals/search?hapikey=api_key" {
+				var request searchPageRequest
+				readJSONRequest(t, req, &request)
+
+				expectedFilters := []SearchFilter{
+					{PropertyName: "pipeline", Operator: "EQ", Value: "default"},
+					{PropertyName: "createdate", Operator: "GTE", Value: "1622505600000"},
+					{PropertyName: "createdate", Operator: "LTE", Value: "1625097600000"},
+				}
+				if !cmp.Equal(expectedFilters, request.FilterGroups[0].Filters) {
+					t.Errorf("Unexpected search filters, expected:\n%v\ngot:\n%v", expectedFilters, request.FilterGroups[0].Filters)
+				}
+
+				writeJSONResponse(t, w, 200, searchPageResponse{
+					Total: 3,
+					Results: []HubSpotSearchResult{
+						{Id: "1", Properties: map[string]string{
+							"hs_date_entered_applied":   "2021-06-01T00:00:00Z",
+							"hs_date_exited_applied":    "2021-06-03T00:00:00Z",
+							"hs_date_entered_interview": "2021-06-03T00:00:00Z",
+							"hs_date_exited_interview":  "2021-06-04T00:00:00Z",
+							"hs_date_entered_selected":  "2021-06-04T00:00:00Z",
+						}},
+						{Id: "2", Properties: map[string]string{
+							"hs_date_entered_applied": "2021-06-01T00:00:00Z",
+							"hs_date_exited_applied":  "2021-06-05T00:00:00Z",
+							// Skipped the interview stage
+							"hs_date_entered_selected": "2021-06-05T00:00:00Z",
+						}},
+						{Id: "3", Properties: map[string]string{
+							"hs_date_entered_applied": "2021-06-01T00:00:00Z",
+						}},
+					},
+				})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	analytics, err := api.GetStageAnalytics("default", from, to)
+	if err != nil {
+		t.Errorf("GetStageAnalytics returned an error: %s", err.Error())
+		return
+	}
+
+	expected := PipelineStageAnalytics{
+		PipelineId: "default",
+		From:       from,
+		To:         to,
+		Deals:      3,
+		Stages: []StageAnalytics{
+			{
+				StageId:            "applied",
+				Label:              "Applied",
+				Entered:            3,
+				Exited:             2,
+				Current:            1,
+				AverageTimeInStage: 72 * time.Hour,
+				MedianTimeInStage:  72 * time.Hour,
+				ConversionRate:     2.0 / 3.0,
+			},
+			{
+				StageId:            "interview",
+				Label:              "Interview",
+				Entered:            1,
+				Exited:             1,
+				AverageTimeInStage: 24 * time.Hour,
+				MedianTimeInStage:  24 * time.Hour,
+				ConversionRate:     1,
+			},
+			{
+				StageId: "selected",
+				Label:   "Selected",
+				Entered: 2,
+				Current: 2,
+			},
+		},
+	}
+
+	if !cmp.Equal(expected, *analytics) {
+		t.Errorf("GetStageAnalytics returned incorrect analytics, expected:\n%v\ngot:\n%v", expected, *analytics)
+	}
+}
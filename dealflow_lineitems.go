@@ -0,0 +1,130 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+func (api HubspotDealFlowAPI) lineItemAPI() HubspotLineItemAPI {
+	return HubspotLineItemAPI{APIKey: api.APIKey, httpClient: api.httpClient}
+}
+
+// AddLineItemToDeal creates a line item with the given properties on the deal
+func (api HubspotDealFlowAPI) AddLineItemToDeal(dealId string, properties map[string]string) (*LineItem, error) {
+	association, _ := newInlineAssociation(dealId, "line_item_to_deal")
+
+	return api.lineItemAPI().createLineItem(objectCreationRequest{
+		Properties:   properties,
+		Associations: []inlineAssociation{association},
+	})
+}
+
+// AddProductToDeal creates a line item for the given quantity of a product on the deal,
+// the name and price of the line item are taken from the product
+func (api HubspotDealFlowAPI) AddProductToDeal(dealId, productId string, quantity int) (*LineItem, error) {
+	return api.AddLineItemToDeal(dealId, map[string]string{
+		"hs_product_id": productId,
+		"quantity":      strconv.Itoa(quantity),
+	})
+}
+
+// GetDealLineItems returns the line items of the deal
+func (api HubspotDealFlowAPI) GetDealLineItems(dealId string) ([]LineItem, error) {
+	lineItemIds, err := api.objects().associatedIds(dealId, "line_items")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lineItemIds) == 0 {
+		return []LineItem{}, nil
+	}
+
+	return api.lineItemAPI().BatchReadLineItems(lineItemIds)
+}
+
+// RemoveLineItemFromDeal archives a line item of the deal, line items only ever belong to a single deal
+func (api HubspotDealFlowAPI) RemoveLineItemFromDeal(dealId, lineItemId string) error {
+	lineItems, err := api.GetDealLineItems(dealId)
+	if err != nil {
+		return err
+	}
+
+	for _, lineItem := range lineItems {
+		if lineItem.Id == lineItemId {
+			return api.lineItemAPI().ArchiveLineItem(lineItemId)
+		}
+	}
+
+	return errors.New(fmt.Sprintf("Line item '%s' is not on deal '%s'", lineItemId, dealId))
+}
+
+// RecomputeDealAmount sets the amount of the deal to the total of its line items, and returns the new amount
+func (api HubspotDealFlowAPI) RecomputeDealAmount(dealId string) (string, error) {
+	lineItems, err := api.GetDealLineItems(dealId)
+	if err != nil {
+		return "", err
+	}
+
+	total := new(big.Rat)
+	for _, lineItem := range lineItems {
+		amount, err := lineItemAmount(lineItem)
+		if err != nil {
+			return "", err
+		}
+		total.Add(total, amount)
+	}
+
+	amount := total.FloatString(2)
+	log.Infof("Setting amount of deal '%s' to %s from %d line items", dealId, amount, len(lineItems))
+
+	err = api.objects().update(dealId, map[string]string{"amount": amount}, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return amount, nil
+}
+
+// lineItemAmount returns the amount of a line item, computing it from the price, quantity and discount if HubSpot has not
+// The discount is per unit, as in HubSpot, so the amount is quantity * (price - discount).
+func lineItemAmount(lineItem LineItem) (*big.Rat, error) {
+	if lineItem.Properties.Amount != "" {
+		return parseDecimal(lineItem.Id, lineItem.Properties.Amount)
+	}
+
+	price, err := parseDecimal(lineItem.Id, lineItem.Properties.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	quantity, err := parseDecimal(lineItem.Id, lineItem.Properties.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	discount, err := parseDecimal(lineItem.Id, lineItem.Properties.Discount)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := new(big.Rat).Sub(price, discount)
+	return amount.Mul(amount, quantity), nil
+}
+
+// parseDecimal parses a decimal property value of a line item, an empty value is zero
+func parseDecimal(lineItemId, value string) (*big.Rat, error) {
+	if value == "" {
+		return new(big.Rat), nil
+	}
+
+	decimal, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Invalid decimal '%s' on line item '%s'", value, lineItemId))
+	}
+
+	return decimal, nil
+}
@@ -0,0 +1,134 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAddProductToDeal(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/line_items?hapikey=api_key" {
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				if request.Properties["hs_product_id"] != "productId" || request.Properties["quantity"] != "3" {
+					t.Errorf("Unexpected line item properties: %v", request.Properties)
+				}
+
+				if len(request.Associations) != 1 ||
+					request.Associations[0].To.Id != "dealId" ||
+					request.Associations[0].Types[0].AssociationTypeId != 20 {
+					t.Errorf("Unexpected line item associations: %v", request.Associations)
+				}
+
+				writeJSONResponse(t, w, 201, LineItem{Id: "lineItemId"})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	lineItem, err := api.AddProductToDeal("dealId", "productId", 3)
+	if err != nil {
+		t.Errorf("AddProductToDeal returned an error: %s", err.Error())
+		return
+	}
+
+	if lineItem.Id != "lineItemId" {
+		t.Errorf("AddProductToDeal returned incorrect line item: %v", lineItem)
+	}
+}
+
+func TestRecomputeDealAmount(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/deals/dealId/associations/line_items?hapikey=api_key&limit=500" {
+				writeJSONResponse(t, w, 200, associatedIdsResponse{
+					Results: []Association{{"1", "deal_to_line_item"}},
+					Paging:  &Paging{Next: map[string]string{"after": "1"}},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/deals/dealId/associations/line_items?after=1&hapikey=api_key&limit=500" {
+				writeJSONResponse(t, w, 200, associatedIdsResponse{
+					Results: []Association{{"2", "deal_to_line_item"}},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/line_items/batch/read?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, map[string][]LineItem{
+					"results": {
+						{Id: "1", Properties: LineItemProperties{Amount: "150.50"}},
+						{Id: "2", Properties: LineItemProperties{Price: "19.99", Quantity: "3", Discount: "5"}},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/deals/dealId?hapikey=api_key" {
+				if req.Method != "PATCH" {
+					t.Errorf("Deal update used %s, instead of PATCH", req.Method)
+				}
+
+				var request dealUpdateRequest
+				readJSONRequest(t, req, &request)
+				if request.Properties["amount"] != "195.47" {
+					t.Errorf("Unexpected deal amount: %s", request.Properties["amount"])
+				}
+
+				w.WriteHeader(200)
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	amount, err := api.RecomputeDealAmount("dealId")
+	if err != nil {
+		t.Errorf("RecomputeDealAmount returned an error: %s", err.Error())
+		return
+	}
+
+	if amount != "195.47" {
+		t.Errorf("RecomputeDealAmount returned incorrect amount, expected: 195.47, got: %s", amount)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 4 {
+		t.Errorf("Expected 4 calls to HubSpot API")
+	}
+}
+
+func TestLineItemAmount(t *testing.T) {
+	cases := []struct {
+		properties LineItemProperties
+		expected   string
+	}{
+		{LineItemProperties{Amount: "150.50", Price: "10", Quantity: "2"}, "150.50"},
+		{LineItemProperties{Price: "19.99", Quantity: "3"}, "59.97"},
+		{LineItemProperties{Price: "19.99", Quantity: "3", Discount: "5"}, "44.97"},
+		{LineItemProperties{Price: "100", Quantity: "1", Discount: "12.50"}, "87.50"},
+	}
+
+	for _, c := range cases {
+		amount, err := lineItemAmount(LineItem{Id: "lineItemId", Properties: c.properties})
+		if err != nil {
+			t.Errorf("lineItemAmount returned an error for %v: %s", c.properties, err.Error())
+			continue
+		}
+		if amount.FloatString(2) != c.expected {
+			t.Errorf("Unexpected amount of %v, expected: %s, got: %s", c.properties, c.expected, amount.FloatString(2))
+		}
+	}
+}
@@ -5,6 +5,7 @@ package go_hubspot
 
 import (
 	"sync"
+	"time"
 )
 
 // Ensure, that IHubspotDealFlowAPIMock does implement IHubspotDealFlowAPI.
@@ -17,12 +18,69 @@ var _ IHubspotDealFlowAPI = &IHubspotDealFlowAPIMock{}
 //
 // 		// make and configure a mocked IHubspotDealFlowAPI
 // 		mockedIHubspotDealFlowAPI := &IHubspotDealFlowAPIMock{
+// 			AddLineItemToDealFunc: func(dealId string, properties map[string]string) (*LineItem, error) {
+// 				panic("mock out the AddLineItemToDeal method")
+// 			},
+// 			AddProductToDealFunc: func(dealId string, productId string, quantity int) (*LineItem, error) {
+// 				panic("mock out the AddProductToDeal method")
+// 			},
+// 			AssignDealOwnerFunc: func(dealId string, ownerEmail string) error {
+// 				panic("mock out the AssignDealOwner method")
+// 			},
 // 			AssociateDealFlowCardFunc: func(dealId string, assocId string, objectType string, assocType string) error {
 // 				panic("mock out the AssociateDealFlowCard method")
 // 			},
+// 			BulkMoveDealsFunc: func(dealIds []string, stageId string) ([]DealMoveOutcome, error) {
+// 				panic("mock out the BulkMoveDeals method")
+// 			},
+// 			BulkMoveDealsMatchingFunc: func(query SearchQuery, stageId string) ([]DealMoveOutcome, error) {
+// 				panic("mock out the BulkMoveDealsMatching method")
+// 			},
+// 			CreateDealFunc: func(request DealCreateRequest) (*Deal, error) {
+// 				panic("mock out the CreateDeal method")
+// 			},
 // 			CreateDealFlowCardFunc: func(cardName string, contactID string, contactAssocType string, companyID string, stageName string, pipeline string, ownerId string, otherProperties map[string]string) (*DealCreationResponse, error) {
 // 				panic("mock out the CreateDealFlowCard method")
 // 			},
+// 			CreateDealFlowCardForOwnerFunc: func(cardName string, contactID string, contactAssocType string, companyID string, stageName string, pipeline string, ownerEmail string, otherProperties map[string]string) (*DealCreationResponse, error) {
+// 				panic("mock out the CreateDealFlowCardForOwner method")
+// 			},
+// 			CreateDealTaskFunc: func(dealId string, subject string, body string, ownerId string, dueDate time.Time) (*Engagement, error) {
+// 				panic("mock out the CreateDealTask method")
+// 			},
+// 			FindStaleDealsFunc: func(options StaleDealOptions) (*StaleDealReport, error) {
+// 				panic("mock out the FindStaleDeals method")
+// 			},
+// 			GetDealLineItemsFunc: func(dealId string) ([]LineItem, error) {
+// 				panic("mock out the GetDealLineItems method")
+// 			},
+// 			GetDealPipelinesFunc: func() ([]Pipeline, error) {
+// 				panic("mock out the GetDealPipelines method")
+// 			},
+// 			GetDealStageHistoryFunc: func(dealId string) ([]StageTransition, error) {
+// 				panic("mock out the GetDealStageHistory method")
+// 			},
+// 			GetStageAnalyticsFunc: func(pipelineId string, from time.Time, to time.Time) (*PipelineStageAnalytics, error) {
+// 				panic("mock out the GetStageAnalytics method")
+// 			},
+// 			LogDealNoteFunc: func(dealId string, note string, ownerId string) (*Engagement, error) {
+// 				panic("mock out the LogDealNote method")
+// 			},
+// 			MoveDealFlowCardWithNoteFunc: func(dealId string, stageId string, note string, ownerId string) error {
+// 				panic("mock out the MoveDealFlowCardWithNote method")
+// 			},
+// 			RecomputeDealAmountFunc: func(dealId string) (string, error) {
+// 				panic("mock out the RecomputeDealAmount method")
+// 			},
+// 			RemoveLineItemFromDealFunc: func(dealId string, lineItemId string) error {
+// 				panic("mock out the RemoveLineItemFromDeal method")
+// 			},
+// 			SearchDealsFunc: func(query SearchQuery) *SearchIterator {
+// 				panic("mock out the SearchDeals method")
+// 			},
+// 			SnapshotPipelineFunc: func(pipelineId string, properties []string) (*PipelineSnapshot, error) {
+// 				panic("mock out the SnapshotPipeline method")
+// 			},
 // 			UpdateDealFlowCardFunc: func(dealId string, properties map[string]string) error {
 // 				panic("mock out the UpdateDealFlowCard method")
 // 			},
@@ -33,17 +91,97 @@ var _ IHubspotDealFlowAPI = &IHubspotDealFlowAPIMock{}
 //
 // 	}
 type IHubspotDealFlowAPIMock struct {
+	// AddLineItemToDealFunc mocks the AddLineItemToDeal method.
+	AddLineItemToDealFunc func(dealId string, properties map[string]string) (*LineItem, error)
+
+	// AddProductToDealFunc mocks the AddProductToDeal method.
+	AddProductToDealFunc func(dealId string, productId string, quantity int) (*LineItem, error)
+
+	// AssignDealOwnerFunc mocks the AssignDealOwner method.
+	AssignDealOwnerFunc func(dealId string, ownerEmail string) error
+
 	// AssociateDealFlowCardFunc mocks the AssociateDealFlowCard method.
 	AssociateDealFlowCardFunc func(dealId string, assocId string, objectType string, assocType string) error
 
+	// BulkMoveDealsFunc mocks the BulkMoveDeals method.
+	BulkMoveDealsFunc func(dealIds []string, stageId string) ([]DealMoveOutcome, error)
+
+	// BulkMoveDealsMatchingFunc mocks the BulkMoveDealsMatching method.
+	BulkMoveDealsMatchingFunc func(query SearchQuery, stageId string) ([]DealMoveOutcome, error)
+
+	// CreateDealFunc mocks the CreateDeal method.
+	CreateDealFunc func(request DealCreateRequest) (*Deal, error)
+
 	// CreateDealFlowCardFunc mocks the CreateDealFlowCard method.
 	CreateDealFlowCardFunc func(cardName string, contactID string, contactAssocType string, companyID string, stageName string, pipeline string, ownerId string, otherProperties map[string]string) (*DealCreationResponse, error)
 
+	// CreateDealFlowCardForOwnerFunc mocks the CreateDealFlowCardForOwner method.
+	CreateDealFlowCardForOwnerFunc func(cardName string, contactID string, contactAssocType string, companyID string, stageName string, pipeline string, ownerEmail string, otherProperties map[string]string) (*DealCreationResponse, error)
+
+	// CreateDealTaskFunc mocks the CreateDealTask method.
+	CreateDealTaskFunc func(dealId string, subject string, body string, ownerId string, dueDate time.Time) (*Engagement, error)
+
+	// FindStaleDealsFunc mocks the FindStaleDeals method.
+	FindStaleDealsFunc func(options StaleDealOptions) (*StaleDealReport, error)
+
+	// GetDealLineItemsFunc mocks the GetDealLineItems method.
+	GetDealLineItemsFunc func(dealId string) ([]LineItem, error)
+
+	// GetDealPipelinesFunc mocks the GetDealPipelines method.
+	GetDealPipelinesFunc func() ([]Pipeline, error)
+
+	// GetDealStageHistoryFunc mocks the GetDealStageHistory method.
+	GetDealStageHistoryFunc func(dealId string) ([]StageTransition, error)
+
+	// GetStageAnalyticsFunc mocks the GetStageAnalytics method.
+	GetStageAnalyticsFunc func(pipelineId string, from time.Time, to time.Time) (*PipelineStageAnalytics, error)
+
+	// LogDealNoteFunc mocks the LogDealNote method.
+	LogDealNoteFunc func(dealId string, note string, ownerId string) (*Engagement, error)
+
+	// MoveDealFlowCardWithNoteFunc mocks the MoveDealFlowCardWithNote method.
+	MoveDealFlowCardWithNoteFunc func(dealId string, stageId string, note string, ownerId string) error
+
+	// RecomputeDealAmountFunc mocks the RecomputeDealAmount method.
+	RecomputeDealAmountFunc func(dealId string) (string, error)
+
+	// RemoveLineItemFromDealFunc mocks the RemoveLineItemFromDeal method.
+	RemoveLineItemFromDealFunc func(dealId string, lineItemId string) error
+
+	// SearchDealsFunc mocks the SearchDeals method.
+	SearchDealsFunc func(query SearchQuery) *SearchIterator
+
+	// SnapshotPipelineFunc mocks the SnapshotPipeline method.
+	SnapshotPipelineFunc func(pipelineId string, properties []string) (*PipelineSnapshot, error)
+
 	// UpdateDealFlowCardFunc mocks the UpdateDealFlowCard method.
 	UpdateDealFlowCardFunc func(dealId string, properties map[string]string) error
 
 	// calls tracks calls to the methods.
 	calls struct {
+		// AddLineItemToDeal holds details about calls to the AddLineItemToDeal method.
+		AddLineItemToDeal []struct {
+			// DealId is the dealId argument value.
+			DealId string
+			// Properties is the properties argument value.
+			Properties map[string]string
+		}
+		// AddProductToDeal holds details about calls to the AddProductToDeal method.
+		AddProductToDeal []struct {
+			// DealId is the dealId argument value.
+			DealId string
+			// ProductId is the productId argument value.
+			ProductId string
+			// Quantity is the quantity argument value.
+			Quantity int
+		}
+		// AssignDealOwner holds details about calls to the AssignDealOwner method.
+		AssignDealOwner []struct {
+			// DealId is the dealId argument value.
+			DealId string
+			// OwnerEmail is the ownerEmail argument value.
+			OwnerEmail string
+		}
 		// AssociateDealFlowCard holds details about calls to the AssociateDealFlowCard method.
 		AssociateDealFlowCard []struct {
 			// DealId is the dealId argument value.
@@ -55,6 +193,25 @@ type IHubspotDealFlowAPIMock struct {
 			// AssocType is the assocType argument value.
 			AssocType string
 		}
+		// BulkMoveDeals holds details about calls to the BulkMoveDeals method.
+		BulkMoveDeals []struct {
+			// DealIds is the dealIds argument value.
+			DealIds []string
+			// StageId is the stageId argument value.
+			StageId string
+		}
+		// BulkMoveDealsMatching holds details about calls to the BulkMoveDealsMatching method.
+		BulkMoveDealsMatching []struct {
+			// Query is the query argument value.
+			Query SearchQuery
+			// StageId is the stageId argument value.
+			StageId string
+		}
+		// CreateDeal holds details about calls to the CreateDeal method.
+		CreateDeal []struct {
+			// Request is the request argument value.
+			Request DealCreateRequest
+		}
 		// CreateDealFlowCard holds details about calls to the CreateDealFlowCard method.
 		CreateDealFlowCard []struct {
 			// CardName is the cardName argument value.
@@ -74,6 +231,109 @@ type IHubspotDealFlowAPIMock struct {
 			// OtherProperties is the otherProperties argument value.
 			OtherProperties map[string]string
 		}
+		// CreateDealFlowCardForOwner holds details about calls to the CreateDealFlowCardForOwner method.
+		CreateDealFlowCardForOwner []struct {
+			// CardName is the cardName argument value.
+			CardName string
+			// ContactID is the contactID argument value.
+			ContactID string
+			// ContactAssocType is the contactAssocType argument value.
+			ContactAssocType string
+			// CompanyID is the companyID argument value.
+			CompanyID string
+			// StageName is the stageName argument value.
+			StageName string
+			// Pipeline is the pipeline argument value.
+			Pipeline string
+			// OwnerEmail is the ownerEmail argument value.
+			OwnerEmail string
+			// OtherProperties is the otherProperties argument value.
+			OtherProperties map[string]string
+		}
+		// CreateDealTask holds details about calls to the CreateDealTask method.
+		CreateDealTask []struct {
+			// DealId is the dealId argument value.
+			DealId string
+			// Subject is the subject argument value.
+			Subject string
+			// Body is the body argument value.
+			Body string
+			// OwnerId is the ownerId argument value.
+			OwnerId string
+			// DueDate is the dueDate argument value.
+			DueDate time.Time
+		}
+		// FindStaleDeals holds details about calls to the FindStaleDeals method.
+		FindStaleDeals []struct {
+			// Options is the options argument value.
+			Options StaleDealOptions
+		}
+		// GetDealLineItems holds details about calls to the GetDealLineItems method.
+		GetDealLineItems []struct {
+			// DealId is the dealId argument value.
+			DealId string
+		}
+		// GetDealPipelines holds details about calls to the GetDealPipelines method.
+		GetDealPipelines []struct {
+		}
+		// GetDealStageHistory holds details about calls to the GetDealStageHistory method.
+		GetDealStageHistory []struct {
+			// DealId is the dealId argument value.
+			DealId string
+		}
+		// GetStageAnalytics holds details about calls to the GetStageAnalytics method.
+		GetStageAnalytics []struct {
+			// PipelineId is the pipelineId argument value.
+			PipelineId string
+			// From is the from argument value.
+			From time.Time
+			// To is the to argument value.
+			To time.Time
+		}
+		// LogDealNote holds details about calls to the LogDealNote method.
+		LogDealNote []struct {
+			// DealId is the dealId argument value.
+			DealId string
+			// Note is the note argument value.
+			Note string
+			// OwnerId is the ownerId argument value.
+			OwnerId string
+		}
+		// MoveDealFlowCardWithNote holds details about calls to the MoveDealFlowCardWithNote method.
+		MoveDealFlowCardWithNote []struct {
+			// DealId is the dealId argument value.
+			DealId string
+			// StageId is the stageId argument value.
+			StageId string
+			// Note is the note argument value.
+			Note string
+			// OwnerId is the ownerId argument value.
+			OwnerId string
+		}
+		// RecomputeDealAmount holds details about calls to the RecomputeDealAmount method.
+		RecomputeDealAmount []struct {
+			// DealId is the dealId argument value.
+			DealId string
+		}
+		// RemoveLineItemFromDeal holds details about calls to the RemoveLineItemFromDeal method.
+		RemoveLineItemFromDeal []struct {
+			// DealId is the dealId argument value.
+			DealId string
+			// LineItemId is the lineItemId argument value.
+			LineItemId string
+		}
+		// SearchDeals holds details about calls to the SearchDeals method.
+		SearchDeals []struct {
+			// Query is the query argument value.
+			Query SearchQuery
+		}
+		// SnapshotPipeline holds details about calls to the SnapshotPipeline method.
+		SnapshotPipeline []struct {
+			// PipelineId is the pipelineId argument value.
+			PipelineId string
+			// Properties is the properties argument value.
+			Properties []string
+		}
 		// UpdateDealFlowCard holds details about calls to the UpdateDealFlowCard method.
 		UpdateDealFlowCard []struct {
 			// DealId is the dealId argument value.
@@ -82,9 +342,137 @@ type IHubspotDealFlowAPIMock struct {
 			Properties map[string]string
 		}
 	}
-	lockAssociateDealFlowCard sync.RWMutex
-	lockCreateDealFlowCard    sync.RWMutex
-	lockUpdateDealFlowCard    sync.RWMutex
+	lockAddLineItemToDeal          sync.RWMutex
+	lockAddProductToDeal           sync.RWMutex
+	lockAssignDealOwner            sync.RWMutex
+	lockAssociateDealFlowCard      sync.RWMutex
+	lockBulkMoveDeals              sync.RWMutex
+	lockBulkMoveDealsMatching      sync.RWMutex
+	lockCreateDeal                 sync.RWMutex
+	lockCreateDealFlowCard         sync.RWMutex
+	lockCreateDealFlowCardForOwner sync.RWMutex
+	lockCreateDealTask             sync.RWMutex
+	lockFindStaleDeals             sync.RWMutex
+	lockGetDealLineItems           sync.RWMutex
+	lockGetDealPipelines           sync.RWMutex
+	lockGetDealStageHistory        sync.RWMutex
+	lockGetStageAnalytics          sync.RWMutex
+	lockLogDealNote                sync.RWMutex
+	lockMoveDealFlowCardWithNote   sync.RWMutex
+	lockRecomputeDealAmount        sync.RWMutex
+	lockRemoveLineItemFromDeal     sync.RWMutex
+	lockSearchDeals                sync.RWMutex
+	lockSnapshotPipeline           sync.RWMutex
+	lockUpdateDealFlowCard         sync.RWMutex
+}
+
+// AddLineItemToDeal calls AddLineItemToDealFunc.
+func (mock *IHubspotDealFlowAPIMock) AddLineItemToDeal(dealId string, properties map[string]string) (*LineItem, error) {
+	if mock.AddLineItemToDealFunc == nil {
+		panic("IHubspotDealFlowAPIMock.AddLineItemToDealFunc: method is nil but IHubspotDealFlowAPI.AddLineItemToDeal was just called")
+	}
+	callInfo := struct {
+		DealId     string
+		Properties map[string]string
+	}{
+		DealId:     dealId,
+		Properties: properties,
+	}
+	mock.lockAddLineItemToDeal.Lock()
+	mock.calls.AddLineItemToDeal = append(mock.calls.AddLineItemToDeal, callInfo)
+	mock.lockAddLineItemToDeal.Unlock()
+	return mock.AddLineItemToDealFunc(dealId, properties)
+}
+
+// AddLineItemToDealCalls gets all the calls that were made to AddLineItemToDeal.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.AddLineItemToDealCalls())
+func (mock *IHubspotDealFlowAPIMock) AddLineItemToDealCalls() []struct {
+	DealId     string
+	Properties map[string]string
+} {
+	var calls []struct {
+		DealId     string
+		Properties map[string]string
+	}
+	mock.lockAddLineItemToDeal.RLock()
+	calls = mock.calls.AddLineItemToDeal
+	mock.lockAddLineItemToDeal.RUnlock()
+	return calls
+}
+
+// AddProductToDeal calls AddProductToDealFunc.
+func (mock *IHubspotDealFlowAPIMock) AddProductToDeal(dealId string, productId string, quantity int) (*LineItem, error) {
+	if mock.AddProductToDealFunc == nil {
+		panic("IHubspotDealFlowAPIMock.AddProductToDealFunc: method is nil but IHubspotDealFlowAPI.AddProductToDeal was just called")
+	}
+	callInfo := struct {
+		DealId    string
+		ProductId string
+		Quantity  int
+	}{
+		DealId:    dealId,
+		ProductId: productId,
+		Quantity:  quantity,
+	}
+	mock.lockAddProductToDeal.Lock()
+	mock.calls.AddProductToDeal = append(mock.calls.AddProductToDeal, callInfo)
+	mock.lockAddProductToDeal.Unlock()
+	return mock.AddProductToDealFunc(dealId, productId, quantity)
+}
+
+// AddProductToDealCalls gets all the calls that were made to AddProductToDeal.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.AddProductToDealCalls())
+func (mock *IHubspotDealFlowAPIMock) AddProductToDealCalls() []struct {
+	DealId    string
+	ProductId string
+	Quantity  int
+} {
+	var calls []struct {
+		DealId    string
+		ProductId string
+		Quantity  int
+	}
+	mock.lockAddProductToDeal.RLock()
+	calls = mock.calls.AddProductToDeal
+	mock.lockAddProductToDeal.RUnlock()
+	return calls
+}
+
+// AssignDealOwner calls AssignDealOwnerFunc.
+func (mock *IHubspotDealFlowAPIMock) AssignDealOwner(dealId string, ownerEmail string) error {
+	if mock.AssignDealOwnerFunc == nil {
+		panic("IHubspotDealFlowAPIMock.AssignDealOwnerFunc: method is nil but IHubspotDealFlowAPI.AssignDealOwner was just called")
+	}
+	callInfo := struct {
+		DealId     string
+		OwnerEmail string
+	}{
+		DealId:     dealId,
+		OwnerEmail: ownerEmail,
+	}
+	mock.lockAssignDealOwner.Lock()
+	mock.calls.AssignDealOwner = append(mock.calls.AssignDealOwner, callInfo)
+	mock.lockAssignDealOwner.Unlock()
+	return mock.AssignDealOwnerFunc(dealId, ownerEmail)
+}
+
+// AssignDealOwnerCalls gets all the calls that were made to AssignDealOwner.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.AssignDealOwnerCalls())
+func (mock *IHubspotDealFlowAPIMock) AssignDealOwnerCalls() []struct {
+	DealId     string
+	OwnerEmail string
+} {
+	var calls []struct {
+		DealId     string
+		OwnerEmail string
+	}
+	mock.lockAssignDealOwner.RLock()
+	calls = mock.calls.AssignDealOwner
+	mock.lockAssignDealOwner.RUnlock()
+	return calls
 }
 
 // AssociateDealFlowCard calls AssociateDealFlowCardFunc.
@@ -130,6 +518,107 @@ func (mock *IHubspotDealFlowAPIMock) AssociateDealFlowCardCalls() []struct {
 	return calls
 }
 
+// BulkMoveDeals calls BulkMoveDealsFunc.
+func (mock *IHubspotDealFlowAPIMock) BulkMoveDeals(dealIds []string, stageId string) ([]DealMoveOutcome, error) {
+	if mock.BulkMoveDealsFunc == nil {
+		panic("IHubspotDealFlowAPIMock.BulkMoveDealsFunc: method is nil but IHubspotDealFlowAPI.BulkMoveDeals was just called")
+	}
+	callInfo := struct {
+		DealIds []string
+		StageId string
+	}{
+		DealIds: dealIds,
+		StageId: stageId,
+	}
+	mock.lockBulkMoveDeals.Lock()
+	mock.calls.BulkMoveDeals = append(mock.calls.BulkMoveDeals, callInfo)
+	mock.lockBulkMoveDeals.Unlock()
+	return mock.BulkMoveDealsFunc(dealIds, stageId)
+}
+
+// BulkMoveDealsCalls gets all the calls that were made to BulkMoveDeals.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.BulkMoveDealsCalls())
+func (mock *IHubspotDealFlowAPIMock) BulkMoveDealsCalls() []struct {
+	DealIds []string
+	StageId string
+} {
+	var calls []struct {
+		DealIds []string
+		StageId string
+	}
+	mock.lockBulkMoveDeals.RLock()
+	calls = mock.calls.BulkMoveDeals
+	mock.lockBulkMoveDeals.RUnlock()
+	return calls
+}
+
+// BulkMoveDealsMatching calls BulkMoveDealsMatchingFunc.
+func (mock *IHubspotDealFlowAPIMock) BulkMoveDealsMatching(query SearchQuery, stageId string) ([]DealMoveOutcome, error) {
+	if mock.BulkMoveDealsMatchingFunc == nil {
+		panic("IHubspotDealFlowAPIMock.BulkMoveDealsMatchingFunc: method is nil but IHubspotDealFlowAPI.BulkMoveDealsMatching was just called")
+	}
+	callInfo := struct {
+		Query   SearchQuery
+		StageId string
+	}{
+		Query:   query,
+		StageId: stageId,
+	}
+	mock.lockBulkMoveDealsMatching.Lock()
+	mock.calls.BulkMoveDealsMatching = append(mock.calls.BulkMoveDealsMatching, callInfo)
+	mock.lockBulkMoveDealsMatching.Unlock()
+	return mock.BulkMoveDealsMatchingFunc(query, stageId)
+}
+
+// BulkMoveDealsMatchingCalls gets all the calls that were made to BulkMoveDealsMatching.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.BulkMoveDealsMatchingCalls())
+func (mock *IHubspotDealFlowAPIMock) BulkMoveDealsMatchingCalls() []struct {
+	Query   SearchQuery
+	StageId string
+} {
+	var calls []struct {
+		Query   SearchQuery
+		StageId string
+	}
+	mock.lockBulkMoveDealsMatching.RLock()
+	calls = mock.calls.BulkMoveDealsMatching
+	mock.lockBulkMoveDealsMatching.RUnlock()
+	return calls
+}
+
+// CreateDeal calls CreateDealFunc.
+func (mock *IHubspotDealFlowAPIMock) CreateDeal(request DealCreateRequest) (*Deal, error) {
+	if mock.CreateDealFunc == nil {
+		panic("IHubspotDealFlowAPIMock.CreateDealFunc: method is nil but IHubspotDealFlowAPI.CreateDeal was just called")
+	}
+	callInfo := struct {
+		Request DealCreateRequest
+	}{
+		Request: request,
+	}
+	mock.lockCreateDeal.Lock()
+	mock.calls.CreateDeal = append(mock.calls.CreateDeal, callInfo)
+	mock.lockCreateDeal.Unlock()
+	return mock.CreateDealFunc(request)
+}
+
+// CreateDealCalls gets all the calls that were made to CreateDeal.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.CreateDealCalls())
+func (mock *IHubspotDealFlowAPIMock) CreateDealCalls() []struct {
+	Request DealCreateRequest
+} {
+	var calls []struct {
+		Request DealCreateRequest
+	}
+	mock.lockCreateDeal.RLock()
+	calls = mock.calls.CreateDeal
+	mock.lockCreateDeal.RUnlock()
+	return calls
+}
+
 // CreateDealFlowCard calls CreateDealFlowCardFunc.
 func (mock *IHubspotDealFlowAPIMock) CreateDealFlowCard(cardName string, contactID string, contactAssocType string, companyID string, stageName string, pipeline string, ownerId string, otherProperties map[string]string) (*DealCreationResponse, error) {
 	if mock.CreateDealFlowCardFunc == nil {
@@ -189,6 +678,484 @@ func (mock *IHubspotDealFlowAPIMock) CreateDealFlowCardCalls() []struct {
 	return calls
 }
 
+// CreateDealFlowCardForOwner calls CreateDealFlowCardForOwnerFunc.
+func (mock *IHubspotDealFlowAPIMock) CreateDealFlowCardForOwner(cardName string, contactID string, contactAssocType string, companyID string, stageName string, pipeline string, ownerEmail string, otherProperties map[string]string) (*DealCreationResponse, error) {
+	if mock.CreateDealFlowCardForOwnerFunc == nil {
+		panic("IHubspotDealFlowAPIMock.CreateDealFlowCardForOwnerFunc: method is nil but IHubspotDealFlowAPI.CreateDealFlowCardForOwner was just called")
+	}
+	callInfo := struct {
+		CardName         string
+		ContactID        string
+		ContactAssocType string
+		CompanyID        string
+		StageName        string
+		Pipeline         string
+		OwnerEmail       string
+		OtherProperties  map[string]string
+	}{
+		CardName:         cardName,
+		ContactID:        contactID,
+		ContactAssocType: contactAssocType,
+		CompanyID:        companyID,
+		StageName:        stageName,
+		Pipeline:         pipeline,
+		OwnerEmail:       ownerEmail,
+		OtherProperties:  otherProperties,
+	}
+	mock.lockCreateDealFlowCardForOwner.Lock()
+	mock.calls.CreateDealFlowCardForOwner = append(mock.calls.CreateDealFlowCardForOwner, callInfo)
+	mock.lockCreateDealFlowCardForOwner.Unlock()
+	return mock.CreateDealFlowCardForOwnerFunc(cardName, contactID, contactAssocType, companyID, stageName, pipeline, ownerEmail, otherProperties)
+}
+
+// CreateDealFlowCardForOwnerCalls gets all the calls that were made to CreateDealFlowCardForOwner.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.CreateDealFlowCardForOwnerCalls())
+func (mock *IHubspotDealFlowAPIMock) CreateDealFlowCardForOwnerCalls() []struct {
+	CardName         string
+	ContactID        string
+	ContactAssocType string
+	CompanyID        string
+	StageName        string
+	Pipeline         string
+	OwnerEmail       string
+	OtherProperties  map[string]string
+} {
+	var calls []struct {
+		CardName         string
+		ContactID        string
+		ContactAssocType string
+		CompanyID        string
+		StageName        string
+		Pipeline         string
+		OwnerEmail       string
+		OtherProperties  map[string]string
+	}
+	mock.lockCreateDealFlowCardForOwner.RLock()
+	calls = mock.calls.CreateDealFlowCardForOwner
+	mock.lockCreateDealFlowCardForOwner.RUnlock()
+	return calls
+}
+
+// CreateDealTask calls CreateDealTaskFunc.
+func (mock *IHubspotDealFlowAPIMock) CreateDealTask(dealId string, subject string, body string, ownerId string, dueDate time.Time) (*Engagement, error) {
+	if mock.CreateDealTaskFunc == nil {
+		panic("IHubspotDealFlowAPIMock.CreateDealTaskFunc: method is nil but IHubspotDealFlowAPI.CreateDealTask was just called")
+	}
+	callInfo := struct {
+		DealId  string
+		Subject string
+		Body    string
+		OwnerId string
+		DueDate time.Time
+	}{
+		DealId:  dealId,
+		Subject: subject,
+		Body:    body,
+		OwnerId: ownerId,
+		DueDate: dueDate,
+	}
+	mock.lockCreateDealTask.Lock()
+	mock.calls.CreateDealTask = append(mock.calls.CreateDealTask, callInfo)
+	mock.lockCreateDealTask.Unlock()
+	return mock.CreateDealTaskFunc(dealId, subject, body, ownerId, dueDate)
+}
+
+// CreateDealTaskCalls gets all the calls that were made to CreateDealTask.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.CreateDealTaskCalls())
+func (mock *IHubspotDealFlowAPIMock) CreateDealTaskCalls() []struct {
+	DealId  string
+	Subject string
+	Body    string
+	OwnerId string
+	DueDate time.Time
+} {
+	var calls []struct {
+		DealId  string
+		Subject string
+		Body    string
+		OwnerId string
+		DueDate time.Time
+	}
+	mock.lockCreateDealTask.RLock()
+	calls = mock.calls.CreateDealTask
+	mock.lockCreateDealTask.RUnlock()
+	return calls
+}
+
+// FindStaleDeals calls FindStaleDealsFunc.
+func (mock *IHubspotDealFlowAPIMock) FindStaleDeals(options StaleDealOptions) (*StaleDealReport, error) {
+	if mock.FindStaleDealsFunc == nil {
+		panic("IHubspotDealFlowAPIMock.FindStaleDealsFunc: method is nil but IHubspotDealFlowAPI.FindStaleDeals was just called")
+	}
+	callInfo := struct {
+		Options StaleDealOptions
+	}{
+		Options: options,
+	}
+	mock.lockFindStaleDeals.Lock()
+	mock.calls.FindStaleDeals = append(mock.calls.FindStaleDeals, callInfo)
+	mock.lockFindStaleDeals.Unlock()
+	return mock.FindStaleDealsFunc(options)
+}
+
+// FindStaleDealsCalls gets all the calls that were made to FindStaleDeals.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.FindStaleDealsCalls())
+func (mock *IHubspotDealFlowAPIMock) FindStaleDealsCalls() []struct {
+	Options StaleDealOptions
+} {
+	var calls []struct {
+		Options StaleDealOptions
+	}
+	mock.lockFindStaleDeals.RLock()
+	calls = mock.calls.FindStaleDeals
+	mock.lockFindStaleDeals.RUnlock()
+	return calls
+}
+
+// GetDealLineItems calls GetDealLineItemsFunc.
+func (mock *IHubspotDealFlowAPIMock) GetDealLineItems(dealId string) ([]LineItem, error) {
+	if mock.GetDealLineItemsFunc == nil {
+		panic("IHubspotDealFlowAPIMock.GetDealLineItemsFunc: method is nil but IHubspotDealFlowAPI.GetDealLineItems was just called")
+	}
+	callInfo := struct {
+		DealId string
+	}{
+		DealId: dealId,
+	}
+	mock.lockGetDealLineItems.Lock()
+	mock.calls.GetDealLineItems = append(mock.calls.GetDealLineItems, callInfo)
+	mock.lockGetDealLineItems.Unlock()
+	return mock.GetDealLineItemsFunc(dealId)
+}
+
+// GetDealLineItemsCalls gets all the calls that were made to GetDealLineItems.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.GetDealLineItemsCalls())
+func (mock *IHubspotDealFlowAPIMock) GetDealLineItemsCalls() []struct {
+	DealId string
+} {
+	var calls []struct {
+		DealId string
+	}
+	mock.lockGetDealLineItems.RLock()
+	calls = mock.calls.GetDealLineItems
+	mock.lockGetDealLineItems.RUnlock()
+	return calls
+}
+
+// GetDealPipelines calls GetDealPipelinesFunc.
+func (mock *IHubspotDealFlowAPIMock) GetDealPipelines() ([]Pipeline, error) {
+	if mock.GetDealPipelinesFunc == nil {
+		panic("IHubspotDealFlowAPIMock.GetDealPipelinesFunc: method is nil but IHubspotDealFlowAPI.GetDealPipelines was just called")
+	}
+	callInfo := struct {
+	}{}
+	mock.lockGetDealPipelines.Lock()
+	mock.calls.GetDealPipelines = append(mock.calls.GetDealPipelines, callInfo)
+	mock.lockGetDealPipelines.Unlock()
+	return mock.GetDealPipelinesFunc()
+}
+
+// GetDealPipelinesCalls gets all the calls that were made to GetDealPipelines.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.GetDealPipelinesCalls())
+func (mock *IHubspotDealFlowAPIMock) GetDealPipelinesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetDealPipelines.RLock()
+	calls = mock.calls.GetDealPipelines
+	mock.lockGetDealPipelines.RUnlock()
+	return calls
+}
+
+// GetDealStageHistory calls GetDealStageHistoryFunc.
+func (mock *IHubspotDealFlowAPIMock) GetDealStageHistory(dealId string) ([]StageTransition, error) {
+	if mock.GetDealStageHistoryFunc == nil {
+		panic("IHubspotDealFlowAPIMock.GetDealStageHistoryFunc: method is nil but IHubspotDealFlowAPI.GetDealStageHistory was just called")
+	}
+	callInfo := struct {
+		DealId string
+	}{
+		DealId: dealId,
+	}
+	mock.lockGetDealStageHistory.Lock()
+	mock.calls.GetDealStageHistory = append(mock.calls.GetDealStageHistory, callInfo)
+	mock.lockGetDealStageHistory.Unlock()
+	return mock.GetDealStageHistoryFunc(dealId)
+}
+
+// GetDealStageHistoryCalls gets all the calls that were made to GetDealStageHistory.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.GetDealStageHistoryCalls())
+func (mock *IHubspotDealFlowAPIMock) GetDealStageHistoryCalls() []struct {
+	DealId string
+} {
+	var calls []struct {
+		DealId string
+	}
+	mock.lockGetDealStageHistory.RLock()
+	calls = mock.calls.GetDealStageHistory
+	mock.lockGetDealStageHistory.RUnlock()
+	return calls
+}
+
+// GetStageAnalytics calls GetStageAnalyticsFunc.
+func (mock *IHubspotDealFlowAPIMock) GetStageAnalytics(pipelineId string, from time.Time, to time.Time) (*PipelineStageAnalytics, error) {
+	if mock.GetStageAnalyticsFunc == nil {
+		panic("IHubspotDealFlowAPIMock.GetStageAnalyticsFunc: method is nil but IHubspotDealFlowAPI.GetStageAnalytics was just called")
+	}
+	callInfo := struct {
+		PipelineId string
+		From       time.Time
+		To         time.Time
+	}{
+		PipelineId: pipelineId,
+		From:       from,
+		To:         to,
+	}
+	mock.lockGetStageAnalytics.Lock()
+	mock.calls.GetStageAnalytics = append(mock.calls.GetStageAnalytics, callInfo)
+	mock.lockGetStageAnalytics.Unlock()
+	return mock.GetStageAnalyticsFunc(pipelineId, from, to)
+}
+
+// GetStageAnalyticsCalls gets all the calls that were made to GetStageAnalytics.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.GetStageAnalyticsCalls())
+func (mock *IHubspotDealFlowAPIMock) GetStageAnalyticsCalls() []struct {
+	PipelineId string
+	From       time.Time
+	To         time.Time
+} {
+	var calls []struct {
+		PipelineId string
+		From       time.Time
+		To         time.Time
+	}
+	mock.lockGetStageAnalytics.RLock()
+	calls = mock.calls.GetStageAnalytics
+	mock.lockGetStageAnalytics.RUnlock()
+	return calls
+}
+
+// LogDealNote calls LogDealNoteFunc.
+func (mock *IHubspotDealFlowAPIMock) LogDealNote(dealId string, note string, ownerId string) (*Engagement, error) {
+	if mock.LogDealNoteFunc == nil {
+		panic("IHubspotDealFlowAPIMock.LogDealNoteFunc: method is nil but IHubspotDealFlowAPI.LogDealNote was just called")
+	}
+	callInfo := struct {
+		DealId  string
+		Note    string
+		OwnerId string
+	}{
+		DealId:  dealId,
+		Note:    note,
+		OwnerId: ownerId,
+	}
+	mock.lockLogDealNote.Lock()
+	mock.calls.LogDealNote = append(mock.calls.LogDealNote, callInfo)
+	mock.lockLogDealNote.Unlock()
+	return mock.LogDealNoteFunc(dealId, note, ownerId)
+}
+
+// LogDealNoteCalls gets all the calls that were made to LogDealNote.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.LogDealNoteCalls())
+func (mock *IHubspotDealFlowAPIMock) LogDealNoteCalls() []struct {
+	DealId  string
+	Note    string
+	OwnerId string
+} {
+	var calls []struct {
+		DealId  string
+		Note    string
+		OwnerId string
+	}
+	mock.lockLogDealNote.RLock()
+	calls = mock.calls.LogDealNote
+	mock.lockLogDealNote.RUnlock()
+	return calls
+}
+
+// MoveDealFlowCardWithNote calls MoveDealFlowCardWithNoteFunc.
+func (mock *IHubspotDealFlowAPIMock) MoveDealFlowCardWithNote(dealId string, stageId string, note string, ownerId string) error {
+	if mock.MoveDealFlowCardWithNoteFunc == nil {
+		panic("IHubspotDealFlowAPIMock.MoveDealFlowCardWithNoteFunc: method is nil but IHubspotDealFlowAPI.MoveDealFlowCardWithNote was just called")
+	}
+	callInfo := struct {
+		DealId  string
+		StageId string
+		Note    string
+		OwnerId string
+	}{
+		DealId:  dealId,
+		StageId: stageId,
+		Note:    note,
+		OwnerId: ownerId,
+	}
+	mock.lockMoveDealFlowCardWithNote.Lock()
+	mock.calls.MoveDealFlowCardWithNote = append(mock.calls.MoveDealFlowCardWithNote, callInfo)
+	mock.lockMoveDealFlowCardWithNote.Unlock()
+	return mock.MoveDealFlowCardWithNoteFunc(dealId, stageId, note, ownerId)
+}
+
+// MoveDealFlowCardWithNoteCalls gets all the calls that were made to MoveDealFlowCardWithNote.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.MoveDealFlowCardWithNoteCalls())
+func (mock *IHubspotDealFlowAPIMock) MoveDealFlowCardWithNoteCalls() []struct {
+	DealId  string
+	StageId string
+	Note    string
+	OwnerId string
+} {
+	var calls []struct {
+		DealId  string
+		StageId string
+		Note    string
+		OwnerId string
+	}
+	mock.lockMoveDealFlowCardWithNote.RLock()
+	calls = mock.calls.MoveDealFlowCardWithNote
+	mock.lockMoveDealFlowCardWithNote.RUnlock()
+	return calls
+}
+
+// RecomputeDealAmount calls RecomputeDealAmountFunc.
+func (mock *IHubspotDealFlowAPIMock) RecomputeDealAmount(dealId string) (string, error) {
+	if mock.RecomputeDealAmountFunc == nil {
+		panic("IHubspotDealFlowAPIMock.RecomputeDealAmountFunc: method is nil but IHubspotDealFlowAPI.RecomputeDealAmount was just called")
+	}
+	callInfo := struct {
+		DealId string
+	}{
+		DealId: dealId,
+	}
+	mock.lockRecomputeDealAmount.Lock()
+	mock.calls.RecomputeDealAmount = append(mock.calls.RecomputeDealAmount, callInfo)
+	mock.lockRecomputeDealAmount.Unlock()
+	return mock.RecomputeDealAmountFunc(dealId)
+}
+
+// RecomputeDealAmountCalls gets all the calls that were made to RecomputeDealAmount.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.RecomputeDealAmountCalls())
+func (mock *IHubspotDealFlowAPIMock) RecomputeDealAmountCalls() []struct {
+	DealId string
+} {
+	var calls []struct {
+		DealId string
+	}
+	mock.lockRecomputeDealAmount.RLock()
+	calls = mock.calls.RecomputeDealAmount
+	mock.lockRecomputeDealAmount.RUnlock()
+	return calls
+}
+
+// RemoveLineItemFromDeal calls RemoveLineItemFromDealFunc.
+func (mock *IHubspotDealFlowAPIMock) RemoveLineItemFromDeal(dealId string, lineItemId string) error {
+	if mock.RemoveLineItemFromDealFunc == nil {
+		panic("IHubspotDealFlowAPIMock.RemoveLineItemFromDealFunc: method is nil but IHubspotDealFlowAPI.RemoveLineItemFromDeal was just called")
+	}
+	callInfo := struct {
+		DealId     string
+		LineItemId string
+	}{
+		DealId:     dealId,
+		LineItemId: lineItemId,
+	}
+	mock.lockRemoveLineItemFromDeal.Lock()
+	mock.calls.RemoveLineItemFromDeal = append(mock.calls.RemoveLineItemFromDeal, callInfo)
+	mock.lockRemoveLineItemFromDeal.Unlock()
+	return mock.RemoveLineItemFromDealFunc(dealId, lineItemId)
+}
+
+// RemoveLineItemFromDealCalls gets all the calls that were made to RemoveLineItemFromDeal.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.RemoveLineItemFromDealCalls())
+func (mock *IHubspotDealFlowAPIMock) RemoveLineItemFromDealCalls() []struct {
+	DealId     string
+	LineItemId string
+} {
+	var calls []struct {
+		DealId     string
+		LineItemId string
+	}
+	mock.lockRemoveLineItemFromDeal.RLock()
+	calls = mock.calls.RemoveLineItemFromDeal
+	mock.lockRemoveLineItemFromDeal.RUnlock()
+	return calls
+}
+
+// SearchDeals calls SearchDealsFunc.
+func (mock *IHubspotDealFlowAPIMock) SearchDeals(query SearchQuery) *SearchIterator {
+	if mock.SearchDealsFunc == nil {
+		panic("IHubspotDealFlowAPIMock.SearchDealsFunc: method is nil but IHubspotDealFlowAPI.SearchDeals was just called")
+	}
+	callInfo := struct {
+		Query SearchQuery
+	}{
+		Query: query,
+	}
+	mock.lockSearchDeals.Lock()
+	mock.calls.SearchDeals = append(mock.calls.SearchDeals, callInfo)
+	mock.lockSearchDeals.Unlock()
+	return mock.SearchDealsFunc(query)
+}
+
+// SearchDealsCalls gets all the calls that were made to SearchDeals.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.SearchDealsCalls())
+func (mock *IHubspotDealFlowAPIMock) SearchDealsCalls() []struct {
+	Query SearchQuery
+} {
+	var calls []struct {
+		Query SearchQuery
+	}
+	mock.lockSearchDeals.RLock()
+	calls = mock.calls.SearchDeals
+	mock.lockSearchDeals.RUnlock()
+	return calls
+}
+
+// SnapshotPipeline calls SnapshotPipelineFunc.
+func (mock *IHubspotDealFlowAPIMock) SnapshotPipeline(pipelineId string, properties []string) (*PipelineSnapshot, error) {
+	if mock.SnapshotPipelineFunc == nil {
+		panic("IHubspotDealFlowAPIMock.SnapshotPipelineFunc: method is nil but IHubspotDealFlowAPI.SnapshotPipeline was just called")
+	}
+	callInfo := struct {
+		PipelineId string
+		Properties []string
+	}{
+		PipelineId: pipelineId,
+		Properties: properties,
+	}
+	mock.lockSnapshotPipeline.Lock()
+	mock.calls.SnapshotPipeline = append(mock.calls.SnapshotPipeline, callInfo)
+	mock.lockSnapshotPipeline.Unlock()
+	return mock.SnapshotPipelineFunc(pipelineId, properties)
+}
+
+// SnapshotPipelineCalls gets all the calls that were made to SnapshotPipeline.
+// Check the length with:
+//     len(mockedIHubspotDealFlowAPI.SnapshotPipelineCalls())
+func (mock *IHubspotDealFlowAPIMock) SnapshotPipelineCalls() []struct {
+	PipelineId string
+	Properties []string
+} {
+	var calls []struct {
+		PipelineId string
+		Properties []string
+	}
+	mock.lockSnapshotPipeline.RLock()
+	calls = mock.calls.SnapshotPipeline
+	mock.lockSnapshotPipeline.RUnlock()
+	return calls
+}
+
 // UpdateDealFlowCard calls UpdateDealFlowCardFunc.
 func (mock *IHubspotDealFlowAPIMock) UpdateDealFlowCard(dealId string, properties map[string]string) error {
 	if mock.UpdateDealFlowCardFunc == nil {
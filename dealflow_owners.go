@@ -0,0 +1,34 @@
+package go_hubspot
+
+func (api HubspotDealFlowAPI) ownerAPI() HubspotOwnerAPI {
+	return HubspotOwnerAPI{APIKey: api.APIKey, httpClient: api.httpClient, cache: api.owners}
+}
+
+// CreateDealFlowCardForOwner creates a deal flow card owned by the owner with the given email, see CreateDealFlowCard
+func (api HubspotDealFlowAPI) CreateDealFlowCardForOwner(
+	cardName string,
+	contactID string,
+	contactAssocType string,
+	companyID string,
+	stageName string,
+	pipeline string,
+	ownerEmail string,
+	otherProperties map[string]string,
+) (*DealCreationResponse, error) {
+	owner, err := api.ownerAPI().GetOwnerByEmail(ownerEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.CreateDealFlowCard(cardName, contactID, contactAssocType, companyID, stageName, pipeline, owner.Id, otherProperties)
+}
+
+// AssignDealOwner makes the owner with the given email the owner of the deal
+func (api HubspotDealFlowAPI) AssignDealOwner(dealId, ownerEmail string) error {
+	owner, err := api.ownerAPI().GetOwnerByEmail(ownerEmail)
+	if err != nil {
+		return err
+	}
+
+	return api.objects().update(dealId, map[string]string{"hubspot_owner_id": owner.Id}, nil)
+}
@@ -0,0 +1,47 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAssignDealOwner(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/owners/?email=alice%40example.com&hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, ownersResponse{Results: []Owner{{Id: "ownerId", Email: "alice@example.com"}}})
+			case "PATCH https://api.hubapi.com/crm/v3/objects/deals/dealId?hapikey=api_key":
+				var request dealUpdateRequest
+				readJSONRequest(t, req, &request)
+
+				if request.Properties["hubspot_owner_id"] != "ownerId" {
+					t.Errorf("Unexpected deal update: %v", request.Properties)
+				}
+
+				w.WriteHeader(200)
+			default:
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	err := api.AssignDealOwner("dealId", "alice@example.com")
+	if err != nil {
+		t.Errorf("AssignDealOwner returned an error: %s", err.Error())
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 2 {
+		t.Errorf("Expected 2 calls to HubSpot API")
+	}
+}
@@ -0,0 +1,284 @@
+package go_hubspot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SnapshotFormat is a format a pipeline snapshot can be exported in
+type SnapshotFormat int64
+
+const (
+	SnapshotJSON SnapshotFormat = iota
+	SnapshotCSV
+	SnapshotMarkdown
+)
+
+// SnapshotDeal is a deal as it was when the snapshot was taken, with the names of its associated companies and contacts
+type SnapshotDeal struct {
+	Id         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+	Companies  []string          `json:"companies"`
+	Contacts   []string          `json:"contacts"`
+}
+
+// SnapshotStage is a column of the deal flow board with the deals it contained
+type SnapshotStage struct {
+	Id    string         `json:"id"`
+	Label string         `json:"label"`
+	Deals []SnapshotDeal `json:"deals"`
+}
+
+// PipelineSnapshot is what a deal flow board looked like at a point in time, stages are in display order
+type PipelineSnapshot struct {
+	PipelineId    string          `json:"pipelineId"`
+	PipelineLabel string          `json:"pipelineLabel"`
+	TakenAt       time.Time       `json:"takenAt"`
+	Properties    []string        `json:"properties"`
+	Stages        []SnapshotStage `json:"stages"`
+}
+
+// SnapshotPipeline fetches every deal in the pipeline with the given properties, grouped by stage
+func (api HubspotDealFlowAPI) SnapshotPipeline(pipelineId string, properties []string) (*PipelineSnapshot, error) {
+	log.Infof("Taking a snapshot of pipeline '%s'", pipelineId)
+
+	pipeline, err := getPipeline(api.httpClient, api.APIKey, "deals", pipelineId)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := make([]PipelineStage, len(pipeline.Stages))
+	copy(stages, pipeline.Stages)
+	sort.SliceStable(stages, func(i, j int) bool {
+		return stages[i].DisplayOrder < stages[j].DisplayOrder
+	})
+
+	snapshot := PipelineSnapshot{
+		PipelineId:    pipeline.Id,
+		PipelineLabel: pipeline.Label,
+		TakenAt:       time.Now().UTC(),
+		Properties:    properties,
+		Stages:        make([]SnapshotStage, len(stages)),
+	}
+
+	stageIndex := map[string]int{}
+	for i, stage := range stages {
+		stageIndex[stage.Id] = i
+		snapshot.Stages[i] = SnapshotStage{Id: stage.Id, Label: stage.Label, Deals: []SnapshotDeal{}}
+	}
+
+	deals, err := api.SearchDeals(SearchQuery{
+		Filters:    []SearchFilter{{PropertyName: "pipeline", Operator: "EQ", Value: pipelineId}},
+		Sorts:      []SearchSort{{PropertyName: "createdate", Direction: "ASCENDING"}},
+		Properties: append([]string{"dealstage"}, properties...),
+	}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	dealIds := make([]string, len(deals))
+	for i, deal := range deals {
+		dealIds[i] = deal.Id
+	}
+
+	companies, err := api.associatedNames(dealIds, "companies")
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := api.associatedNames(dealIds, "contacts")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deal := range deals {
+		stageId := deal.Properties["dealstage"]
+		index, ok := stageIndex[stageId]
+		if !ok {
+			// The deal is in a stage that has since been removed from the pipeline
+			index = len(snapshot.Stages)
+			stageIndex[stageId] = index
+			snapshot.Stages = append(snapshot.Stages, SnapshotStage{Id: stageId, Label: stageId, Deals: []SnapshotDeal{}})
+		}
+
+		dealProperties := map[string]string{}
+		for _, property := range properties {
+			dealProperties[property] = deal.Properties[property]
+		}
+
+		snapshot.Stages[index].Deals = append(snapshot.Stages[index].Deals, SnapshotDeal{
+			Id:         deal.Id,
+			Properties: dealProperties,
+			Companies:  companies[deal.Id],
+			Contacts:   contacts[deal.Id],
+		})
+	}
+
+	return &snapshot, nil
+}
+
+// associatedNames returns the names of the companies or contacts associated with each of the deals
+func (api HubspotDealFlowAPI) associatedNames(dealIds []string, objectType string) (map[string][]string, error) {
+	associations, err := batchReadAssociations(api.httpClient, api.APIKey, "deals", objectType, dealIds)
+	if err != nil {
+		return nil, err
+	}
+
+	objectIds := []string{}
+	seen := map[string]bool{}
+	for _, ids := range associations {
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				objectIds = append(objectIds, id)
+			}
+		}
+	}
+
+	records := crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: objectType}
+	raw, _, err := records.batchRead(objectIds, []string{"name", "firstname", "lastname", "email"})
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []HubSpotSearchResult{}
+	err = unmarshalAll(raw, &objects)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]string{}
+	for _, object := range objects {
+		names[object.Id] = displayName(object)
+	}
+
+	dealNames := map[string][]string{}
+	for dealId, ids := range associations {
+		for _, id := range ids {
+			if name, ok := names[id]; ok {
+				dealNames[dealId] = append(dealNames[dealId], name)
+			}
+		}
+	}
+
+	return dealNames, nil
+}
+
+// displayName returns a human readable name of a company or contact
+func displayName(object HubSpotSearchResult) string {
+	if name := object.Properties["name"]; name != "" {
+		return name
+	}
+
+	name := strings.TrimSpace(object.Properties["firstname"] + " " + object.Properties["lastname"])
+	if name != "" {
+		return name
+	}
+
+	if email := object.Properties["email"]; email != "" {
+		return email
+	}
+
+	return object.Id
+}
+
+// Export writes the snapshot to w in the given format
+func (s PipelineSnapshot) Export(w io.Writer, format SnapshotFormat) error {
+	switch format {
+	case SnapshotJSON:
+		return s.WriteJSON(w)
+	case SnapshotCSV:
+		return s.WriteCSV(w)
+	case SnapshotMarkdown:
+		return s.WriteMarkdown(w)
+	default:
+		return errors.New(fmt.Sprintf("Unknown snapshot format %d", format))
+	}
+}
+
+// WriteJSON writes the snapshot to w as an indented JSON document
+func (s PipelineSnapshot) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// WriteCSV writes the snapshot to w as CSV, with one row per deal
+func (s PipelineSnapshot) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	header := append([]string{"stage", "id"}, s.Properties...)
+	header = append(header, "companies", "contacts")
+	err := writer.Write(header)
+	if err != nil {
+		return err
+	}
+
+	for _, stage := range s.Stages {
+		for _, deal := range stage.Deals {
+			err = writer.Write(s.dealRow(stage, deal))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteMarkdown writes the snapshot to w as Markdown, with a table of deals for each stage
+func (s PipelineSnapshot) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\nSnapshot taken at %s\n", escapeMarkdown(s.PipelineLabel), s.TakenAt.Format(time.RFC3339))
+
+	header := append([]string{"id"}, s.Properties...)
+	header = append(header, "companies", "contacts")
+
+	for _, stage := range s.Stages {
+		fmt.Fprintf(&b, "\n## %s (%d)\n", escapeMarkdown(stage.Label), len(stage.Deals))
+		if len(stage.Deals) == 0 {
+			continue
+		}
+
+		b.WriteString("\n" + markdownRow(header))
+		b.WriteString(strings.Repeat("| --- ", len(header)) + "|\n")
+		for _, deal := range stage.Deals {
+			b.WriteString(markdownRow(s.dealRow(stage, deal)[1:]))
+		}
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// dealRow returns the stage, id, properties, companies and contacts of a deal as a row of values
+func (s PipelineSnapshot) dealRow(stage SnapshotStage, deal SnapshotDeal) []string {
+	row := []string{stage.Label, deal.Id}
+	for _, property := range s.Properties {
+		row = append(row, deal.Properties[property])
+	}
+	return append(row, strings.Join(deal.Companies, "; "), strings.Join(deal.Contacts, "; "))
+}
+
+func markdownRow(values []string) string {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = escapeMarkdown(value)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |\n"
+}
+
+func escapeMarkdown(value string) string {
+	value = strings.Replace(value, "|", "\\|", -1)
+	return strings.Replace(value, "\n", " ", -1)
+}
@@ -0,0 +1,140 @@
+package go_hubspot
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSnapshotPipeline(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/pipelines/deals/default?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, Pipeline{
+					Id:    "default",
+					Label: "Deal flow",
+					Stages: []PipelineStage{
+						{Id: "interview", Label: "Interview", DisplayOrder: 1},
+						{Id: "applied", Label: "Applied", DisplayOrder: 0},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/deals/search?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, searchPageResponse{
+					Total: 2,
+					Results: []HubSpotSearchResult{
+						{Id: "1", Properties: map[string]string{"dealstage": "interview", "dealname": "Acme"}},
+						{Id: "2", Properties: map[string]string{"dealstage": "applied", "dealname": "Pipe | Co"}},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/associations/deals/companies/batch/read?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, associationBatchReadResponse{
+					Results: []associationBatchResult{
+						{From: associationBatchFrom{"1"}, To: []Association{{"10", "deal_to_company"}}},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/associations/deals/contacts/batch/read?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, associationBatchReadResponse{
+					Results: []associationBatchResult{
+						{From: associationBatchFrom{"1"}, To: []Association{{"20", "deal_to_contact"}}},
+						{From: associationBatchFrom{"2"}, To: []Association{{"21", "deal_to_contact"}}},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/companies/batch/read?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, batchResponse{
+					Results: []HubSpotSearchResult{
+						{Id: "10", Properties: map[string]string{"name": "Acme Ltd"}},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/contacts/batch/read?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, batchResponse{
+					Results: []HubSpotSearchResult{
+						{Id: "20", Properties: map[string]string{"firstname": "Jane", "lastname": "Doe"}},
+						{Id: "21", Properties: map[string]string{"email": "john@example.com"}},
+					},
+				})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	snapshot, err := api.SnapshotPipeline("default", []string{"dealname"})
+	if err != nil {
+		t.Errorf("SnapshotPipeline returned an error: %s", err.Error())
+		return
+	}
+
+	expectedStages := []SnapshotStage{
+		{
+			Id:    "applied",
+			Label: "Applied",
+			Deals: []SnapshotDeal{
+				{Id: "2", Properties: map[string]string{"dealname": "Pipe | Co"}, Contacts: []string{"john@example.com"}},
+			},
+		},
+		{
+			Id:    "interview",
+			Label: "Interview",
+			Deals: []SnapshotDeal{
+				{Id: "1", Properties: map[string]string{"dealname": "Acme"}, Companies: []string{"Acme Ltd"}, Contacts: []string{"Jane Doe"}},
+			},
+		},
+	}
+
+	if !cmp.Equal(expectedStages, snapshot.Stages) {
+		t.Errorf("SnapshotPipeline returned incorrect stages, expected:\n%v\ngot:\n%v", expectedStages, snapshot.Stages)
+	}
+
+	snapshot.TakenAt = time.Date(2021, 6, 30, 12, 0, 0, 0, time.UTC)
+
+	var csvOut bytes.Buffer
+	err = snapshot.Export(&csvOut, SnapshotCSV)
+	if err != nil {
+		t.Errorf("Error exporting snapshot as CSV: %s", err.Error())
+	}
+
+	expectedCSV := "stage,id,dealname,companies,contacts\n" +
+		"Applied,2,Pipe | Co,,john@example.com\n" +
+		"Interview,1,Acme,Acme Ltd,Jane Doe\n"
+	if csvOut.String() != expectedCSV {
+		t.Errorf("Unexpected CSV export, expected:\n%s\ngot:\n%s", expectedCSV, csvOut.String())
+	}
+
+	var markdownOut bytes.Buffer
+	err = snapshot.Export(&markdownOut, SnapshotMarkdown)
+	if err != nil {
+		t.Errorf("Error exporting snapshot as Markdown: %s", err.Error())
+	}
+
+	expectedMarkdown := "# Deal flow\n\nSnapshot taken at 2021-06-30T12:00:00Z\n" +
+		"\n## Applied (1)\n\n" +
+		"| id | dealname | companies | contacts |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| 2 | Pipe \\| Co |  | john@example.com |\n" +
+		"\n## Interview (1)\n\n" +
+		"| id | dealname | companies | contacts |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| 1 | Acme | Acme Ltd | Jane Doe |\n"
+	if markdownOut.String() != expectedMarkdown {
+		t.Errorf("Unexpected Markdown export, expected:\n%s\ngot:\n%s", expectedMarkdown, markdownOut.String())
+	}
+
+	var jsonOut bytes.Buffer
+	err = snapshot.Export(&jsonOut, SnapshotJSON)
+	if err != nil {
+		t.Errorf("Error exporting snapshot as JSON: %s", err.Error())
+	}
+}
@@ -0,0 +1,166 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// StaleSince is the date a deal's inactivity is measured from
+type StaleSince int64
+
+const (
+	// StaleSinceLastModified measures inactivity from the hs_lastmodifieddate of the deal
+	StaleSinceLastModified StaleSince = iota
+	// StaleSinceStageEntry measures inactivity from the date the deal entered its current stage
+	StaleSinceStageEntry
+)
+
+// StaleDealOptions configures the detection of stale deals in a pipeline
+// Thresholds are per stage id, DefaultThreshold is used for the other stages, unless it is zero, in which case they are not checked.
+// When CreateTasks is set, a follow-up task due after TaskDueIn is created for the owner of each stale deal,
+// unless DryRun is set, in which case only the report is produced.
+type StaleDealOptions struct {
+	PipelineId       string
+	Thresholds       map[string]time.Duration
+	DefaultThreshold time.Duration
+	Since            StaleSince
+	CreateTasks      bool
+	DryRun           bool
+	TaskDueIn        time.Duration
+}
+
+// StaleDeal is a deal that has been inactive for longer than the threshold of its stage
+// TaskId is the id of the follow-up task created for the deal, TaskErr is set if creating the task failed.
+type StaleDeal struct {
+	DealId        string
+	DealName      string
+	StageId       string
+	OwnerId       string
+	InactiveSince time.Time
+	Inactive      time.Duration
+	Threshold     time.Duration
+	TaskId        string
+	TaskErr       error
+}
+
+// StaleDealReport is the result of a stale deal detection run, deals are ordered from the longest inactive
+type StaleDealReport struct {
+	PipelineId  string
+	GeneratedAt time.Time
+	DryRun      bool
+	Deals       []StaleDeal
+}
+
+// FindStaleDeals finds the deals of a pipeline that have been inactive for longer than the threshold of their stage,
+// and optionally creates a follow-up task for the deal owner
+func (api HubspotDealFlowAPI) FindStaleDeals(options StaleDealOptions) (*StaleDealReport, error) {
+	log.Infof("Looking for stale deals in pipeline '%s'", options.PipelineId)
+
+	pipeline, err := getPipeline(api.httpClient, api.APIKey, "deals", options.PipelineId)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := []string{"dealname", "dealstage", "hubspot_owner_id", "hs_lastmodifieddate"}
+	checkedStages := []string{}
+	for _, stage := range pipeline.Stages {
+		if options.threshold(stage.Id) > 0 {
+			checkedStages = append(checkedStages, stage.Id)
+			properties = append(properties, "hs_date_entered_"+stage.Id)
+		}
+	}
+
+	now := time.Now().UTC()
+	report := StaleDealReport{
+		PipelineId:  options.PipelineId,
+		GeneratedAt: now,
+		DryRun:      options.DryRun,
+		Deals:       []StaleDeal{},
+	}
+
+	if len(checkedStages) == 0 {
+		return &report, nil
+	}
+
+	it := api.SearchDeals(SearchQuery{
+		Filters: []SearchFilter{
+			{PropertyName: "pipeline", Operator: "EQ", Value: options.PipelineId},
+			{PropertyName: "dealstage", Operator: "IN", Values: checkedStages},
+		},
+		Properties: properties,
+	})
+	for it.Next() {
+		deal := it.Result()
+		stageId := deal.Properties["dealstage"]
+
+		sinceProperty := "hs_lastmodifieddate"
+		if options.Since == StaleSinceStageEntry {
+			sinceProperty = "hs_date_entered_" + stageId
+		}
+
+		inactiveSince, err := parseHubSpotTime(deal.Properties[sinceProperty])
+		if err != nil {
+			log.Warnf("Deal '%s' has no valid %s, skipping it", deal.Id, sinceProperty)
+			continue
+		}
+
+		threshold := options.threshold(stageId)
+		inactive := now.Sub(inactiveSince)
+		if inactive <= threshold {
+			continue
+		}
+
+		report.Deals = append(report.Deals, StaleDeal{
+			DealId:        deal.Id,
+			DealName:      deal.Properties["dealname"],
+			StageId:       stageId,
+			OwnerId:       deal.Properties["hubspot_owner_id"],
+			InactiveSince: inactiveSince,
+			Inactive:      inactive,
+			Threshold:     threshold,
+		})
+	}
+	if it.Err() != nil {
+		return nil, it.Err()
+	}
+
+	sort.SliceStable(report.Deals, func(i, j int) bool {
+		return report.Deals[i].Inactive > report.Deals[j].Inactive
+	})
+
+	if options.CreateTasks && !options.DryRun {
+		for i := range report.Deals {
+			staleDeal := &report.Deals[i]
+			staleDeal.TaskId, staleDeal.TaskErr = api.createFollowUpTask(*staleDeal, now.Add(options.TaskDueIn))
+		}
+	}
+
+	return &report, nil
+}
+
+// threshold returns the inactivity threshold of a stage, zero if the stage is not checked
+func (options StaleDealOptions) threshold(stageId string) time.Duration {
+	if threshold, ok := options.Thresholds[stageId]; ok {
+		return threshold
+	}
+	return options.DefaultThreshold
+}
+
+// createFollowUpTask creates a task for the owner of a stale deal, associated with the deal
+func (api HubspotDealFlowAPI) createFollowUpTask(staleDeal StaleDeal, due time.Time) (string, error) {
+	task, err := api.engagementAPI().CreateTask(
+		fmt.Sprintf("Follow up on %s", staleDeal.DealName),
+		fmt.Sprintf("The deal has not moved for %d days", int(staleDeal.Inactive.Hours()/24)),
+		staleDeal.OwnerId,
+		due,
+		[]ObjectAssociation{{ObjectType: "deals", Id: staleDeal.DealId}},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return task.Id, nil
+}
@@ -0,0 +1,123 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func staleDealsMock(t *testing.T) *IHTTPClientMock {
+	return &IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/pipelines/deals/default?hapikey=api_key" {
+				writeJSONResponse(t, w, 200, dealPipelinesResponse.Results[0])
+			} else if url == "https://api.hubapi.com/crm/v3/objects/deals/search?hapikey=api_key" {
+				var request searchPageRequest
+				readJSONRequest(t, req, &request)
+
+				expectedFilters := []SearchFilter{
+					{PropertyName: "pipeline", Operator: "EQ", Value: "default"},
+					{PropertyName: "dealstage", Operator: "IN", Values: []string{"applied", "interview"}},
+				}
+				if !cmp.Equal(expectedFilters, request.FilterGroups[0].Filters) {
+					t.Errorf("Unexpected search filters, expected:\n%v\ngot:\n%v", expectedFilters, request.FilterGroups[0].Filters)
+				}
+
+				recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+				writeJSONResponse(t, w, 200, searchPageResponse{
+					Total: 3,
+					Results: []HubSpotSearchResult{
+						{Id: "1", Properties: map[string]string{
+							"dealname": "Acme", "dealstage": "applied", "hubspot_owner_id": "owner1",
+							"hs_lastmodifieddate": "2021-06-01T00:00:00Z",
+						}},
+						{Id: "2", Properties: map[string]string{
+							"dealname": "Recent", "dealstage": "applied", "hubspot_owner_id": "owner1",
+							"hs_lastmodifieddate": recent,
+						}},
+						{Id: "3", Properties: map[string]string{
+							"dealname": "Older", "dealstage": "interview", "hubspot_owner_id": "owner2",
+							"hs_lastmodifieddate": "2021-01-01T00:00:00Z",
+						}},
+					},
+				})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/tasks?hapikey=api_key" {
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				if request.Properties["hubspot_owner_id"] == "" || request.Properties["hs_task_status"] != "NOT_STARTED" {
+					t.Errorf("Unexpected task properties: %v", request.Properties)
+				}
+
+				dealId := request.Associations[0].To.Id
+				if request.Associations[0].Types[0].AssociationTypeId != 216 {
+					t.Errorf("Unexpected task association: %v", request.Associations)
+				}
+
+				writeJSONResponse(t, w, 201, map[string]string{"id": "task-" + dealId})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+}
+
+func TestFindStaleDeals(t *testing.T) {
+	options := StaleDealOptions{
+		PipelineId: "default",
+		Thresholds: map[string]time.Duration{
+			"applied":   7 * 24 * time.Hour,
+			"interview": 14 * 24 * time.Hour,
+		},
+		CreateTasks: true,
+		TaskDueIn:   24 * time.Hour,
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		mockHubspotHTTPClient := staleDealsMock(t)
+		api := getMockDealFlowAPI(mockHubspotHTTPClient)
+
+		options.DryRun = dryRun
+		report, err := api.FindStaleDeals(options)
+		if err != nil {
+			t.Errorf("FindStaleDeals returned an error: %s", err.Error())
+			return
+		}
+
+		ids := []string{}
+		taskIds := []string{}
+		for _, deal := range report.Deals {
+			ids = append(ids, deal.DealId)
+			taskIds = append(taskIds, deal.TaskId)
+		}
+
+		if !cmp.Equal([]string{"3", "1"}, ids) {
+			t.Errorf("FindStaleDeals returned incorrect deals, expected: [3 1], got: %v", ids)
+		}
+
+		expectedTaskIds := []string{"task-3", "task-1"}
+		expectedCalls := 4
+		if dryRun {
+			expectedTaskIds = []string{"", ""}
+			expectedCalls = 2
+		}
+
+		if !cmp.Equal(expectedTaskIds, taskIds) {
+			t.Errorf("FindStaleDeals created incorrect tasks, expected: %v, got: %v", expectedTaskIds, taskIds)
+		}
+
+		if len(mockHubspotHTTPClient.DoCalls()) != expectedCalls {
+			t.Errorf("Expected %d calls to HubSpot API, got %d", expectedCalls, len(mockHubspotHTTPClient.DoCalls()))
+		}
+	}
+}
@@ -2,6 +2,7 @@ package go_hubspot
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/google/go-cmp/cmp"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func getMockDealFlowAPI(mockClient *IHTTPClientMock) HubspotDealFlowAPI {
@@ -54,7 +56,7 @@ func TestCreateDealFlowCard(t *testing.T) {
 				// Test the body
 
 				expectedRequest := dealCreationRequest{
-					map[string]string{
+					Properties: map[string]string{
 						"dealname":                  "cardName",
 						"dealstage":                 "stageName",
 						"pipeline":                  "pipeline",
@@ -83,7 +85,7 @@ func TestCreateDealFlowCard(t *testing.T) {
 				}
 
 				if !reflect.DeepEqual(expectedRequest, request) {
-					t.Errorf("Unexpected CreateDealFlowCard request, expected:\n%s\ngot:\n%s", expectedRequest, request)
+					t.Errorf("Unexpected CreateDealFlowCard request, expected:\n%v\ngot:\n%v", expectedRequest, request)
 				}
 
 				// Send the expected response
@@ -281,6 +283,333 @@ func TestCreateDealFlowCard(t *testing.T) {
 	}
 }
 
+func TestCreateDealFlowCardInlineAssociations(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/deals?hapikey=api_key" {
+				expectedAssociations := []inlineAssociation{
+					{
+						To:    inlineAssociationTo{Id: "companyId"},
+						Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 5}},
+					},
+					{
+						To:    inlineAssociationTo{Id: "contactId"},
+						Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 3}},
+					},
+				}
+
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("Error reading CreateDealFlowCard request body: %s", err.Error())
+				}
+
+				var request dealCreationRequest
+				err = json.Unmarshal(body, &request)
+				if err != nil {
+					t.Errorf("Error unmarshalling CreateDealFlowCard request: %s", err.Error())
+				}
+
+				if !cmp.Equal(expectedAssociations, request.Associations) {
+					t.Errorf("Unexpected CreateDealFlowCard associations, expected:\n%v\ngot:\n%v", expectedAssociations, request.Associations)
+				}
+
+				w.WriteHeader(201)
+				_, _ = w.Write([]byte(`{"id":"dealId"}`))
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	response, err := api.CreateDealFlowCard(
+		"cardName",
+		"contactId",
+		"deal_to_contact",
+		"companyId",
+		"stageName",
+		"pipeline",
+		"HubspotOwnerId",
+		map[string]string{},
+	)
+	if err != nil {
+		t.Errorf("CreateDealFlowCard returned an error: %s", err.Error())
+		return
+	}
+
+	if response.Id != "dealId" {
+		t.Errorf("CreateDealFlowCard returned incorrect deal id, expected: dealId, got: %s", response.Id)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 1 {
+		t.Errorf("Expected 1 call to HubSpot API")
+	}
+}
+
+func TestCreateDealFlowCardRollback(t *testing.T) {
+	for _, archiveStatus := range []int{204, 500} {
+		archived := false
+
+		mockHubspotHTTPClient := IHTTPClientMock{
+			GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+			DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+				url := fmt.Sprintf("%s", req.URL)
+
+				w := httptest.NewRecorder()
+				if url == "https://api.hubapi.com/crm/v3/objects/deals?hapikey=api_key" {
+					w.WriteHeader(201)
+					_, _ = w.Write([]byte(`{"id":"dealId"}`))
+				} else if url == "https://api.hubapi.com/crm/v3/associations/deal/company/batch/create?hapikey=api_key" {
+					w.WriteHeader(201)
+				} else if url == "https://api.hubapi.com/crm/v3/associations/deal/contact/batch/create?hapikey=api_key" {
+					w.WriteHeader(400)
+					_, _ = w.Write([]byte(`{"message":"Invalid association type"}`))
+				} else if url == "https://api.hubapi.com/crm/v3/objects/deals/dealId?hapikey=api_key" {
+					if req.Method != "DELETE" {
+						t.Errorf("Deal rollback used %s, instead of DELETE", req.Method)
+					}
+					archived = true
+					w.WriteHeader(archiveStatus)
+				} else {
+					t.Errorf("Unexpected url %s", url)
+				}
+
+				return w.Result(), nil
+			},
+		}
+
+		api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+		response, err := api.CreateDealFlowCard(
+			"cardName",
+			"contactId",
+			"custom_label",
+			"companyId",
+			"stageName",
+			"pipeline",
+			"HubspotOwnerId",
+			map[string]string{},
+		)
+		if err == nil {
+			t.Errorf("CreateDealFlowCard expected to return an error, got response: %v", response)
+			continue
+		}
+
+		if !archived {
+			t.Errorf("Expected the deal to be archived after a failed association")
+		}
+
+		var apiErr HubSpotAPIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+			t.Errorf("Expected the association error to be returned, got: %s", err.Error())
+		}
+
+		var partialFailure *DealFlowCardPartialFailure
+		isPartialFailure := errors.As(err, &partialFailure)
+		if archiveStatus == 500 && (!isPartialFailure || partialFailure.DealId != "dealId") {
+			t.Errorf("Expected a partial failure for deal 'dealId' when archiving fails, got: %s", err.Error())
+		}
+		if archiveStatus != 500 && isPartialFailure {
+			t.Errorf("Unexpected partial failure when archiving succeeded: %s", err.Error())
+		}
+	}
+}
+
+func TestCreateDeal(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/deals?hapikey=api_key" {
+				expectedRequest := dealCreationRequest{
+					Properties: map[string]string{
+						"dealname":         "dealName",
+						"pipeline":         "pipeline",
+						"dealstage":        "stageName",
+						"hubspot_owner_id": "ownerId",
+						"amount":           "1000",
+						"closedate":        "2021-06-30T00:00:00Z",
+						"application_id":   "applicationId",
+					},
+					Associations: []inlineAssociation{
+						{
+							To:    inlineAssociationTo{Id: "contactId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 3}},
+						},
+						{
+							To:    inlineAssociationTo{Id: "otherContactId"},
+							Types: []associationTypeSpec{{"USER_DEFINED", 42}},
+						},
+						{
+							To:    inlineAssociationTo{Id: "companyId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 5}},
+						},
+						{
+							To:    inlineAssociationTo{Id: "lineItemId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 19}},
+						},
+						{
+							To:    inlineAssociationTo{Id: "ticketId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 27}},
+						},
+					},
+				}
+
+				body, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Errorf("Error reading CreateDeal request body: %s", err.Error())
+				}
+
+				var request dealCreationRequest
+				err = json.Unmarshal(body, &request)
+				if err != nil {
+					t.Errorf("Error unmarshalling CreateDeal request: %s", err.Error())
+				}
+
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected CreateDeal request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				w.WriteHeader(201)
+				_, _ = w.Write([]byte(`{"id":"dealId","properties":{"dealname":"dealName","amount":"1000"},"createdAt":"CreatedAt","updatedAt":"UpdatedAt","archived":false}`))
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	deal, err := api.CreateDeal(DealCreateRequest{
+		Name:      "dealName",
+		Pipeline:  "pipeline",
+		Stage:     "stageName",
+		OwnerId:   "ownerId",
+		Amount:    "1000",
+		CloseDate: time.Date(2021, 6, 30, 0, 0, 0, 0, time.UTC),
+		Properties: map[string]string{
+			"application_id": "applicationId",
+		},
+		Associations: []DealCreateAssociation{
+			{ObjectType: "contacts", Id: "contactId"},
+			{ObjectType: "contacts", Id: "otherContactId", TypeId: 42, Category: "USER_DEFINED"},
+			{ObjectType: "companies", Id: "companyId"},
+			{ObjectType: "line_items", Id: "lineItemId"},
+			{ObjectType: "tickets", Id: "ticketId"},
+		},
+	})
+	if err != nil {
+		t.Errorf("CreateDeal returned an error: %s", err.Error())
+		return
+	}
+
+	expected := Deal{
+		Id: "dealId",
+		Properties: map[string]string{
+			"dealname": "dealName",
+			"amount":   "1000",
+		},
+		CreatedAt: "CreatedAt",
+		UpdatedAt: "UpdatedAt",
+	}
+
+	if !cmp.Equal(expected, *deal) {
+		t.Errorf("CreateDeal returned incorrect deal, expected:\n%v\ngot:\n%v", expected, *deal)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 1 {
+		t.Errorf("Expected 1 call to HubSpot API")
+	}
+}
+
+func TestCreateDealMixedAssociations(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "POST https://api.hubapi.com/crm/v3/objects/deals?hapikey=api_key":
+				var request dealCreationRequest
+				readJSONRequest(t, req, &request)
+				if len(request.Associations) != 0 {
+					t.Errorf("Expected the associations to be made separately, got: %v", request.Associations)
+				}
+
+				writeJSONResponse(t, w, 201, Deal{Id: "dealId"})
+			case "POST https://api.hubapi.com/crm/v4/associations/deals/contacts/batch/create?hapikey=api_key":
+				expectedRequest := typedAssociationBatchRequest{
+					Inputs: []typedAssociation{{
+						From:  inlineAssociationTo{Id: "dealId"},
+						To:    inlineAssociationTo{Id: "contactId"},
+						Types: []associationTypeSpec{{"USER_DEFINED", 42}},
+					}},
+				}
+
+				var request typedAssociationBatchRequest
+				readJSONRequest(t, req, &request)
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected typed association request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 201, map[string]string{"status": "COMPLETE"})
+			case "POST https://api.hubapi.com/crm/v3/associations/deal/company/batch/create?hapikey=api_key":
+				expectedRequest := DealAssociationBatchRequest{
+					Inputs: []DealAssociation{{
+						From: DealAssociationFromTo{Id: "dealId"},
+						To:   DealAssociationFromTo{Id: "companyId"},
+						Type: "reseller",
+					}},
+				}
+
+				var request DealAssociationBatchRequest
+				readJSONRequest(t, req, &request)
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected labelled association request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 201, map[string]string{"status": "COMPLETE"})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	deal, err := api.CreateDeal(DealCreateRequest{
+		Name: "dealName",
+		Associations: []DealCreateAssociation{
+			{ObjectType: "contacts", Id: "contactId", TypeId: 42, Category: "USER_DEFINED"},
+			{ObjectType: "companies", Id: "companyId", Label: "reseller"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateDeal returned an error: %s", err.Error())
+	}
+	if deal.Id != "dealId" {
+		t.Errorf("Unexpected deal %v", deal)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 3 {
+		t.Errorf("Expected 3 calls to HubSpot API, got %d", len(mockHubspotHTTPClient.DoCalls()))
+	}
+}
+
 func TestAssociateDealFlowCard(t *testing.T) {
 	mockHubspotHTTPClient := IHTTPClientMock{
 		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
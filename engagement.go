@@ -0,0 +1,270 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotEngagementAPI interface {
+	CreateEngagement(engagement Engagement, associations []ObjectAssociation) (*Engagement, error)
+	GetEngagement(engagementType EngagementType, engagementId string) (*Engagement, error)
+	UpdateEngagement(engagement Engagement) (*Engagement, error)
+	ArchiveEngagement(engagementType EngagementType, engagementId string) error
+	CreateNote(body, ownerId string, associations []ObjectAssociation) (*Engagement, error)
+	CreateTask(subject, body, ownerId string, dueDate time.Time, associations []ObjectAssociation) (*Engagement, error)
+	GetActivityTimeline(objectType, objectId string) ([]Engagement, error)
+}
+
+// HubspotEngagementAPI is the structure to interact with HubSpot engagement objects: notes, tasks, calls, meetings and emails
+type HubspotEngagementAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+}
+
+// EngagementType is the CRM object type of an engagement
+type EngagementType string
+
+const (
+	EngagementNote    EngagementType = "notes"
+	EngagementTask    EngagementType = "tasks"
+	EngagementCall    EngagementType = "calls"
+	EngagementMeeting EngagementType = "meetings"
+	EngagementEmail   EngagementType = "emails"
+)
+
+// EngagementTypes are all the engagement types, in the order they are fetched for activity timelines
+var EngagementTypes = []EngagementType{EngagementNote, EngagementTask, EngagementCall, EngagementMeeting, EngagementEmail}
+
+// engagementFields are the properties the typed fields of an engagement are stored in, for each engagement type
+var engagementFields = map[EngagementType]struct {
+	body    string
+	subject string
+	status  string
+}{
+	EngagementNote:    {"hs_note_body", "", ""},
+	EngagementTask:    {"hs_task_body", "hs_task_subject", "hs_task_status"},
+	EngagementCall:    {"hs_call_body", "hs_call_title", "hs_call_status"},
+	EngagementMeeting: {"hs_meeting_body", "hs_meeting_title", "hs_meeting_outcome"},
+	EngagementEmail:   {"hs_email_text", "hs_email_subject", "hs_email_status"},
+}
+
+// readOnlyEngagementProperties are the properties HubSpot maintains, they are returned when reading engagements
+// but cannot be written
+var readOnlyEngagementProperties = map[string]bool{
+	"hs_object_id":        true,
+	"hs_createdate":       true,
+	"hs_lastmodifieddate": true,
+	"createdate":          true,
+	"lastmodifieddate":    true,
+}
+
+// Engagement is an activity logged against CRM records
+// Subject is the subject of tasks and emails, or the title of calls and meetings, notes have none.
+// Status is the status of tasks, calls and emails, or the outcome of meetings, notes have none.
+// For tasks, the due date is stored as the timestamp of the task.
+// Properties holds any other properties of the engagement.
+type Engagement struct {
+	Id         string            `json:"id"`
+	Type       EngagementType    `json:"type"`
+	Timestamp  time.Time         `json:"timestamp"`
+	OwnerId    string            `json:"ownerId"`
+	Body       string            `json:"body"`
+	Subject    string            `json:"subject"`
+	Status     string            `json:"status"`
+	Properties map[string]string `json:"properties"`
+}
+
+// DueDate returns the due date of a task
+func (engagement Engagement) DueDate() time.Time {
+	return engagement.Timestamp
+}
+
+// properties returns the HubSpot properties of the engagement to write, fields that are not set and read-only properties are omitted
+func (engagement Engagement) properties() map[string]string {
+	properties := map[string]string{}
+	for key, value := range engagement.Properties {
+		if !readOnlyEngagementProperties[key] {
+			properties[key] = value
+		}
+	}
+
+	fields := engagementFields[engagement.Type]
+	values := map[string]string{
+		"hubspot_owner_id": engagement.OwnerId,
+		fields.body:        engagement.Body,
+		fields.subject:     engagement.Subject,
+		fields.status:      engagement.Status,
+	}
+	for key, value := range values {
+		if key != "" && value != "" {
+			properties[key] = value
+		}
+	}
+
+	if !engagement.Timestamp.IsZero() {
+		properties["hs_timestamp"] = engagement.Timestamp.UTC().Format(time.RFC3339)
+	}
+
+	return properties
+}
+
+// engagementProperties returns the properties to request when reading engagements of a type
+func engagementProperties(engagementType EngagementType) []string {
+	fields := engagementFields[engagementType]
+	properties := []string{"hs_timestamp", "hubspot_owner_id", "hs_attachment_ids", fields.body}
+	if fields.subject != "" {
+		properties = append(properties, fields.subject, fields.status)
+	}
+	return properties
+}
+
+// engagementFromObject creates an engagement from a HubSpot object of the engagement type
+func engagementFromObject(engagementType EngagementType, object HubSpotSearchResult) Engagement {
+	fields := engagementFields[engagementType]
+
+	engagement := Engagement{
+		Id:         object.Id,
+		Type:       engagementType,
+		OwnerId:    object.Properties["hubspot_owner_id"],
+		Body:       object.Properties[fields.body],
+		Properties: object.Properties,
+	}
+	if fields.subject != "" {
+		engagement.Subject = object.Properties[fields.subject]
+		engagement.Status = object.Properties[fields.status]
+	}
+
+	timestamp, err := parseHubSpotTime(object.Properties["hs_timestamp"])
+	if err == nil {
+		engagement.Timestamp = timestamp
+	}
+
+	return engagement
+}
+
+// NewHubspotEngagementAPI creates new HubspotEngagementAPI with API key
+func NewHubspotEngagementAPI(apiKey string) HubspotEngagementAPI {
+	return NewHubspotEngagementAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotEngagementAPIWithClient creates new HubspotEngagementAPI with API key and HTTP client
+func NewHubspotEngagementAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotEngagementAPI {
+	return HubspotEngagementAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (api HubspotEngagementAPI) objects(engagementType EngagementType) crmObjects {
+	return crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: string(engagementType)}
+}
+
+// CreateEngagement creates an engagement associated with the given records, e.g. contacts, companies or deals
+// Associations default to the HubSpot defined <engagement>_to_<object type> labels, see ObjectAssociation.
+func (api HubspotEngagementAPI) CreateEngagement(engagement Engagement, associations []ObjectAssociation) (*Engagement, error) {
+	log.Infof("Creating an engagement of type %s", engagement.Type)
+
+	if engagement.Timestamp.IsZero() {
+		engagement.Timestamp = time.Now()
+	}
+
+	var object HubSpotSearchResult
+	err := api.objects(engagement.Type).createAssociated(engagement.properties(), associations, &object)
+	if err != nil {
+		return nil, err
+	}
+
+	created := engagementFromObject(engagement.Type, object)
+	return &created, nil
+}
+
+// GetEngagement returns the engagement of the given type with the given id
+func (api HubspotEngagementAPI) GetEngagement(engagementType EngagementType, engagementId string) (*Engagement, error) {
+	var object HubSpotSearchResult
+	err := api.objects(engagementType).get(engagementId, engagementProperties(engagementType), &object)
+	if err != nil {
+		return nil, err
+	}
+
+	engagement := engagementFromObject(engagementType, object)
+	return &engagement, nil
+}
+
+// UpdateEngagement updates the fields of the engagement that are set
+func (api HubspotEngagementAPI) UpdateEngagement(engagement Engagement) (*Engagement, error) {
+	var object HubSpotSearchResult
+	err := api.objects(engagement.Type).update(engagement.Id, engagement.properties(), &object)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := engagementFromObject(engagement.Type, object)
+	return &updated, nil
+}
+
+// ArchiveEngagement archives the engagement of the given type with the given id
+func (api HubspotEngagementAPI) ArchiveEngagement(engagementType EngagementType, engagementId string) error {
+	return api.objects(engagementType).archive(engagementId)
+}
+
+// CreateNote creates a note owned by the given owner, associated with the given records
+func (api HubspotEngagementAPI) CreateNote(body, ownerId string, associations []ObjectAssociation) (*Engagement, error) {
+	return api.CreateEngagement(Engagement{
+		Type:    EngagementNote,
+		OwnerId: ownerId,
+		Body:    body,
+	}, associations)
+}
+
+// CreateTask creates a task for the given owner, due at the given date, associated with the given records
+func (api HubspotEngagementAPI) CreateTask(subject, body, ownerId string, dueDate time.Time, associations []ObjectAssociation) (*Engagement, error) {
+	return api.CreateEngagement(Engagement{
+		Type:       EngagementTask,
+		Timestamp:  dueDate,
+		OwnerId:    ownerId,
+		Body:       body,
+		Subject:    subject,
+		Status:     "NOT_STARTED",
+		Properties: map[string]string{"hs_task_type": "TODO"},
+	}, associations)
+}
+
+// GetActivityTimeline returns all the engagements associated with a record, newest first
+func (api HubspotEngagementAPI) GetActivityTimeline(objectType, objectId string) ([]Engagement, error) {
+	record := crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: objectType}
+
+	timeline := []Engagement{}
+	for _, engagementType := range EngagementTypes {
+		ids, err := record.associatedIds(objectId, string(engagementType))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		raw, _, err := api.objects(engagementType).batchRead(ids, engagementProperties(engagementType))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rawObject := range raw {
+			var object HubSpotSearchResult
+			err = json.Unmarshal(rawObject, &object)
+			if err != nil {
+				return nil, err
+			}
+			timeline = append(timeline, engagementFromObject(engagementType, object))
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].Timestamp.After(timeline[j].Timestamp)
+	})
+
+	return timeline, nil
+}
@@ -0,0 +1,197 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockEngagementAPI(mockClient *IHTTPClientMock) HubspotEngagementAPI {
+	return HubspotEngagementAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+func TestCreateEngagement(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/calls?hapikey=api_key" {
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := objectCreationRequest{
+					Properties: map[string]string{
+						"hs_timestamp":     "2021-06-30T12:00:00Z",
+						"hubspot_owner_id": "ownerId",
+						"hs_call_body":     "Discussed the application",
+						"hs_call_title":    "Intro call",
+						"hs_call_status":   "COMPLETED",
+					},
+					Associations: []inlineAssociation{
+						{
+							To:    inlineAssociationTo{Id: "contactId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 194}},
+						},
+						{
+							To:    inlineAssociationTo{Id: "dealId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 206}},
+						},
+					},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected CreateEngagement request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 201, HubSpotSearchResult{Id: "callId", Properties: request.Properties})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockEngagementAPI(&mockHubspotHTTPClient)
+
+	call := Engagement{
+		Type:      EngagementCall,
+		Timestamp: time.Date(2021, 6, 30, 12, 0, 0, 0, time.UTC),
+		OwnerId:   "ownerId",
+		Body:      "Discussed the application",
+		Subject:   "Intro call",
+		Status:    "COMPLETED",
+	}
+
+	created, err := api.CreateEngagement(call, []ObjectAssociation{
+		{ObjectType: "contacts", Id: "contactId"},
+		{ObjectType: "deals", Id: "dealId"},
+	})
+	if err != nil {
+		t.Errorf("CreateEngagement returned an error: %s", err.Error())
+		return
+	}
+
+	call.Id = "callId"
+	call.Properties = created.Properties
+	if !cmp.Equal(call, *created) {
+		t.Errorf("CreateEngagement returned incorrect engagement, expected:\n%v\ngot:\n%v", call, *created)
+	}
+}
+
+func TestUpdateEngagement(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/objects/notes/noteId?hapikey=api_key&properties=hs_timestamp%2Chubspot_owner_id%2Chs_attachment_ids%2Chs_note_body":
+				writeJSONResponse(t, w, 200, HubSpotSearchResult{Id: "noteId", Properties: map[string]string{
+					"hs_object_id":        "noteId",
+					"hs_createdate":       "2021-06-30T12:00:00Z",
+					"hs_lastmodifieddate": "2021-06-30T12:00:00Z",
+					"hs_timestamp":        "2021-06-30T12:00:00Z",
+					"hs_note_body":        "Called back",
+				}})
+			case "PATCH https://api.hubapi.com/crm/v3/objects/notes/noteId?hapikey=api_key":
+				var request dealUpdateRequest
+				readJSONRequest(t, req, &request)
+
+				// Read-only properties returned by the read are not written back
+				expectedProperties := map[string]string{
+					"hs_timestamp": "2021-06-30T12:00:00Z",
+					"hs_note_body": "Called back, sent the quote",
+				}
+				if !cmp.Equal(expectedProperties, request.Properties) {
+					t.Errorf("Unexpected UpdateEngagement request, expected:\n%v\ngot:\n%v", expectedProperties, request.Properties)
+				}
+
+				writeJSONResponse(t, w, 200, HubSpotSearchResult{Id: "noteId", Properties: request.Properties})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockEngagementAPI(&mockHubspotHTTPClient)
+
+	note, err := api.GetEngagement(EngagementNote, "noteId")
+	if err != nil {
+		t.Fatalf("GetEngagement returned an error: %s", err.Error())
+	}
+
+	note.Body = "Called back, sent the quote"
+	updated, err := api.UpdateEngagement(*note)
+	if err != nil {
+		t.Fatalf("UpdateEngagement returned an error: %s", err.Error())
+	}
+	if updated.Body != note.Body {
+		t.Errorf("Unexpected updated engagement %v", updated)
+	}
+}
+
+func TestGetActivityTimeline(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "https://api.hubapi.com/crm/v3/objects/deals/dealId/associations/notes?hapikey=api_key&limit=500":
+				writeJSONResponse(t, w, 200, Associations{Results: []Association{{"noteId", "deal_to_note"}}})
+			case "https://api.hubapi.com/crm/v3/objects/deals/dealId/associations/tasks?hapikey=api_key&limit=500":
+				writeJSONResponse(t, w, 200, Associations{Results: []Association{{"taskId", "deal_to_task"}}})
+			case "https://api.hubapi.com/crm/v3/objects/deals/dealId/associations/calls?hapikey=api_key&limit=500",
+				"https://api.hubapi.com/crm/v3/objects/deals/dealId/associations/meetings?hapikey=api_key&limit=500",
+				"https://api.hubapi.com/crm/v3/objects/deals/dealId/associations/emails?hapikey=api_key&limit=500":
+				writeJSONResponse(t, w, 200, Associations{Results: []Association{}})
+			case "https://api.hubapi.com/crm/v3/objects/notes/batch/read?hapikey=api_key":
+				writeJSONResponse(t, w, 200, batchResponse{Results: []HubSpotSearchResult{
+					{Id: "noteId", Properties: map[string]string{"hs_timestamp": "2021-06-01T00:00:00Z", "hs_note_body": "Applied"}},
+				}})
+			case "https://api.hubapi.com/crm/v3/objects/tasks/batch/read?hapikey=api_key":
+				writeJSONResponse(t, w, 200, batchResponse{Results: []HubSpotSearchResult{
+					{Id: "taskId", Properties: map[string]string{"hs_timestamp": "2021-06-10T00:00:00Z", "hs_task_subject": "Interview", "hs_task_status": "NOT_STARTED"}},
+				}})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockEngagementAPI(&mockHubspotHTTPClient)
+
+	timeline, err := api.GetActivityTimeline("deals", "dealId")
+	if err != nil {
+		t.Errorf("GetActivityTimeline returned an error: %s", err.Error())
+		return
+	}
+
+	if len(timeline) != 2 {
+		t.Errorf("Expected 2 engagements in the timeline, got: %v", timeline)
+		return
+	}
+
+	if timeline[0].Id != "taskId" || timeline[0].Subject != "Interview" || timeline[0].Status != "NOT_STARTED" {
+		t.Errorf("Unexpected newest engagement: %v", timeline[0])
+	}
+
+	if timeline[1].Id != "noteId" || timeline[1].Type != EngagementNote || timeline[1].Body != "Applied" {
+		t.Errorf("Unexpected oldest engagement: %v", timeline[1])
+	}
+}
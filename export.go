@@ -0,0 +1,223 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotExportAPI interface {
+	StartExport(request ExportRequest) (string, error)
+	GetExport(taskId string) (*ExportTask, error)
+	WaitForExport(taskId string, pollInterval, timeout time.Duration) (*ExportTask, error)
+	DownloadExport(task ExportTask, w io.Writer) (int64, error)
+	ExportTo(request ExportRequest, w io.Writer, pollInterval, timeout time.Duration) (int64, error)
+}
+
+// HubspotExportAPI is the structure to interact with HubSpot CRM Exports API
+type HubspotExportAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+}
+
+// ExportFormat is a file format exports can be made in
+type ExportFormat string
+
+const (
+	ExportCSV  ExportFormat = "CSV"
+	ExportXLSX ExportFormat = "XLSX"
+)
+
+// Export task statuses reported by HubSpot
+const (
+	ExportPending    = "PENDING"
+	ExportProcessing = "PROCESSING"
+	ExportComplete   = "COMPLETE"
+	ExportCanceled   = "CANCELED"
+	ExportConflict   = "CONFLICT"
+)
+
+// ExportRequest describes an export of all the objects of an object type, e.g. "companies", or of the members of a list when ListId is set
+// Format defaults to CSV and Language, the language of the column headers, to "EN".
+type ExportRequest struct {
+	Name       string
+	ObjectType string
+	Properties []string
+	Format     ExportFormat
+	ListId     string
+	Language   string
+}
+
+// ExportTask is a representation of the state of an export, Result is the URL of the exported file once it is complete
+type ExportTask struct {
+	Id          string `json:"id"`
+	Status      string `json:"status"`
+	Result      string `json:"result"`
+	RequestedAt string `json:"requestedAt"`
+	StartedAt   string `json:"startedAt"`
+	CompletedAt string `json:"completedAt"`
+}
+
+// Finished returns whether the export is complete, was canceled or conflicted with another export
+func (task ExportTask) Finished() bool {
+	return task.Status == ExportComplete || task.Status == ExportCanceled || task.Status == ExportConflict
+}
+
+// exportRequestJSON is a representation of a request to start an export
+type exportRequestJSON struct {
+	ExportType       string   `json:"exportType"`
+	ExportName       string   `json:"exportName"`
+	Format           string   `json:"format"`
+	Language         string   `json:"language"`
+	ObjectType       string   `json:"objectType"`
+	ObjectProperties []string `json:"objectProperties"`
+	ListId           string   `json:"listId,omitempty"`
+}
+
+// NewHubspotExportAPI creates new HubspotExportAPI with API key
+func NewHubspotExportAPI(apiKey string) HubspotExportAPI {
+	return NewHubspotExportAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotExportAPIWithClient creates new HubspotExportAPI with API key and HTTP client
+func NewHubspotExportAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotExportAPI {
+	return HubspotExportAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+// json returns the request to HubSpot to start the export
+func (request ExportRequest) json() exportRequestJSON {
+	requestJSON := exportRequestJSON{
+		ExportType:       "VIEW",
+		ExportName:       request.Name,
+		Format:           string(request.Format),
+		Language:         request.Language,
+		ObjectType:       objectTypeId(request.ObjectType),
+		ObjectProperties: request.Properties,
+		ListId:           request.ListId,
+	}
+
+	if request.ListId != "" {
+		requestJSON.ExportType = "LIST"
+	}
+	if requestJSON.Format == "" {
+		requestJSON.Format = string(ExportCSV)
+	}
+	if requestJSON.Language == "" {
+		requestJSON.Language = "EN"
+	}
+
+	return requestJSON
+}
+
+// StartExport starts an export, and returns the id of the export task
+func (api HubspotExportAPI) StartExport(request ExportRequest) (string, error) {
+	log.Infof("Starting export '%s' of %s", request.Name, request.ObjectType)
+
+	url := fmt.Sprintf("https://api.hubapi.com/crm/v3/exports/export/async?hapikey=%s", api.APIKey)
+
+	var started struct {
+		Id string `json:"id"`
+	}
+	err := doJSONRequest(api.httpClient, "POST", url, request.json(), &started)
+	if err != nil {
+		return "", err
+	}
+
+	return started.Id, nil
+}
+
+// GetExport returns the state of the export task with the given id
+func (api HubspotExportAPI) GetExport(taskId string) (*ExportTask, error) {
+	url := fmt.Sprintf("https://api.hubapi.com/crm/v3/exports/export/async/tasks/%s/status?hapikey=%s", taskId, api.APIKey)
+
+	var task ExportTask
+	err := doJSONRequest(api.httpClient, "GET", url, nil, &task)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Id == "" {
+		task.Id = taskId
+	}
+
+	return &task, nil
+}
+
+// WaitForExport polls the export task with the given id until it is finished, and returns it
+// An error is returned if the export is not finished after timeout, or if it was canceled or conflicted with another export.
+func (api HubspotExportAPI) WaitForExport(taskId string, pollInterval, timeout time.Duration) (*ExportTask, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		task, err := api.GetExport(taskId)
+		if err != nil {
+			return nil, err
+		}
+
+		if task.Finished() {
+			if task.Status != ExportComplete {
+				return task, errors.New(fmt.Sprintf("Export '%s' finished with status %s", taskId, task.Status))
+			}
+			return task, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return task, errors.New(fmt.Sprintf("Export '%s' is still %s after %s", taskId, task.Status, timeout))
+		}
+
+		log.Infof("Export '%s' is %s, checking again in %s", taskId, task.Status, pollInterval)
+		time.Sleep(pollInterval)
+	}
+}
+
+// DownloadExport streams the file of a complete export to w as it is downloaded, and returns the number of bytes written
+func (api HubspotExportAPI) DownloadExport(task ExportTask, w io.Writer) (int64, error) {
+	if task.Status != ExportComplete || task.Result == "" {
+		return 0, errors.New(fmt.Sprintf("Export '%s' is not complete, its status is %s", task.Id, task.Status))
+	}
+
+	// The result is a pre-signed URL, the API key must not be sent with it
+	req, err := http.NewRequest("GET", task.Result, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := api.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return 0, HubSpotAPIError{
+			StatusCode: resp.StatusCode,
+			Body:       string(body),
+		}
+	}
+
+	return io.Copy(w, resp.Body)
+}
+
+// ExportTo starts an export, waits for it to complete, and streams the exported file to w
+func (api HubspotExportAPI) ExportTo(request ExportRequest, w io.Writer, pollInterval, timeout time.Duration) (int64, error) {
+	taskId, err := api.StartExport(request)
+	if err != nil {
+		return 0, err
+	}
+
+	task, err := api.WaitForExport(taskId, pollInterval, timeout)
+	if err != nil {
+		return 0, err
+	}
+
+	return api.DownloadExport(*task, w)
+}
@@ -0,0 +1,130 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockExportAPI(mockClient *IHTTPClientMock) HubspotExportAPI {
+	return HubspotExportAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+// repeatReader endlessly repeats a string, so that large downloads can be tested without holding them in memory
+type repeatReader struct {
+	s string
+	i int
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	for n := range p {
+		p[n] = r.s[r.i%len(r.s)]
+		r.i++
+	}
+	return len(p), nil
+}
+
+// chunkWriter counts the bytes written to it, and the size of the largest write
+type chunkWriter struct {
+	total   int64
+	largest int
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.total += int64(len(p))
+	if len(p) > w.largest {
+		w.largest = len(p)
+	}
+	return len(p), nil
+}
+
+func TestExportTo(t *testing.T) {
+	const size = 10 << 20
+	polls := 0
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "POST https://api.hubapi.com/crm/v3/exports/export/async?hapikey=api_key":
+				var request exportRequestJSON
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := exportRequestJSON{
+					ExportType:       "LIST",
+					ExportName:       "Investors",
+					Format:           "CSV",
+					Language:         "EN",
+					ObjectType:       "0-1",
+					ObjectProperties: []string{"email", "firstname"},
+					ListId:           "42",
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected export request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 202, map[string]string{"id": "taskId"})
+			case "GET https://api.hubapi.com/crm/v3/exports/export/async/tasks/taskId/status?hapikey=api_key":
+				polls++
+				if polls == 1 {
+					writeJSONResponse(t, w, 200, ExportTask{Status: ExportProcessing})
+				} else {
+					writeJSONResponse(t, w, 200, ExportTask{Status: ExportComplete, Result: "https://exports.example.com/file.csv?signature=x"})
+				}
+			case "GET https://exports.example.com/file.csv?signature=x":
+				return &http.Response{
+					StatusCode: 200,
+					Body:       ioutil.NopCloser(io.LimitReader(&repeatReader{s: "email,firstname\n"}, size)),
+				}, nil
+			default:
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockExportAPI(&mockHubspotHTTPClient)
+
+	var writer chunkWriter
+	written, err := api.ExportTo(ExportRequest{
+		Name:       "Investors",
+		ObjectType: "contacts",
+		Properties: []string{"email", "firstname"},
+		ListId:     "42",
+	}, &writer, time.Millisecond, time.Second)
+	if err != nil {
+		t.Errorf("ExportTo returned an error: %s", err.Error())
+		return
+	}
+
+	if written != size || writer.total != size {
+		t.Errorf("Expected %d bytes to be written, got %d (%d)", size, written, writer.total)
+	}
+
+	if writer.largest >= size {
+		t.Errorf("Expected the export to be streamed in chunks, it was written in a single write of %d bytes", writer.largest)
+	}
+}
+
+func TestDownloadIncompleteExport(t *testing.T) {
+	api := getMockExportAPI(&IHTTPClientMock{})
+
+	_, err := api.DownloadExport(ExportTask{Id: "taskId", Status: ExportProcessing}, &strings.Builder{})
+	if err == nil {
+		t.Errorf("Expected an error when downloading an incomplete export")
+	}
+}
@@ -30,11 +30,16 @@ type FileUploadResponse struct {
 
 // NewHubspotFileAPI creates new HubspotFileAPI and API key
 func NewHubspotFileAPI(apiKey string, portalId string) HubspotFileAPI {
+	return NewHubspotFileAPIWithClient(apiKey, portalId, HTTPClient{})
+}
+
+// NewHubspotFileAPIWithClient creates new HubspotFileAPI with API key, portal ID and HTTP client
+func NewHubspotFileAPIWithClient(apiKey string, portalId string, httpClient IHTTPClient) HubspotFileAPI {
 	return HubspotFileAPI{
 		URLTemplate: "https://api.hubapi.com/files/v3/files?hapikey=%s",
 		APIKey:      apiKey,
 		PortalID:    portalId,
-		httpClient:  HTTPClient{},
+		httpClient:  httpClient,
 	}
 }
 
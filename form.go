@@ -46,13 +46,21 @@ type HubspotResponse struct {
 	Paging  *Paging      `json:"paging"`
 }
 
+// formSubmissionsURLTemplate is the URL of a page of submissions of a form, given the form ID, API key and page
+const formSubmissionsURLTemplate = "https://api.hubapi.com/form-integrations/v1/submissions/forms/%s?hapikey=%s&limit=50&after=%s"
+
 // NewHubspotFormAPI creates new HubspotFormAPI with form ID and API key
 func NewHubspotFormAPI(formID string, apiKey string) HubspotFormAPI {
+	return NewHubspotFormAPIWithClient(formID, apiKey, HTTPClient{})
+}
+
+// NewHubspotFormAPIWithClient creates new HubspotFormAPI with form ID, API key and HTTP client
+func NewHubspotFormAPIWithClient(formID string, apiKey string, httpClient IHTTPClient) HubspotFormAPI {
 	return HubspotFormAPI{
-		URLTemplate: "https://api.hubapi.com/form-integrations/v1/submissions/forms/%s?hapikey=%s&limit=50&after=%s",
+		URLTemplate: formSubmissionsURLTemplate,
 		FormID:      formID,
 		APIKey:      apiKey,
-		httpClient:  HTTPClient{},
+		httpClient:  httpClient,
 	}
 }
 
@@ -0,0 +1,263 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotGDPRAPI interface {
+	DeleteContact(contactId string) error
+	DeleteContactByEmail(email string) error
+	ExportContact(contactId string, formIds []string) (*DataSubjectExport, error)
+	ExportContactByEmail(email string, formIds []string) (*DataSubjectExport, error)
+}
+
+// HubspotGDPRAPI is the structure to handle data subject requests for HubSpot contacts
+type HubspotGDPRAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+}
+
+// gdprDeleteRequest is a representation of a GDPR delete request, IdProperty is set when the contact is not identified by its id
+type gdprDeleteRequest struct {
+	ObjectId   string `json:"objectId"`
+	IdProperty string `json:"idProperty,omitempty"`
+}
+
+// DataSubjectFormSubmission is a submission of a form by the data subject
+type DataSubjectFormSubmission struct {
+	FormId      string      `json:"formId"`
+	SubmittedAt time.Time   `json:"submittedAt"`
+	Values      []FormValue `json:"values"`
+}
+
+// DataSubjectFile is a file attached to an engagement of the data subject
+type DataSubjectFile struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	Size      int64  `json:"size"`
+	Url       string `json:"url"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// DataSubjectExport is everything HubSpot holds about a contact
+// Associations are the ids of the companies, deals and tickets associated with the contact.
+type DataSubjectExport struct {
+	ContactId       string                      `json:"contactId"`
+	ExportedAt      time.Time                   `json:"exportedAt"`
+	Properties      map[string]string           `json:"properties"`
+	FormSubmissions []DataSubjectFormSubmission `json:"formSubmissions"`
+	Engagements     []Engagement                `json:"engagements"`
+	Associations    map[string][]string         `json:"associations"`
+	Files           []DataSubjectFile           `json:"files"`
+}
+
+// dataSubjectAssociationTypes are the object types whose associations with the contact are exported
+var dataSubjectAssociationTypes = []string{"companies", "deals", "tickets"}
+
+// NewHubspotGDPRAPI creates new HubspotGDPRAPI with API key
+func NewHubspotGDPRAPI(apiKey string) HubspotGDPRAPI {
+	return NewHubspotGDPRAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotGDPRAPIWithClient creates new HubspotGDPRAPI with API key and HTTP client
+func NewHubspotGDPRAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotGDPRAPI {
+	return HubspotGDPRAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (api HubspotGDPRAPI) objects() crmObjects {
+	return crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: "contacts"}
+}
+
+// DeleteContact permanently deletes the contact with the given id and all its content, this cannot be undone
+func (api HubspotGDPRAPI) DeleteContact(contactId string) error {
+	log.Infof("Permanently deleting contact '%s'", contactId)
+
+	return doJSONRequest(api.httpClient, "POST", api.objects().url("/gdpr-delete", nil), gdprDeleteRequest{ObjectId: contactId}, nil)
+}
+
+// DeleteContactByEmail permanently deletes the contact with the given email and all its content, this cannot be undone
+func (api HubspotGDPRAPI) DeleteContactByEmail(email string) error {
+	log.Infof("Permanently deleting a contact by email")
+
+	request := gdprDeleteRequest{ObjectId: normaliseEmail(email), IdProperty: "email"}
+	return doJSONRequest(api.httpClient, "POST", api.objects().url("/gdpr-delete", nil), request, nil)
+}
+
+// ExportContact collects all the properties, engagements, associations and engagement attachments of a contact,
+// and its submissions of the given forms, matched on the contact's email
+func (api HubspotGDPRAPI) ExportContact(contactId string, formIds []string) (*DataSubjectExport, error) {
+	log.Infof("Exporting the data of contact '%s'", contactId)
+
+	properties, err := api.contactProperties()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, _, err := api.objects().batchRead([]string{contactId}, properties)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, errors.New(fmt.Sprintf("There is no contact with id '%s'", contactId))
+	}
+
+	var contact HubSpotSearchResult
+	err = json.Unmarshal(raw[0], &contact)
+	if err != nil {
+		return nil, err
+	}
+
+	export := DataSubjectExport{
+		ContactId:       contact.Id,
+		ExportedAt:      time.Now().UTC(),
+		Properties:      contact.Properties,
+		FormSubmissions: []DataSubjectFormSubmission{},
+		Associations:    map[string][]string{},
+		Files:           []DataSubjectFile{},
+	}
+
+	if email := contact.Properties["email"]; email != "" {
+		for _, formId := range formIds {
+			submissions, err := api.formSubmissions(formId, email)
+			if err != nil {
+				return nil, err
+			}
+			export.FormSubmissions = append(export.FormSubmissions, submissions...)
+		}
+	}
+
+	engagementAPI := HubspotEngagementAPI{APIKey: api.APIKey, httpClient: api.httpClient}
+	export.Engagements, err = engagementAPI.GetActivityTimeline("contacts", contact.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, objectType := range dataSubjectAssociationTypes {
+		ids, err := api.objects().associatedIds(contact.Id, objectType)
+		if err != nil {
+			return nil, err
+		}
+		export.Associations[objectType] = ids
+	}
+
+	for _, fileId := range attachmentIds(export.Engagements) {
+		var file DataSubjectFile
+		url := fmt.Sprintf("https://api.hubapi.com/files/v3/files/%s?hapikey=%s", fileId, api.APIKey)
+		err = doJSONRequest(api.httpClient, "GET", url, nil, &file)
+		if err != nil {
+			return nil, err
+		}
+		export.Files = append(export.Files, file)
+	}
+
+	return &export, nil
+}
+
+// ExportContactByEmail exports the contact with the given email, see ExportContact
+func (api HubspotGDPRAPI) ExportContactByEmail(email string, formIds []string) (*DataSubjectExport, error) {
+	contacts, err := newSearchIterator(api.APIKey, api.httpClient, "contacts", SearchQuery{
+		Filters:    []SearchFilter{{PropertyName: "email", Operator: "EQ", Value: normaliseEmail(email)}},
+		Properties: []string{"email"},
+	}).All()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(contacts) == 0 {
+		return nil, errors.New(fmt.Sprintf("There is no contact with email '%s'", email))
+	}
+
+	return api.ExportContact(contacts[0].Id, formIds)
+}
+
+// contactProperties returns the names of all the contact properties defined in HubSpot
+func (api HubspotGDPRAPI) contactProperties() ([]string, error) {
+	definitions, err := getPropertyDefinitions(api.httpClient, api.APIKey, "contacts")
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make([]string, len(definitions))
+	for i, definition := range definitions {
+		properties[i] = definition.Name
+	}
+
+	return properties, nil
+}
+
+// formSubmissions returns the submissions of a form with the given email
+func (api HubspotGDPRAPI) formSubmissions(formId, email string) ([]DataSubjectFormSubmission, error) {
+	formAPI := HubspotFormAPI{
+		URLTemplate: formSubmissionsURLTemplate,
+		FormID:      formId,
+		APIKey:      api.APIKey,
+		httpClient:  api.httpClient,
+	}
+
+	submissions := []DataSubjectFormSubmission{}
+	after := ""
+	for {
+		page, err := formAPI.Query(after)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, submission := range page.Results {
+			for _, value := range submission.Values {
+				if value.Name == "email" && normaliseEmail(value.Value) == normaliseEmail(email) {
+					submissions = append(submissions, DataSubjectFormSubmission{
+						FormId:      formId,
+						SubmittedAt: time.Unix(0, submission.SubmittedAt*int64(time.Millisecond)).UTC(),
+						Values:      submission.Values,
+					})
+					break
+				}
+			}
+		}
+
+		if page.Paging == nil || page.Paging.Next["after"] == "" {
+			return submissions, nil
+		}
+		after = page.Paging.Next["after"]
+	}
+}
+
+// attachmentIds returns the sorted ids of the files attached to the engagements
+func attachmentIds(engagements []Engagement) []string {
+	seen := map[string]bool{}
+	for _, engagement := range engagements {
+		for _, id := range strings.Split(engagement.Properties["hs_attachment_ids"], ";") {
+			id = strings.TrimSpace(id)
+			if id != "" {
+				seen[id] = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// WriteJSON writes the export to w as an indented JSON document
+func (export DataSubjectExport) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
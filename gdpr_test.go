@@ -0,0 +1,152 @@
+package go_hubspot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockGDPRAPI(mockClient *IHTTPClientMock) HubspotGDPRAPI {
+	return HubspotGDPRAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+func TestDeleteContactByEmail(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "POST https://api.hubapi.com/crm/v3/objects/contacts/gdpr-delete?hapikey=api_key" {
+				var request gdprDeleteRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := gdprDeleteRequest{ObjectId: "jane@example.com", IdProperty: "email"}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected GDPR delete request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				w.WriteHeader(204)
+			} else {
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockGDPRAPI(&mockHubspotHTTPClient)
+
+	err := api.DeleteContactByEmail("Jane@Example.com")
+	if err != nil {
+		t.Errorf("DeleteContactByEmail returned an error: %s", err.Error())
+	}
+}
+
+func TestExportContact(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) {
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/form-integrations/v1/submissions/forms/formId?hapikey=api_key&limit=50&after=" {
+				writeJSONResponse(t, w, 200, HubspotResponse{Results: []Submission{
+					{SubmittedAt: 1622505600000, Values: []FormValue{{"email", "JANE@example.com"}, {"firstname", "Jane"}}},
+					{SubmittedAt: 1622505600000, Values: []FormValue{{"email", "john@example.com"}}},
+				}})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+			return w.Result(), nil
+		},
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/properties/contacts?hapikey=api_key":
+				writeJSONResponse(t, w, 200, map[string]interface{}{
+					"results": []map[string]string{{"name": "email"}, {"name": "firstname"}},
+				})
+			case "POST https://api.hubapi.com/crm/v3/objects/contacts/batch/read?hapikey=api_key":
+				var request batchReadRequest
+				readJSONRequest(t, req, &request)
+
+				if !cmp.Equal([]string{"email", "firstname"}, request.Properties) {
+					t.Errorf("Expected all the contact properties to be read, got: %v", request.Properties)
+				}
+
+				writeJSONResponse(t, w, 200, batchResponse{Results: []HubSpotSearchResult{
+					{Id: "contactId", Properties: map[string]string{"email": "jane@example.com", "firstname": "Jane"}},
+				}})
+			case "GET https://api.hubapi.com/crm/v3/objects/contacts/contactId/associations/notes?hapikey=api_key&limit=500":
+				writeJSONResponse(t, w, 200, Associations{Results: []Association{{"noteId", "contact_to_note"}}})
+			case "POST https://api.hubapi.com/crm/v3/objects/notes/batch/read?hapikey=api_key":
+				writeJSONResponse(t, w, 200, batchResponse{Results: []HubSpotSearchResult{
+					{Id: "noteId", Properties: map[string]string{"hs_note_body": "CV attached", "hs_attachment_ids": "fileId"}},
+				}})
+			case "GET https://api.hubapi.com/crm/v3/objects/contacts/contactId/associations/deals?hapikey=api_key&limit=500":
+				writeJSONResponse(t, w, 200, Associations{Results: []Association{{"dealId", "contact_to_deal"}}})
+			case "GET https://api.hubapi.com/files/v3/files/fileId?hapikey=api_key":
+				writeJSONResponse(t, w, 200, DataSubjectFile{Id: "fileId", Name: "cv", Extension: "pdf"})
+			default:
+				if req.Method == "GET" {
+					writeJSONResponse(t, w, 200, Associations{Results: []Association{}})
+				} else {
+					t.Errorf("Unexpected request %s", url)
+				}
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockGDPRAPI(&mockHubspotHTTPClient)
+
+	export, err := api.ExportContact("contactId", []string{"formId"})
+	if err != nil {
+		t.Errorf("ExportContact returned an error: %s", err.Error())
+		return
+	}
+
+	if export.Properties["firstname"] != "Jane" {
+		t.Errorf("Unexpected exported properties: %v", export.Properties)
+	}
+
+	if len(export.FormSubmissions) != 1 || export.FormSubmissions[0].FormId != "formId" {
+		t.Errorf("Expected a single form submission, got: %v", export.FormSubmissions)
+	}
+
+	if len(export.Engagements) != 1 || export.Engagements[0].Body != "CV attached" {
+		t.Errorf("Expected a single note, got: %v", export.Engagements)
+	}
+
+	expectedAssociations := map[string][]string{"companies": {}, "deals": {"dealId"}, "tickets": {}}
+	if !cmp.Equal(expectedAssociations, export.Associations) {
+		t.Errorf("Unexpected associations, expected:\n%v\ngot:\n%v", expectedAssociations, export.Associations)
+	}
+
+	expectedFiles := []DataSubjectFile{{Id: "fileId", Name: "cv", Extension: "pdf"}}
+	if !cmp.Equal(expectedFiles, export.Files) {
+		t.Errorf("Unexpected files, expected:\n%v\ngot:\n%v", expectedFiles, export.Files)
+	}
+
+	var buffer bytes.Buffer
+	err = export.WriteJSON(&buffer)
+	if err != nil {
+		t.Errorf("WriteJSON returned an error: %s", err.Error())
+		return
+	}
+
+	var document map[string]interface{}
+	err = json.Unmarshal(buffer.Bytes(), &document)
+	if err != nil || document["contactId"] != "contactId" {
+		t.Errorf("WriteJSON wrote an invalid document: %s", buffer.String())
+	}
+}
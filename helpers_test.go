@@ -0,0 +1,43 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// writeJSONResponse writes a mock HubSpot response with the given status and JSON body
+func writeJSONResponse(t *testing.T, w *httptest.ResponseRecorder, status int, body interface{}) {
+	response, err := json.Marshal(body)
+	if err != nil {
+		t.Errorf("Error marshalling mock response: %s", err.Error())
+	}
+
+	w.WriteHeader(status)
+	_, err = w.Write(response)
+	if err != nil {
+		t.Errorf("Error writing response in mock: %s", err.Error())
+	}
+}
+
+// readJSONRequest unmarshals the JSON body of a request made to the mock client
+func readJSONRequest(t *testing.T, req *http.Request, request interface{}) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Errorf("Error reading mock request body: %s", err.Error())
+	}
+
+	err = json.Unmarshal(body, request)
+	if err != nil {
+		t.Errorf("Error unmarshalling mock request: %s", err.Error())
+	}
+}
+
+// batchResponse is a representation of the response to a batch request
+type batchResponse struct {
+	Status  string                `json:"status"`
+	Results []HubSpotSearchResult `json:"results"`
+	Errors  []BatchError          `json:"errors"`
+}
@@ -0,0 +1,142 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// PropertyHistoryValue is a single value in the history of a property
+type PropertyHistoryValue struct {
+	Value           string    `json:"value"`
+	Timestamp       time.Time `json:"timestamp"`
+	SourceType      string    `json:"sourceType"`
+	SourceId        string    `json:"sourceId"`
+	UpdatedByUserId int64     `json:"updatedByUserId"`
+}
+
+// objectWithHistory is a representation of an object fetched with propertiesWithHistory
+type objectWithHistory struct {
+	Id                    string                            `json:"id"`
+	Properties            map[string]string                 `json:"properties"`
+	PropertiesWithHistory map[string][]PropertyHistoryValue `json:"propertiesWithHistory"`
+}
+
+// getObjectWithHistory fetches an object with the history of the given properties, histories are ordered newest first
+func getObjectWithHistory(httpClient IHTTPClient, apiKey, objectType, objectId string, properties []string) (*objectWithHistory, error) {
+	requestUrl := fmt.Sprintf(
+		"https://api.hubapi.com/crm/v3/objects/%s/%s?propertiesWithHistory=%s&hapikey=%s",
+		objectType,
+		objectId,
+		url.QueryEscape(strings.Join(properties, ",")),
+		apiKey,
+	)
+
+	var object objectWithHistory
+	err := doJSONRequest(httpClient, "GET", requestUrl, nil, &object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &object, nil
+}
+
+// parseHubSpotTime parses a datetime property value, which is either an ISO 8601 string or milliseconds since the epoch
+func parseHubSpotTime(value string) (time.Time, error) {
+	return crm.ParseTime(value)
+}
+
+// formatHubSpotTime formats a time as milliseconds since the epoch, as expected by search filters on datetime properties
+func formatHubSpotTime(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano()/int64(time.Millisecond), 10)
+}
+
+// PropertyHistory is the history of selected properties of a CRM object, timelines are ordered from oldest to newest
+type PropertyHistory struct {
+	ObjectType string
+	ObjectId   string
+	Timelines  map[string][]PropertyHistoryValue
+}
+
+// PropertyChange is a property that had a different value at two points in time, a value is "" when the property was not set
+type PropertyChange struct {
+	Property string
+	OldValue string
+	NewValue string
+}
+
+// GetPropertyHistory returns the history of the given properties of an object
+func (api HubspotCRMAPI) GetPropertyHistory(objectType, objectId string, properties []string) (*PropertyHistory, error) {
+	object, err := getObjectWithHistory(api.httpClient, api.APIKey, objectType, objectId, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	history := PropertyHistory{
+		ObjectType: objectType,
+		ObjectId:   object.Id,
+		Timelines:  map[string][]PropertyHistoryValue{},
+	}
+	for _, property := range properties {
+		timeline := append([]PropertyHistoryValue{}, object.PropertiesWithHistory[property]...)
+		sort.SliceStable(timeline, func(i, j int) bool {
+			return timeline[i].Timestamp.Before(timeline[j].Timestamp)
+		})
+		history.Timelines[property] = timeline
+	}
+
+	return &history, nil
+}
+
+// ValueAt returns the value a property had at the given time, returns false if the property was not set yet
+func (history PropertyHistory) ValueAt(property string, at time.Time) (PropertyHistoryValue, bool) {
+	timeline := history.Timelines[property]
+	index := sort.Search(len(timeline), func(i int) bool {
+		return timeline[i].Timestamp.After(at)
+	})
+	if index == 0 {
+		return PropertyHistoryValue{}, false
+	}
+	return timeline[index-1], true
+}
+
+// StateAt returns the values the properties had at the given time, properties that were not set yet are omitted
+func (history PropertyHistory) StateAt(at time.Time) map[string]string {
+	state := map[string]string{}
+	for property := range history.Timelines {
+		if value, ok := history.ValueAt(property, at); ok {
+			state[property] = value.Value
+		}
+	}
+	return state
+}
+
+// Diff returns the properties whose value changed between from and to, ordered by property name
+func (history PropertyHistory) Diff(from, to time.Time) []PropertyChange {
+	before := history.StateAt(from)
+	after := history.StateAt(to)
+
+	properties := make([]string, 0, len(history.Timelines))
+	for property := range history.Timelines {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+
+	changes := []PropertyChange{}
+	for _, property := range properties {
+		if before[property] != after[property] {
+			changes = append(changes, PropertyChange{
+				Property: property,
+				OldValue: before[property],
+				NewValue: after[property],
+			})
+		}
+	}
+
+	return changes
+}
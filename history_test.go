@@ -0,0 +1,86 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGetPropertyHistory(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/companies/companyId?propertiesWithHistory=name%2Cdomain%2Cphone&hapikey=api_key" {
+				w.WriteHeader(200)
+				_, _ = w.Write([]byte(`{
+					"id": "companyId",
+					"properties": {"name": "Acme Ltd", "domain": "acme.com"},
+					"propertiesWithHistory": {
+						"name": [
+							{"value": "Acme Ltd", "timestamp": "2021-06-10T00:00:00Z", "sourceType": "CRM_UI", "sourceId": "userId:1", "updatedByUserId": 1},
+							{"value": "Acme", "timestamp": "2021-06-01T00:00:00Z", "sourceType": "FORM", "sourceId": "formId"}
+						],
+						"domain": [
+							{"value": "acme.com", "timestamp": "2021-06-05T00:00:00Z", "sourceType": "INTEGRATION"}
+						]
+					}
+				}`))
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockCRMAPI(&mockHubspotHTTPClient)
+
+	history, err := api.GetPropertyHistory("companies", "companyId", []string{"name", "domain", "phone"})
+	if err != nil {
+		t.Errorf("GetPropertyHistory returned an error: %s", err.Error())
+		return
+	}
+
+	expectedNameTimeline := []PropertyHistoryValue{
+		{Value: "Acme", Timestamp: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), SourceType: "FORM", SourceId: "formId"},
+		{Value: "Acme Ltd", Timestamp: time.Date(2021, 6, 10, 0, 0, 0, 0, time.UTC), SourceType: "CRM_UI", SourceId: "userId:1", UpdatedByUserId: 1},
+	}
+	if !cmp.Equal(expectedNameTimeline, history.Timelines["name"]) {
+		t.Errorf("Unexpected name timeline, expected:\n%v\ngot:\n%v", expectedNameTimeline, history.Timelines["name"])
+	}
+
+	if len(history.Timelines["phone"]) != 0 {
+		t.Errorf("Expected an empty phone timeline, got: %v", history.Timelines["phone"])
+	}
+
+	cases := []struct {
+		at       time.Time
+		expected map[string]string
+	}{
+		{time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC), map[string]string{}},
+		{time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC), map[string]string{"name": "Acme"}},
+		{time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC), map[string]string{"name": "Acme", "domain": "acme.com"}},
+		{time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC), map[string]string{"name": "Acme Ltd", "domain": "acme.com"}},
+	}
+	for _, c := range cases {
+		if state := history.StateAt(c.at); !cmp.Equal(c.expected, state) {
+			t.Errorf("Unexpected state at %s, expected:\n%v\ngot:\n%v", c.at, c.expected, state)
+		}
+	}
+
+	changes := history.Diff(time.Date(2021, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC))
+	expectedChanges := []PropertyChange{
+		{Property: "domain", OldValue: "", NewValue: "acme.com"},
+		{Property: "name", OldValue: "Acme", NewValue: "Acme Ltd"},
+	}
+	if !cmp.Equal(expectedChanges, changes) {
+		t.Errorf("Unexpected changes, expected:\n%v\ngot:\n%v", expectedChanges, changes)
+	}
+}
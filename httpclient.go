@@ -1,6 +1,13 @@
 package go_hubspot
 
-import "net/http"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
 
 // IHTTPClient HTTP client interface to be used with HubSpot API clients
 type IHTTPClient interface {
@@ -21,3 +28,66 @@ func (c HTTPClient) Do(req *http.Request) (resp *http.Response, err error) {
 	client := http.Client{}
 	return client.Do(req)
 }
+
+// HubSpotAPIError is returned when HubSpot responds with an unsuccessful status code
+type HubSpotAPIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e HubSpotAPIError) Error() string {
+	return fmt.Sprintf("HubSpot API responded with status %d: %s", e.StatusCode, e.Body)
+}
+
+// doJSONRequest makes a request with an optional JSON payload to the HubSpot API,
+// and unmarshals a successful response into result, unless result is nil
+func doJSONRequest(client IHTTPClient, method, url string, payload interface{}, result interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		payloadBuf := new(bytes.Buffer)
+		err := json.NewEncoder(payloadBuf).Encode(payload)
+		if err != nil {
+			return err
+		}
+		body = payloadBuf
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return doRequest(client, req, result)
+}
+
+// doRequest makes a request to the HubSpot API, and unmarshals a successful JSON response into result, unless result is nil
+func doRequest(client IHTTPClient, req *http.Request, result interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return HubSpotAPIError{
+			StatusCode: resp.StatusCode,
+			Body:       string(respBody),
+		}
+	}
+
+	if result == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, result)
+}
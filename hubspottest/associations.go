@@ -0,0 +1,274 @@
+package hubspottest
+
+import (
+	"fmt"
+	"net/http"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// associationKey identifies the associations of an object with the objects of another type, object types are singular
+type associationKey struct {
+	fromObjectType string
+	fromId         string
+	toObjectType   string
+}
+
+func newAssociationKey(fromObjectType, fromId, toObjectType string) associationKey {
+	return associationKey{crm.SingularObjectType(fromObjectType), fromId, crm.SingularObjectType(toObjectType)}
+}
+
+type associationBatchRequest struct {
+	Inputs []hubspot.DealAssociation `json:"inputs"`
+}
+
+type associationBatchReadRequest struct {
+	Inputs []hubspot.DealAssociationFromTo `json:"inputs"`
+}
+
+type associationBatchResult struct {
+	From hubspot.DealAssociationFromTo `json:"from"`
+	To   []hubspot.Association         `json:"to"`
+}
+
+type associationBatchResponse struct {
+	Status  string                   `json:"status"`
+	Results []associationBatchResult `json:"results"`
+	Errors  []hubspot.BatchError     `json:"errors,omitempty"`
+}
+
+// Associate associates two objects with the default association label of their types, e.g. "deal_to_company"
+func (s *Server) Associate(fromObjectType, fromId, toObjectType, toId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.associate(fromObjectType, fromId, toObjectType, toId, defaultLabel(fromObjectType, toObjectType))
+}
+
+// Associated returns the ids of the objects of toObjectType associated with an object, in the order they were associated
+func (s *Server) Associated(fromObjectType, fromId, toObjectType string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := []string{}
+	for _, association := range s.associations[newAssociationKey(fromObjectType, fromId, toObjectType)] {
+		ids = append(ids, association.Id)
+	}
+	return ids
+}
+
+// defaultLabel returns the default association label between two object types
+func defaultLabel(fromObjectType, toObjectType string) string {
+	return crm.SingularObjectType(fromObjectType) + "_to_" + crm.SingularObjectType(toObjectType)
+}
+
+// associate associates two objects both ways, as HubSpot does, the reverse association has the default label
+func (s *Server) associate(fromObjectType, fromId, toObjectType, toId, label string) {
+	s.addAssociation(newAssociationKey(fromObjectType, fromId, toObjectType), hubspot.Association{Id: toId, AssociationType: label})
+	s.addAssociation(newAssociationKey(toObjectType, toId, fromObjectType), hubspot.Association{Id: fromId, AssociationType: defaultLabel(toObjectType, fromObjectType)})
+}
+
+func (s *Server) addAssociation(key associationKey, association hubspot.Association) {
+	for _, existing := range s.associations[key] {
+		if existing == association {
+			return
+		}
+	}
+	s.associations[key] = append(s.associations[key], association)
+}
+
+// dissociate removes the associations between two objects, both ways
+func (s *Server) dissociate(fromObjectType, fromId, toObjectType, toId string) {
+	s.removeAssociations(newAssociationKey(fromObjectType, fromId, toObjectType), toId)
+	s.removeAssociations(newAssociationKey(toObjectType, toId, fromObjectType), fromId)
+}
+
+func (s *Server) removeAssociations(key associationKey, id string) {
+	kept := []hubspot.Association{}
+	for _, association := range s.associations[key] {
+		if association.Id != id {
+			kept = append(kept, association)
+		}
+	}
+	s.associations[key] = kept
+}
+
+// associationsOf returns the associations of an object with the objects of toObjectType that are not archived
+func (s *Server) associationsOf(fromObjectType, fromId, toObjectType string) []hubspot.Association {
+	associations := []hubspot.Association{}
+	for _, association := range s.associations[newAssociationKey(fromObjectType, fromId, toObjectType)] {
+		if _, ok := s.liveObject(toObjectType, association.Id); ok {
+			associations = append(associations, association)
+		}
+	}
+	return associations
+}
+
+// checkAssociation returns an error if either of the objects to associate does not exist
+func (s *Server) checkAssociation(fromObjectType, fromId, toObjectType, toId string) error {
+	if _, ok := s.liveObject(fromObjectType, fromId); !ok {
+		return fmt.Errorf("%s %s does not exist", crm.SingularObjectType(fromObjectType), fromId)
+	}
+	if _, ok := s.liveObject(toObjectType, toId); !ok {
+		return fmt.Errorf("%s %s does not exist", crm.SingularObjectType(toObjectType), toId)
+	}
+	return nil
+}
+
+func (s *Server) getAssociations(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType, id, toObjectType := params[0], params[1], params[2]
+
+	if _, ok := s.liveObject(objectType, id); !ok {
+		writeError(w, 404, "OBJECT_NOT_FOUND", "resource not found")
+		return
+	}
+
+	writeJSON(w, 200, hubspot.Associations{Results: s.associationsOf(objectType, id, toObjectType)})
+}
+
+func (s *Server) putAssociation(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType, id, toObjectType, toId, label := params[0], params[1], params[2], params[3], params[4]
+
+	if err := s.checkAssociation(objectType, id, toObjectType, toId); err != nil {
+		writeError(w, 404, "OBJECT_NOT_FOUND", err.Error())
+		return
+	}
+
+	s.associate(objectType, id, toObjectType, toId, label)
+
+	object, _ := s.liveObject(objectType, id)
+	writeJSON(w, 200, s.toJSON(crm.PluralObjectType(objectType), object, nil, []string{toObjectType}))
+}
+
+func (s *Server) deleteAssociation(w http.ResponseWriter, r *http.Request, params []string) {
+	s.dissociate(params[0], params[1], params[2], params[3])
+
+	w.WriteHeader(204)
+}
+
+// batchCreateAssociations associates objects, nothing is associated if any of the objects does not exist
+func (s *Server) batchCreateAssociations(w http.ResponseWriter, r *http.Request, params []string) {
+	fromObjectType, toObjectType := params[0], params[1]
+
+	var request associationBatchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	for _, input := range request.Inputs {
+		if err := s.checkAssociation(fromObjectType, input.From.Id, toObjectType, input.To.Id); err != nil {
+			writeError(w, 400, "VALIDATION_ERROR", err.Error())
+			return
+		}
+	}
+
+	results := []hubspot.DealAssociation{}
+	for _, input := range request.Inputs {
+		label := input.Type
+		if label == "" {
+			label = defaultLabel(fromObjectType, toObjectType)
+		}
+		s.associate(fromObjectType, input.From.Id, toObjectType, input.To.Id, label)
+		results = append(results, hubspot.DealAssociation{From: input.From, To: input.To, Type: label})
+	}
+
+	writeJSON(w, 201, map[string]interface{}{"status": "COMPLETE", "results": results})
+}
+
+type typedAssociationBatchRequest struct {
+	Inputs []struct {
+		From hubspot.DealAssociationFromTo `json:"from"`
+		inlineAssociationJSON
+	} `json:"inputs"`
+}
+
+// batchCreateTypedAssociations associates objects by association type id, as the v4 associations API does,
+// nothing is associated if any of the objects or association types does not exist
+func (s *Server) batchCreateTypedAssociations(w http.ResponseWriter, r *http.Request, params []string) {
+	fromObjectType, toObjectType := params[0], params[1]
+
+	var request typedAssociationBatchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	associations := []hubspot.DealAssociation{}
+	for _, input := range request.Inputs {
+		if err := s.checkAssociation(fromObjectType, input.From.Id, toObjectType, input.To.Id); err != nil {
+			writeError(w, 400, "VALIDATION_ERROR", err.Error())
+			return
+		}
+
+		resolved, toObjectTypes, err := s.inlineAssociations(fromObjectType, []inlineAssociationJSON{input.inlineAssociationJSON})
+		if err != nil {
+			writeError(w, 400, "VALIDATION_ERROR", err.Error())
+			return
+		}
+		for i, association := range resolved {
+			if toObjectTypes[i] != crm.SingularObjectType(toObjectType) {
+				writeError(w, 400, "VALIDATION_ERROR", fmt.Sprintf("Association type %s cannot be used to %s", association.Type, toObjectType))
+				return
+			}
+			association.From = input.From
+			associations = append(associations, association)
+		}
+	}
+
+	for _, association := range associations {
+		s.associate(fromObjectType, association.From.Id, toObjectType, association.To.Id, association.Type)
+	}
+
+	writeJSON(w, 201, map[string]interface{}{"status": "COMPLETE", "results": associations})
+}
+
+// batchReadAssociations returns the associations of objects, with status 207 when some of the objects have none
+func (s *Server) batchReadAssociations(w http.ResponseWriter, r *http.Request, params []string) {
+	fromObjectType, toObjectType := params[0], params[1]
+
+	var request associationBatchReadRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	response := associationBatchResponse{Status: "COMPLETE", Results: []associationBatchResult{}}
+	missing := []string{}
+	for _, input := range request.Inputs {
+		associations := s.associationsOf(fromObjectType, input.Id, toObjectType)
+		if len(associations) == 0 {
+			missing = append(missing, input.Id)
+			continue
+		}
+		response.Results = append(response.Results, associationBatchResult{From: input, To: associations})
+	}
+
+	status := 200
+	if len(missing) > 0 {
+		response.Errors = []hubspot.BatchError{{
+			Status:   "error",
+			Category: "OBJECT_NOT_FOUND",
+			Message:  fmt.Sprintf("No %s is associated", crm.SingularObjectType(toObjectType)),
+			Context:  map[string][]string{"fromIds": missing},
+		}}
+		status = 207
+	}
+
+	writeJSON(w, status, response)
+}
+
+func (s *Server) batchArchiveAssociations(w http.ResponseWriter, r *http.Request, params []string) {
+	var request associationBatchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	for _, input := range request.Inputs {
+		s.dissociate(params[0], input.From.Id, params[1], input.To.Id)
+	}
+
+	w.WriteHeader(204)
+}
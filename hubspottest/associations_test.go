@@ -0,0 +1,122 @@
+package hubspottest
+
+import (
+	"errors"
+	"testing"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAssociationRollback(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	companyId := server.AddObject("companies", map[string]string{"name": "Acme"})
+	api := hubspot.NewHubspotDealFlowAPIWithClient("api_key", server.Client())
+
+	// Inline associations to missing objects fail the creation
+	_, err := api.CreateDeal(hubspot.DealCreateRequest{
+		Name:         "Acme renewal",
+		Associations: []hubspot.DealCreateAssociation{{ObjectType: "contacts", Id: "404"}},
+	})
+	var apiErr hubspot.HubSpotAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 400 {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+	if len(server.Objects("deals")) != 0 {
+		t.Errorf("Unexpected deals %v", server.Objects("deals"))
+	}
+
+	// Associations with custom labels are made after the creation, the deal is archived when they fail
+	_, err = api.CreateDeal(hubspot.DealCreateRequest{
+		Name: "Acme renewal",
+		Associations: []hubspot.DealCreateAssociation{
+			{ObjectType: "companies", Id: companyId, Label: "reseller"},
+			{ObjectType: "contacts", Id: "404", Label: "champion"},
+		},
+	})
+	if err == nil {
+		t.Fatalf("Expected an association error")
+	}
+
+	deals := server.Objects("deals")
+	if len(deals) != 1 || !deals[0].Archived {
+		t.Fatalf("Unexpected deals %v", deals)
+	}
+	if len(server.Associated("companies", companyId, "deals")) != 1 {
+		t.Errorf("Unexpected associations %v", server.Associated("companies", companyId, "deals"))
+	}
+
+	// Associations of archived objects are not returned
+	lineItems, err := api.GetDealLineItems(deals[0].Id)
+	if err == nil {
+		t.Errorf("Expected an error reading the associations of an archived deal, got %v", lineItems)
+	}
+}
+
+func TestMixedAssociations(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	companyId := server.AddObject("companies", map[string]string{"name": "Acme"})
+	contactId := server.AddObject("contacts", map[string]string{"email": "alice@acme.com"})
+	api := hubspot.NewHubspotDealFlowAPIWithClient("api_key", server.Client())
+
+	// Associations by type id are made with the v4 API when others can only be made by label
+	deal, err := api.CreateDeal(hubspot.DealCreateRequest{
+		Name: "Acme renewal",
+		Associations: []hubspot.DealCreateAssociation{
+			{ObjectType: "companies", Id: companyId, TypeId: 5},
+			{ObjectType: "contacts", Id: contactId, Label: "champion"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if !cmp.Equal([]string{companyId}, server.Associated("deals", deal.Id, "companies")) {
+		t.Errorf("Unexpected company associations %v", server.Associated("deals", deal.Id, "companies"))
+	}
+	if !cmp.Equal([]string{contactId}, server.Associated("deals", deal.Id, "contacts")) {
+		t.Errorf("Unexpected contact associations %v", server.Associated("deals", deal.Id, "contacts"))
+	}
+}
+
+func TestMirrorAssociations(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	acmeId := server.AddObject("companies", map[string]string{"name": "Acme"})
+	globexId := server.AddObject("companies", map[string]string{"name": "Globex"})
+	aliceId := server.AddObject("contacts", map[string]string{"email": "alice@acme.com"})
+	bobId := server.AddObject("contacts", map[string]string{"email": "bob@acme.com"})
+	server.Associate("contacts", aliceId, "companies", acmeId)
+	server.Associate("contacts", bobId, "companies", acmeId)
+
+	api := hubspot.NewHubspotCRMAPIWithClient("api_key", server.Client())
+	mirror := api.Mirror(hubspot.NewMemoryMirrorStore(), hubspot.NewMemoryCheckpointStore(), hubspot.MirrorObject{
+		ObjectType:   "companies",
+		Properties:   []string{"name"},
+		Associations: []string{"contacts"},
+	})
+
+	err := mirror.Load()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	acme, _ := mirror.Get("companies", acmeId)
+	if acme == nil || !cmp.Equal(map[string][]string{"contacts": {aliceId, bobId}}, acme.Associations) {
+		t.Errorf("Unexpected record %v", acme)
+	}
+	globex, _ := mirror.Get("companies", globexId)
+	if globex == nil || !cmp.Equal(map[string][]string{"contacts": {}}, globex.Associations) {
+		t.Errorf("Unexpected record %v", globex)
+	}
+
+	companyId, err := api.GetCompanyForContact(bobId)
+	if err != nil || companyId != acmeId {
+		t.Errorf("Unexpected company %s, %v", companyId, err)
+	}
+}
@@ -0,0 +1,105 @@
+package hubspottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+)
+
+// maxUploadSize is the maximum size of the uploads the server keeps in memory while parsing them
+const maxUploadSize = 32 << 20
+
+// File is a file uploaded to the fake server
+type File struct {
+	Id         string
+	Name       string
+	FolderPath string
+	Content    []byte
+	Options    hubspot.FileUploadOptions
+}
+
+type fileJSON struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Size   int    `json:"size"`
+	Access string `json:"access"`
+	URL    string `json:"url"`
+}
+
+// Files returns the files uploaded to the server, in the order they were uploaded
+func (s *Server) Files() []File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files := make([]File, len(s.files))
+	copy(files, s.files)
+	return files
+}
+
+// uploadFile stores a file uploaded as a multipart form, a file with the same name in the same folder
+// is replaced, keeping its id, when the upload options allow overwriting
+func (s *Server) uploadFile(w http.ResponseWriter, r *http.Request, params []string) {
+	err := r.ParseMultipartForm(maxUploadSize)
+	if err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	part, header, err := r.FormFile("file")
+	if err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", fmt.Sprintf("The file is missing: %s", err.Error()))
+		return
+	}
+	defer part.Close()
+
+	content, err := ioutil.ReadAll(part)
+	if err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	file := File{
+		Name:       header.Filename,
+		FolderPath: r.FormValue("folderPath"),
+		Content:    content,
+	}
+	if options := r.FormValue("options"); options != "" {
+		err = json.Unmarshal([]byte(options), &file.Options)
+		if err != nil {
+			writeError(w, 400, "VALIDATION_ERROR", fmt.Sprintf("Invalid options: %s", err.Error()))
+			return
+		}
+	}
+
+	replaced := false
+	for i, existing := range s.files {
+		if existing.FolderPath == file.FolderPath && existing.Name == file.Name {
+			if !file.Options.Overwrite {
+				writeError(w, 409, "CONFLICT", fmt.Sprintf("A file named %s already exists in %s", file.Name, file.FolderPath))
+				return
+			}
+			file.Id = existing.Id
+			s.files[i] = file
+			replaced = true
+		}
+	}
+	if !replaced {
+		file.Id = s.nextId()
+		s.files = append(s.files, file)
+	}
+
+	path := strings.TrimSuffix(file.FolderPath, "/") + "/" + file.Name
+	writeJSON(w, 201, fileJSON{
+		Id:     file.Id,
+		Name:   file.Name,
+		Path:   path,
+		Size:   len(file.Content),
+		Access: file.Options.Access,
+		URL:    s.URL + "/hubfs" + path,
+	})
+}
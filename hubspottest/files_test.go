@@ -0,0 +1,38 @@
+package hubspottest
+
+import (
+	"testing"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+)
+
+func TestFileUpload(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	api := hubspot.NewHubspotFileAPIWithClient("api_key", "portal_id", server.Client())
+
+	url, err := api.UploadFile([]byte("first"), "/invoices", "invoice.pdf")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	files := server.Files()
+	if len(files) != 1 || string(files[0].Content) != "first" || files[0].FolderPath != "/invoices" || files[0].Options.Access != "PUBLIC_NOT_INDEXABLE" {
+		t.Fatalf("Unexpected files %v", files)
+	}
+	if url != "https://app.hubspot.com/file-preview/portal_id/file/"+files[0].Id {
+		t.Errorf("Unexpected URL %s", url)
+	}
+
+	// The upload options overwrite files with the same name
+	overwrittenURL, err := api.UploadFile([]byte("second"), "/invoices", "invoice.pdf")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	files = server.Files()
+	if len(files) != 1 || string(files[0].Content) != "second" || overwrittenURL != url {
+		t.Errorf("Unexpected files %v", files)
+	}
+}
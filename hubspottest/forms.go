@@ -0,0 +1,32 @@
+package hubspottest
+
+import (
+	"net/http"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+)
+
+// AddFormSubmission adds a submission of the form with the given id, submissions are listed in the order they were added
+func (s *Server) AddFormSubmission(formId string, submission hubspot.Submission) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.submissions[formId] = append(s.submissions[formId], submission)
+}
+
+func (s *Server) listSubmissions(w http.ResponseWriter, r *http.Request, params []string) {
+	submissions := s.submissions[params[0]]
+	query := r.URL.Query()
+
+	offset, limit := pageParams(query.Get("after"), query.Get("limit"), 20)
+	if limit > 50 {
+		limit = 50
+	}
+
+	response := hubspot.HubspotResponse{Results: []hubspot.Submission{}, Paging: nextPage(offset, limit, len(submissions))}
+	for i := offset; i < offset+limit && i < len(submissions); i++ {
+		response.Results = append(response.Results, submissions[i])
+	}
+
+	writeJSON(w, 200, response)
+}
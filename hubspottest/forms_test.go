@@ -0,0 +1,48 @@
+package hubspottest
+
+import (
+	"fmt"
+	"testing"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+)
+
+func TestFormSubmissions(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	for i := 0; i < 60; i++ {
+		server.AddFormSubmission("form_id", hubspot.Submission{
+			SubmittedAt: int64(i),
+			Values: []hubspot.FormValue{
+				{Name: "email", Value: fmt.Sprintf("user%d@example.com", i)},
+				{Name: "interest", Value: "pricing"},
+				{Name: "interest", Value: "support"},
+			},
+		})
+	}
+
+	api := hubspot.NewHubspotFormAPIWithClient("form_id", "api_key", server.Client())
+
+	page, err := api.Query("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(page.Results) != 50 || page.Paging == nil {
+		t.Errorf("Unexpected first page of %d submissions, paging %v", len(page.Results), page.Paging)
+	}
+
+	// The submission is on the second page
+	submission, err := api.SearchForKeyValue("email", "user55@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(submission["interest"].ForceMultipleValues()) != 2 {
+		t.Errorf("Unexpected submission %v", submission)
+	}
+
+	_, err = api.SearchForKeyValue("email", "nobody@example.com")
+	if err == nil {
+		t.Errorf("Expected an error for a missing submission")
+	}
+}
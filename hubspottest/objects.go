@@ -0,0 +1,526 @@
+package hubspottest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// hubspotTimeFormat is the format of the times HubSpot returns
+const hubspotTimeFormat = "2006-01-02T15:04:05.000Z"
+
+// Object is a CRM object stored by the fake server
+// Properties include the properties HubSpot maintains, "hs_object_id", "createdate" and the last modification date.
+type Object struct {
+	Id         string
+	Properties map[string]string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Archived   bool
+	ArchivedAt time.Time
+}
+
+func (o *Object) copy() Object {
+	c := *o
+	c.Properties = map[string]string{}
+	for name, value := range o.Properties {
+		c.Properties[name] = value
+	}
+	return c
+}
+
+// objectJSON is a representation of an object in the responses of the objects API
+type objectJSON struct {
+	Id           string                          `json:"id"`
+	Properties   map[string]string               `json:"properties"`
+	CreatedAt    string                          `json:"createdAt"`
+	UpdatedAt    string                          `json:"updatedAt"`
+	Archived     bool                            `json:"archived"`
+	ArchivedAt   string                          `json:"archivedAt,omitempty"`
+	Associations map[string]hubspot.Associations `json:"associations,omitempty"`
+}
+
+type inlineAssociationJSON struct {
+	To struct {
+		Id string `json:"id"`
+	} `json:"to"`
+	Types []struct {
+		AssociationCategory string `json:"associationCategory"`
+		AssociationTypeId   int    `json:"associationTypeId"`
+	} `json:"types"`
+}
+
+type objectInput struct {
+	Id           string                  `json:"id"`
+	Properties   map[string]string       `json:"properties"`
+	Associations []inlineAssociationJSON `json:"associations"`
+}
+
+type batchRequest struct {
+	Properties []string      `json:"properties"`
+	Inputs     []objectInput `json:"inputs"`
+}
+
+type batchResponse struct {
+	Status  string               `json:"status"`
+	Results []objectJSON         `json:"results"`
+	Errors  []hubspot.BatchError `json:"errors,omitempty"`
+}
+
+type listResponse struct {
+	Results []objectJSON    `json:"results"`
+	Paging  *hubspot.Paging `json:"paging,omitempty"`
+}
+
+type filterGroup struct {
+	Filters []hubspot.SearchFilter `json:"filters"`
+}
+
+type searchRequest struct {
+	FilterGroups []filterGroup        `json:"filterGroups"`
+	Sorts        []hubspot.SearchSort `json:"sorts"`
+	Properties   []string             `json:"properties"`
+	Limit        int                  `json:"limit"`
+	After        string               `json:"after"`
+}
+
+type searchResponse struct {
+	Total   int             `json:"total"`
+	Results []objectJSON    `json:"results"`
+	Paging  *hubspot.Paging `json:"paging,omitempty"`
+}
+
+// AddObject adds an object of the given type with the given properties, and returns its id
+func (s *Server) AddObject(objectType string, properties map[string]string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.newObject(crm.PluralObjectType(objectType), properties).Id
+}
+
+// Object returns a copy of the object of the given type with the given id, returns false if there is no such object
+// Archived objects are returned too, with Archived set.
+func (s *Server) Object(objectType, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.objects[crm.PluralObjectType(objectType)][id]
+	if !ok {
+		return Object{}, false
+	}
+	return object.copy(), true
+}
+
+// Objects returns copies of all the objects of the given type, archived ones included, ordered by id
+func (s *Server) Objects(objectType string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objects := []Object{}
+	for _, object := range s.sortedObjects(crm.PluralObjectType(objectType)) {
+		objects = append(objects, object.copy())
+	}
+	return objects
+}
+
+// sortedObjects returns the objects of a type ordered by id
+func (s *Server) sortedObjects(objectType string) []*Object {
+	ids := []string{}
+	for id := range s.objects[objectType] {
+		ids = append(ids, id)
+	}
+	sortIds(ids)
+
+	objects := make([]*Object, len(ids))
+	for i, id := range ids {
+		objects[i] = s.objects[objectType][id]
+	}
+	return objects
+}
+
+// liveObject returns the object of a type with the given id unless it does not exist or is archived
+func (s *Server) liveObject(objectType, id string) (*Object, bool) {
+	object, ok := s.objects[crm.PluralObjectType(objectType)][id]
+	if !ok || object.Archived {
+		return nil, false
+	}
+	return object, true
+}
+
+func (s *Server) newObject(objectType string, properties map[string]string) *Object {
+	now := s.now().UTC()
+	object := &Object{
+		Id:         s.nextId(),
+		Properties: map[string]string{},
+		CreatedAt:  now,
+	}
+	for name, value := range properties {
+		object.Properties[name] = value
+	}
+	object.Properties["hs_object_id"] = object.Id
+	object.Properties["createdate"] = now.Format(hubspotTimeFormat)
+	s.touch(objectType, object)
+
+	if s.objects[objectType] == nil {
+		s.objects[objectType] = map[string]*Object{}
+	}
+	s.objects[objectType][object.Id] = object
+
+	return object
+}
+
+// touch sets the modification time of an object to now
+func (s *Server) touch(objectType string, object *Object) {
+	object.UpdatedAt = s.now().UTC()
+	object.Properties[crm.LastModifiedProperty(objectType)] = object.UpdatedAt.Format(hubspotTimeFormat)
+}
+
+func (s *Server) updateProperties(objectType string, object *Object, properties map[string]string) {
+	for name, value := range properties {
+		object.Properties[name] = value
+	}
+	s.touch(objectType, object)
+}
+
+// toJSON returns the representation of an object with the requested properties and associations,
+// all the properties are returned when none are requested
+func (s *Server) toJSON(objectType string, object *Object, properties []string, associations []string) objectJSON {
+	result := objectJSON{
+		Id:         object.Id,
+		Properties: map[string]string{},
+		CreatedAt:  object.CreatedAt.Format(hubspotTimeFormat),
+		UpdatedAt:  object.UpdatedAt.Format(hubspotTimeFormat),
+		Archived:   object.Archived,
+	}
+	if object.Archived {
+		result.ArchivedAt = object.ArchivedAt.Format(hubspotTimeFormat)
+	}
+
+	if len(properties) == 0 {
+		for name, value := range object.Properties {
+			result.Properties[name] = value
+		}
+	} else {
+		names := append([]string{"hs_object_id", "createdate", crm.LastModifiedProperty(objectType)}, properties...)
+		for _, name := range names {
+			if value, ok := object.Properties[name]; ok {
+				result.Properties[name] = value
+			}
+		}
+	}
+
+	for _, toObjectType := range associations {
+		if result.Associations == nil {
+			result.Associations = map[string]hubspot.Associations{}
+		}
+		result.Associations[toObjectType] = hubspot.Associations{Results: s.associationsOf(objectType, object.Id, toObjectType)}
+	}
+
+	return result
+}
+
+// splitList splits a comma separated query parameter
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+func (s *Server) listObjects(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType := params[0]
+	query := r.URL.Query()
+	archived := query.Get("archived") == "true"
+
+	objects := []*Object{}
+	for _, object := range s.sortedObjects(objectType) {
+		if object.Archived == archived {
+			objects = append(objects, object)
+		}
+	}
+
+	offset, limit := pageParams(query.Get("after"), query.Get("limit"), 10)
+	if limit > 100 {
+		limit = 100
+	}
+
+	response := listResponse{Results: []objectJSON{}, Paging: nextPage(offset, limit, len(objects))}
+	for i := offset; i < offset+limit && i < len(objects); i++ {
+		response.Results = append(response.Results, s.toJSON(objectType, objects[i], splitList(query.Get("properties")), splitList(query.Get("associations"))))
+	}
+
+	writeJSON(w, 200, response)
+}
+
+// inlineAssociations resolves the associations sent along with the creation of an object,
+// returns an error if the association type or the associated object is not known
+func (s *Server) inlineAssociations(objectType string, inputs []inlineAssociationJSON) ([]hubspot.DealAssociation, []string, error) {
+	associations := []hubspot.DealAssociation{}
+	toObjectTypes := []string{}
+	for _, input := range inputs {
+		for _, associationType := range input.Types {
+			label, ok := hubspot.HubSpotDefinedAssociationLabel(associationType.AssociationTypeId)
+			if associationType.AssociationCategory != "HUBSPOT_DEFINED" || !ok {
+				return nil, nil, fmt.Errorf("Association type %d is not known to the fake HubSpot server", associationType.AssociationTypeId)
+			}
+
+			parts := strings.SplitN(label, "_to_", 2)
+			if parts[0] != crm.SingularObjectType(objectType) {
+				return nil, nil, fmt.Errorf("Association type %s cannot be used from %s", label, objectType)
+			}
+			if _, ok := s.liveObject(parts[1], input.To.Id); !ok {
+				return nil, nil, fmt.Errorf("%s %s does not exist", parts[1], input.To.Id)
+			}
+
+			associations = append(associations, hubspot.DealAssociation{To: hubspot.DealAssociationFromTo{Id: input.To.Id}, Type: label})
+			toObjectTypes = append(toObjectTypes, parts[1])
+		}
+	}
+
+	return associations, toObjectTypes, nil
+}
+
+// createWithAssociations creates an object and its inline associations, nothing is created if any association is invalid
+func (s *Server) createWithAssociations(objectType string, input objectInput) (*Object, error) {
+	associations, toObjectTypes, err := s.inlineAssociations(objectType, input.Associations)
+	if err != nil {
+		return nil, err
+	}
+
+	object := s.newObject(objectType, input.Properties)
+	for i, association := range associations {
+		s.associate(objectType, object.Id, toObjectTypes[i], association.To.Id, association.Type)
+	}
+
+	return object, nil
+}
+
+func (s *Server) createObject(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType := params[0]
+
+	var input objectInput
+	if err := readJSON(r, &input); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	object, err := s.createWithAssociations(objectType, input)
+	if err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	writeJSON(w, 201, s.toJSON(objectType, object, nil, nil))
+}
+
+func (s *Server) getObject(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType, id := params[0], params[1]
+	query := r.URL.Query()
+
+	object, ok := s.objects[objectType][id]
+	if !ok || object.Archived != (query.Get("archived") == "true") {
+		writeError(w, 404, "OBJECT_NOT_FOUND", "resource not found")
+		return
+	}
+
+	writeJSON(w, 200, s.toJSON(objectType, object, splitList(query.Get("properties")), splitList(query.Get("associations"))))
+}
+
+func (s *Server) updateObject(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType, id := params[0], params[1]
+
+	var input objectInput
+	if err := readJSON(r, &input); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	object, ok := s.liveObject(objectType, id)
+	if !ok {
+		writeError(w, 404, "OBJECT_NOT_FOUND", "resource not found")
+		return
+	}
+
+	s.updateProperties(objectType, object, input.Properties)
+	writeJSON(w, 200, s.toJSON(objectType, object, nil, nil))
+}
+
+// archive archives an object, archived objects are kept but only returned when archived objects are requested
+func (s *Server) archive(object *Object) {
+	object.Archived = true
+	object.ArchivedAt = s.now().UTC()
+}
+
+func (s *Server) archiveObject(w http.ResponseWriter, r *http.Request, params []string) {
+	if object, ok := s.liveObject(params[0], params[1]); ok {
+		s.archive(object)
+	}
+
+	w.WriteHeader(204)
+}
+
+// notFoundError returns the batch error HubSpot reports for the ids of objects that do not exist
+func notFoundError(objectType string, ids []string) hubspot.BatchError {
+	return hubspot.BatchError{
+		Status:   "error",
+		Category: "OBJECT_NOT_FOUND",
+		Message:  fmt.Sprintf("Could not get some %s objects, they may be deleted or not exist. Check that ids are valid.", crm.SingularObjectType(objectType)),
+		Context:  map[string][]string{"ids": ids},
+	}
+}
+
+// writeBatchResponse writes the response to a batch request, with status 207 when some of the inputs failed
+func writeBatchResponse(w http.ResponseWriter, status int, results []objectJSON, missing []string, objectType string) {
+	response := batchResponse{Status: "COMPLETE", Results: results}
+	if len(missing) > 0 {
+		response.Errors = []hubspot.BatchError{notFoundError(objectType, missing)}
+		status = 207
+	}
+
+	writeJSON(w, status, response)
+}
+
+func (s *Server) batchRead(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType := params[0]
+	archived := r.URL.Query().Get("archived") == "true"
+
+	var request batchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	results := []objectJSON{}
+	missing := []string{}
+	for _, input := range request.Inputs {
+		object, ok := s.objects[objectType][input.Id]
+		if !ok || object.Archived != archived {
+			missing = append(missing, input.Id)
+			continue
+		}
+		results = append(results, s.toJSON(objectType, object, request.Properties, nil))
+	}
+
+	writeBatchResponse(w, 200, results, missing, objectType)
+}
+
+func (s *Server) batchCreate(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType := params[0]
+
+	var request batchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	for _, input := range request.Inputs {
+		if _, _, err := s.inlineAssociations(objectType, input.Associations); err != nil {
+			writeError(w, 400, "VALIDATION_ERROR", err.Error())
+			return
+		}
+	}
+
+	results := []objectJSON{}
+	for _, input := range request.Inputs {
+		object, _ := s.createWithAssociations(objectType, input)
+		results = append(results, s.toJSON(objectType, object, nil, nil))
+	}
+
+	writeBatchResponse(w, 201, results, nil, objectType)
+}
+
+func (s *Server) batchUpdate(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType := params[0]
+
+	var request batchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	results := []objectJSON{}
+	missing := []string{}
+	for _, input := range request.Inputs {
+		object, ok := s.liveObject(objectType, input.Id)
+		if !ok {
+			missing = append(missing, input.Id)
+			continue
+		}
+		s.updateProperties(objectType, object, input.Properties)
+		results = append(results, s.toJSON(objectType, object, nil, nil))
+	}
+
+	writeBatchResponse(w, 200, results, missing, objectType)
+}
+
+func (s *Server) batchArchive(w http.ResponseWriter, r *http.Request, params []string) {
+	var request batchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	for _, input := range request.Inputs {
+		if object, ok := s.liveObject(params[0], input.Id); ok {
+			s.archive(object)
+		}
+	}
+
+	w.WriteHeader(204)
+}
+
+// search returns a page of the objects matching any of the filter groups of the search request,
+// with the same filter and sort semantics as HubSpot search
+func (s *Server) search(w http.ResponseWriter, r *http.Request, params []string) {
+	objectType := params[0]
+
+	var request searchRequest
+	if err := readJSON(r, &request); err != nil {
+		writeError(w, 400, "VALIDATION_ERROR", err.Error())
+		return
+	}
+
+	offset, limit := pageParams(request.After, "", 10)
+	if request.Limit > 0 {
+		limit = request.Limit
+	}
+	if limit > 200 {
+		writeError(w, 400, "VALIDATION_ERROR", fmt.Sprintf("The limit %d is above the maximum of 200", limit))
+		return
+	}
+	if offset >= crm.SearchResultLimit {
+		writeError(w, 400, "VALIDATION_ERROR", fmt.Sprintf("Search results are limited to %d", crm.SearchResultLimit))
+		return
+	}
+
+	matches := []hubspot.HubSpotSearchResult{}
+	byId := map[string]*Object{}
+	for _, object := range s.sortedObjects(objectType) {
+		if object.Archived {
+			continue
+		}
+
+		if matchFilterGroups(request.FilterGroups, object.Properties) {
+			matches = append(matches, hubspot.HubSpotSearchResult{Id: object.Id, Properties: object.Properties})
+			byId[object.Id] = object
+		}
+	}
+
+	sortResults(matches, request.Sorts)
+
+	total := len(matches)
+	if len(matches) > crm.SearchResultLimit {
+		matches = matches[:crm.SearchResultLimit]
+	}
+
+	response := searchResponse{Total: total, Results: []objectJSON{}, Paging: nextPage(offset, limit, len(matches))}
+	for i := offset; i < offset+limit && i < len(matches); i++ {
+		response.Results = append(response.Results, s.toJSON(objectType, byId[matches[i].Id], request.Properties, nil))
+	}
+
+	writeJSON(w, 200, response)
+}
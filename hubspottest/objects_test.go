@@ -0,0 +1,119 @@
+package hubspottest
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeals(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	created := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	server.SetNow(func() time.Time { return created })
+
+	server.AddPipeline("deals", hubspot.Pipeline{Id: "default", Stages: []hubspot.PipelineStage{{Id: "open"}, {Id: "won"}}})
+	companyId := server.AddObject("companies", map[string]string{"name": "Acme"})
+	contactId := server.AddObject("contacts", map[string]string{"email": "alice@acme.com"})
+
+	api := hubspot.NewHubspotDealFlowAPIWithClient("api_key", server.Client())
+	deal, err := api.CreateDeal(hubspot.DealCreateRequest{
+		Name:     "Acme renewal",
+		Pipeline: "default",
+		Stage:    "open",
+		Associations: []hubspot.DealCreateAssociation{
+			{ObjectType: "companies", Id: companyId},
+			{ObjectType: "contacts", Id: contactId},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if deal.Properties["dealname"] != "Acme renewal" || deal.CreatedAt != "2021-01-01T12:00:00.000Z" {
+		t.Errorf("Unexpected deal %v", deal)
+	}
+
+	if !cmp.Equal([]string{companyId}, server.Associated("deals", deal.Id, "companies")) {
+		t.Errorf("Unexpected companies %v", server.Associated("deals", deal.Id, "companies"))
+	}
+	if !cmp.Equal([]string{deal.Id}, server.Associated("contacts", contactId, "deals")) {
+		t.Errorf("Unexpected deals %v", server.Associated("contacts", contactId, "deals"))
+	}
+
+	server.SetNow(func() time.Time { return created.Add(time.Hour) })
+
+	outcomes, err := api.BulkMoveDeals([]string{deal.Id, "404"}, "won")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if !outcomes[0].Moved || outcomes[0].Err != nil || outcomes[1].Err == nil {
+		t.Errorf("Unexpected outcomes %v", outcomes)
+	}
+
+	stored, _ := server.Object("deals", deal.Id)
+	if stored.Properties["dealstage"] != "won" || stored.Properties["hs_lastmodifieddate"] != "2021-01-01T13:00:00.000Z" {
+		t.Errorf("Unexpected deal %v", stored)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	for i := 0; i < 250; i++ {
+		server.AddObject("companies", map[string]string{
+			"name":              fmt.Sprintf("Company %03d", i),
+			"numberofemployees": fmt.Sprintf("%d", i),
+			"industry":          []string{"SOFTWARE", "RETAIL"}[i%2],
+		})
+	}
+
+	api := hubspot.NewHubspotCRMAPIWithClient("api_key", server.Client())
+	it := api.Search("companies", hubspot.SearchQuery{
+		Filters: []hubspot.SearchFilter{
+			{PropertyName: "industry", Operator: "EQ", Value: "software"},
+			{PropertyName: "numberofemployees", Operator: "GTE", Value: "20"},
+		},
+		Sorts:      []hubspot.SearchSort{{PropertyName: "numberofemployees", Direction: "DESCENDING"}},
+		Properties: []string{"name"},
+	})
+
+	results, err := it.All()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(results) != 115 || it.Total() != 115 {
+		t.Fatalf("Unexpected number of results %d, total %d", len(results), it.Total())
+	}
+	if results[0].Properties["name"] != "Company 248" || results[114].Properties["name"] != "Company 020" {
+		t.Errorf("Unexpected order %v ... %v", results[0], results[114])
+	}
+	if _, ok := results[0].Properties["industry"]; ok {
+		t.Errorf("Unexpected properties %v", results[0].Properties)
+	}
+
+	// Archived objects are not returned by searches, but are listed as archived
+	req, _ := http.NewRequest("DELETE", "https://api.hubapi.com/crm/v3/objects/companies/"+results[0].Id+"?hapikey=api_key", nil)
+	resp, err := server.Client().Do(req)
+	if err != nil || resp.StatusCode != 204 {
+		t.Fatalf("Unexpected archive response %v, %v", resp, err)
+	}
+	resp.Body.Close()
+
+	for name, expected := range map[string]int{"Company 248": 0, "Company 246": 1} {
+		found, err := api.SearchCompanies(map[string]string{"name": name}, []string{"name"})
+		if err != nil || len(found) != expected {
+			t.Errorf("Unexpected search results for %s %v, %v", name, found, err)
+		}
+	}
+
+	archived, err := api.ListArchived("companies", []string{"name"})
+	if err != nil || len(archived) != 1 || archived[0].Id != results[0].Id {
+		t.Errorf("Unexpected archived companies %v, %v", archived, err)
+	}
+}
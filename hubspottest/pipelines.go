@@ -0,0 +1,37 @@
+package hubspottest
+
+import (
+	"net/http"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// AddPipeline adds a pipeline of an object type, e.g. a deal pipeline with its stages
+func (s *Server) AddPipeline(objectType string, pipeline hubspot.Pipeline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	objectType = crm.PluralObjectType(objectType)
+	s.pipelines[objectType] = append(s.pipelines[objectType], pipeline)
+}
+
+func (s *Server) listPipelines(w http.ResponseWriter, r *http.Request, params []string) {
+	pipelines := s.pipelines[crm.PluralObjectType(params[0])]
+	if pipelines == nil {
+		pipelines = []hubspot.Pipeline{}
+	}
+
+	writeJSON(w, 200, map[string][]hubspot.Pipeline{"results": pipelines})
+}
+
+func (s *Server) getPipeline(w http.ResponseWriter, r *http.Request, params []string) {
+	for _, pipeline := range s.pipelines[crm.PluralObjectType(params[0])] {
+		if pipeline.Id == params[1] {
+			writeJSON(w, 200, pipeline)
+			return
+		}
+	}
+
+	writeError(w, 404, "OBJECT_NOT_FOUND", "resource not found")
+}
@@ -0,0 +1,42 @@
+package hubspottest
+
+import (
+	"sort"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// matchFilterGroups returns whether properties match any of the filter groups, and all the filters of that group
+// An empty list of filter groups matches all objects, filters match the same way they do for the client's local searches.
+func matchFilterGroups(groups []filterGroup, properties map[string]string) bool {
+	if len(groups) == 0 {
+		return true
+	}
+
+	for _, group := range groups {
+		matched := true
+		for _, filter := range group.Filters {
+			if !crm.Filter(filter).Matches(properties) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// sortResults sorts search results by the sorts of a search request, results are left in order when there are no sorts
+func sortResults(results []hubspot.HubSpotSearchResult, searchSorts []hubspot.SearchSort) {
+	sorts := make([]crm.Sort, len(searchSorts))
+	for i, searchSort := range searchSorts {
+		sorts[i] = crm.Sort(searchSort)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return crm.Less(results[i].Properties, results[j].Properties, sorts)
+	})
+}
@@ -0,0 +1,21 @@
+package hubspottest
+
+import (
+	"testing"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// TestMatchFilter checks the filters of the fake server against the cases the client is checked against too
+func TestMatchFilter(t *testing.T) {
+	for _, test := range crm.FilterCases {
+		t.Run(test.Name, func(t *testing.T) {
+			filter := hubspot.SearchFilter(test.Filter)
+			groups := []filterGroup{{Filters: []hubspot.SearchFilter{filter}}}
+			if matchFilterGroups(groups, crm.FilterCaseProperties) != test.Expected {
+				t.Errorf("Expected %v to match %v: %v", filter, crm.FilterCaseProperties, test.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+// Package hubspottest provides a fake HubSpot API server for tests
+//
+// The server keeps CRM objects, associations, pipelines, form submissions and uploaded files in memory,
+// and serves the HubSpot endpoints the go-hubspot clients use, so that tests exercise real request and response handling:
+//
+//	server := hubspottest.NewServer()
+//	defer server.Close()
+//
+//	api := hubspot.NewHubspotDealFlowAPIWithClient("api_key", server.Client())
+package hubspottest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+)
+
+// hubspotHost is the host of the HubSpot API, the client of the server sends the requests to it to the server instead
+const hubspotHost = "api.hubapi.com"
+
+// Server is a fake HubSpot API server keeping its data in memory
+// When APIKey is set, requests with a different "hapikey" are rejected with status 401, as HubSpot does.
+type Server struct {
+	*httptest.Server
+	APIKey string
+
+	mu           sync.Mutex
+	now          func() time.Time
+	lastId       int
+	objects      map[string]map[string]*Object
+	associations map[associationKey][]hubspot.Association
+	pipelines    map[string][]hubspot.Pipeline
+	submissions  map[string][]hubspot.Submission
+	files        []File
+}
+
+// NewServer starts a fake HubSpot API server, it must be closed with Close
+func NewServer() *Server {
+	server := &Server{
+		now:          time.Now,
+		objects:      map[string]map[string]*Object{},
+		associations: map[associationKey][]hubspot.Association{},
+		pipelines:    map[string][]hubspot.Pipeline{},
+		submissions:  map[string][]hubspot.Submission{},
+	}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.serveHTTP))
+
+	return server
+}
+
+// Client returns an HTTP client sending the requests made to the HubSpot API to the server,
+// to pass to the constructors of the API clients, e.g. hubspot.NewHubspotCRMAPIWithClient
+func (s *Server) Client() hubspot.IHTTPClient {
+	return client{server: s}
+}
+
+// client is a hubspot.IHTTPClient redirecting the requests to the HubSpot API to the fake server
+type client struct {
+	server *Server
+}
+
+// Do sends the request to the fake server, requests to other hosts than the HubSpot API fail
+func (c client) Do(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != hubspotHost {
+		return nil, errors.New(fmt.Sprintf("Request to %s, only requests to %s can be sent to the fake HubSpot server", req.URL.Host, hubspotHost))
+	}
+
+	target, err := url.Parse(c.server.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	redirected := req.Clone(req.Context())
+	redirected.URL.Scheme = target.Scheme
+	redirected.URL.Host = target.Host
+	redirected.Host = target.Host
+
+	return c.server.Server.Client().Do(redirected)
+}
+
+// Get makes a GET request to the fake server
+func (c client) Get(url string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Do(req)
+}
+
+// SetNow sets the clock of the server, used for the creation and modification times of objects
+func (s *Server) SetNow(now func() time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.now = now
+}
+
+// nextId returns a new object id, ids are unique across object types
+func (s *Server) nextId() string {
+	s.lastId++
+	return strconv.Itoa(s.lastId)
+}
+
+// apiError is a representation of an error response of the HubSpot API
+type apiError struct {
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	Category string `json:"category"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, category, message string) {
+	writeJSON(w, status, apiError{Status: "error", Message: message, Category: category})
+}
+
+func readJSON(r *http.Request, v interface{}) error {
+	return json.NewDecoder(r.Body).Decode(v)
+}
+
+// route is a handler of the requests with a method and a path matching a pattern, where "{}" matches any path segment
+type route struct {
+	method  string
+	pattern string
+	handle  func(s *Server, w http.ResponseWriter, r *http.Request, params []string)
+}
+
+var routes = []route{
+	{"POST", "/crm/v3/objects/{}/search", (*Server).search},
+	{"POST", "/crm/v3/objects/{}/batch/read", (*Server).batchRead},
+	{"POST", "/crm/v3/objects/{}/batch/create", (*Server).batchCreate},
+	{"POST", "/crm/v3/objects/{}/batch/update", (*Server).batchUpdate},
+	{"POST", "/crm/v3/objects/{}/batch/archive", (*Server).batchArchive},
+	{"GET", "/crm/v3/objects/{}", (*Server).listObjects},
+	{"POST", "/crm/v3/objects/{}", (*Server).createObject},
+	{"GET", "/crm/v3/objects/{}/{}", (*Server).getObject},
+	{"PATCH", "/crm/v3/objects/{}/{}", (*Server).updateObject},
+	{"DELETE", "/crm/v3/objects/{}/{}", (*Server).archiveObject},
+	{"GET", "/crm/v3/objects/{}/{}/associations/{}", (*Server).getAssociations},
+	{"PUT", "/crm/v3/objects/{}/{}/associations/{}/{}/{}", (*Server).putAssociation},
+	{"DELETE", "/crm/v3/objects/{}/{}/associations/{}/{}/{}", (*Server).deleteAssociation},
+	{"POST", "/crm/v3/associations/{}/{}/batch/create", (*Server).batchCreateAssociations},
+	{"POST", "/crm/v3/associations/{}/{}/batch/read", (*Server).batchReadAssociations},
+	{"POST", "/crm/v3/associations/{}/{}/batch/archive", (*Server).batchArchiveAssociations},
+	{"POST", "/crm/v4/associations/{}/{}/batch/create", (*Server).batchCreateTypedAssociations},
+	{"GET", "/crm/v3/pipelines/{}", (*Server).listPipelines},
+	{"GET", "/crm/v3/pipelines/{}/{}", (*Server).getPipeline},
+	{"GET", "/form-integrations/v1/submissions/forms/{}", (*Server).listSubmissions},
+	{"POST", "/files/v3/files", (*Server).uploadFile},
+}
+
+// match returns the path segments matching the "{}" of the pattern, returns false if the path does not match
+func (rt route) match(method, path string) ([]string, bool) {
+	if method != rt.method {
+		return nil, false
+	}
+
+	patternSegments := strings.Split(strings.Trim(rt.pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	params := []string{}
+	for i, segment := range patternSegments {
+		if segment == "{}" {
+			params = append(params, pathSegments[i])
+		} else if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+
+	return params, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.APIKey != "" && r.URL.Query().Get("hapikey") != s.APIKey {
+		writeError(w, 401, "INVALID_AUTHENTICATION", "The API key provided is invalid")
+		return
+	}
+
+	for _, rt := range routes {
+		params, ok := rt.match(r.Method, r.URL.Path)
+		if !ok {
+			continue
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		rt.handle(s, w, r, params)
+		return
+	}
+
+	writeError(w, 404, "NOT_FOUND", fmt.Sprintf("%s %s is not supported by the fake HubSpot server", r.Method, r.URL.Path))
+}
+
+// pageParams returns the offset and limit of a page from the "after" and "limit" parameters of a request
+func pageParams(after, limit string, defaultLimit int) (int, int) {
+	offset, err := strconv.Atoi(after)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	pageLimit, err := strconv.Atoi(limit)
+	if err != nil || pageLimit <= 0 {
+		pageLimit = defaultLimit
+	}
+
+	return offset, pageLimit
+}
+
+// nextPage returns the paging of a page of total items starting at offset, or nil if it is the last page
+func nextPage(offset, limit, total int) *hubspot.Paging {
+	if offset+limit >= total {
+		return nil
+	}
+	return &hubspot.Paging{Next: map[string]string{"after": strconv.Itoa(offset + limit)}}
+}
+
+// sortIds sorts ids in numeric order, the way HubSpot orders objects that are not sorted otherwise
+func sortIds(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		if len(ids[i]) != len(ids[j]) {
+			return len(ids[i]) < len(ids[j])
+		}
+		return ids[i] < ids[j]
+	})
+}
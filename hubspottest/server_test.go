@@ -0,0 +1,49 @@
+package hubspottest
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	hubspot "github.com/fuzzylabs/go-hubspot"
+)
+
+func TestServerAuthentication(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.APIKey = "api_key"
+
+	companyId := server.AddObject("companies", map[string]string{"name": "Acme"})
+
+	_, err := hubspot.NewHubspotCRMAPIWithClient("wrong_key", server.Client()).MergeObjects("companies", companyId, companyId)
+	var apiErr hubspot.HubSpotAPIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 401 {
+		t.Errorf("Expected an authentication error, got %v", err)
+	}
+
+	// Merging is not supported by the fake server
+	_, err = hubspot.NewHubspotCRMAPIWithClient("api_key", server.Client()).MergeObjects("companies", companyId, companyId)
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 404 {
+		t.Errorf("Expected an unsupported endpoint error, got %v", err)
+	}
+}
+
+func TestServerClient(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	resp, err := server.Client().Get("https://api.hubapi.com/crm/v3/objects/contacts?hapikey=api_key")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	resp.Body.Close()
+	if resp.StatusCode != 200 {
+		t.Errorf("Unexpected status %d", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+	_, err = server.Client().Do(req)
+	if err == nil {
+		t.Errorf("Expected requests to other hosts than HubSpot to fail")
+	}
+}
@@ -0,0 +1,328 @@
+package go_hubspot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotImportAPI interface {
+	StartImport(request ImportRequest) (*Import, error)
+	GetImport(importId string) (*Import, error)
+	WaitForImport(importId string, pollInterval, timeout time.Duration) (*Import, error)
+	GetImportErrors(importId string) ([]ImportError, error)
+	CancelImport(importId string) error
+}
+
+// HubspotImportAPI is the structure to interact with HubSpot CRM Imports API
+type HubspotImportAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+}
+
+// Import states reported by HubSpot
+const (
+	ImportStarted    = "STARTED"
+	ImportProcessing = "PROCESSING"
+	ImportDone       = "DONE"
+	ImportFailed     = "FAILED"
+	ImportCanceled   = "CANCELED"
+	ImportDeferred   = "DEFERRED"
+)
+
+// ImportColumnMapping maps a column of an import file to a property of an object type, e.g. "companies"
+// IdColumnType is set for columns identifying records, "HUBSPOT_OBJECT_ID" or "HUBSPOT_ALTERNATE_ID", e.g. an email or domain.
+// For association columns, AssociateWith is the object type the records are associated with,
+// and AssociationTypeId the HubSpot defined association type, the column identifies records of AssociateWith.
+type ImportColumnMapping struct {
+	ColumnName        string
+	ObjectType        string
+	PropertyName      string
+	IdColumnType      string
+	AssociateWith     string
+	AssociationTypeId int
+}
+
+// ImportFile is a file to import, FileFormat defaults to "CSV", and the first row is a header unless NoHeader is set
+type ImportFile struct {
+	FileName       string
+	Content        io.Reader
+	FileFormat     string
+	NoHeader       bool
+	ColumnMappings []ImportColumnMapping
+}
+
+// ImportRequest describes an import, Operations is the operation for each object type, "CREATE", "UPDATE" or "UPSERT"
+type ImportRequest struct {
+	Name       string
+	DateFormat string
+	Operations map[string]string
+	Files      []ImportFile
+}
+
+// Import is a representation of an import in HubSpot, Counters are the numbers of rows created, updated and failed
+type Import struct {
+	Id        string `json:"id"`
+	State     string `json:"state"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+	Metadata  struct {
+		Counters map[string]int `json:"counters"`
+	} `json:"metadata"`
+}
+
+// Finished returns whether the import is done, failed or was canceled
+func (i Import) Finished() bool {
+	return i.State == ImportDone || i.State == ImportFailed || i.State == ImportCanceled
+}
+
+// ImportError is a row of an import file that could not be imported
+type ImportError struct {
+	Id           string `json:"id"`
+	ErrorType    string `json:"errorType"`
+	ObjectType   string `json:"objectType"`
+	InvalidValue string `json:"invalidValue"`
+	ExtraContext string `json:"extraContext"`
+	CreatedAt    int64  `json:"createdAt"`
+	SourceData   struct {
+		RowData    string   `json:"rowData"`
+		LineNumber int      `json:"lineNumber"`
+		FileId     int64    `json:"fileId"`
+		PageName   string   `json:"pageName"`
+		Values     []string `json:"values"`
+	} `json:"sourceData"`
+}
+
+type importForeignKeyType struct {
+	AssociationTypeId   int    `json:"associationTypeId"`
+	AssociationCategory string `json:"associationCategory"`
+}
+
+type importColumnMappingRequest struct {
+	ColumnObjectTypeId   string                `json:"columnObjectTypeId"`
+	ColumnName           string                `json:"columnName"`
+	PropertyName         string                `json:"propertyName,omitempty"`
+	IdColumnType         string                `json:"idColumnType,omitempty"`
+	ToColumnObjectTypeId string                `json:"toColumnObjectTypeId,omitempty"`
+	ForeignKeyType       *importForeignKeyType `json:"foreignKeyType,omitempty"`
+}
+
+type importFilePage struct {
+	HasHeader      bool                         `json:"hasHeader"`
+	ColumnMappings []importColumnMappingRequest `json:"columnMappings"`
+}
+
+type importFileRequest struct {
+	FileName       string         `json:"fileName"`
+	FileFormat     string         `json:"fileFormat"`
+	FileImportPage importFilePage `json:"fileImportPage"`
+}
+
+// importRequestJSON is a representation of the importRequest part of an import request
+type importRequestJSON struct {
+	Name             string              `json:"name"`
+	DateFormat       string              `json:"dateFormat,omitempty"`
+	ImportOperations map[string]string   `json:"importOperations,omitempty"`
+	Files            []importFileRequest `json:"files"`
+}
+
+type importErrorsResponse struct {
+	Results []ImportError `json:"results"`
+	Paging  *Paging       `json:"paging"`
+}
+
+// NewHubspotImportAPI creates new HubspotImportAPI with API key
+func NewHubspotImportAPI(apiKey string) HubspotImportAPI {
+	return NewHubspotImportAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotImportAPIWithClient creates new HubspotImportAPI with API key and HTTP client
+func NewHubspotImportAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotImportAPI {
+	return HubspotImportAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (api HubspotImportAPI) url(path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("hapikey", api.APIKey)
+
+	return fmt.Sprintf("https://api.hubapi.com/crm/v3/imports%s?%s", path, query.Encode())
+}
+
+// json returns the importRequest part of the import request
+func (request ImportRequest) json() importRequestJSON {
+	requestJSON := importRequestJSON{
+		Name:             request.Name,
+		DateFormat:       request.DateFormat,
+		ImportOperations: map[string]string{},
+		Files:            make([]importFileRequest, len(request.Files)),
+	}
+
+	for objectType, operation := range request.Operations {
+		requestJSON.ImportOperations[objectTypeId(objectType)] = operation
+	}
+
+	for i, file := range request.Files {
+		fileFormat := file.FileFormat
+		if fileFormat == "" {
+			fileFormat = "CSV"
+		}
+
+		mappings := make([]importColumnMappingRequest, len(file.ColumnMappings))
+		for j, mapping := range file.ColumnMappings {
+			mappings[j] = importColumnMappingRequest{
+				ColumnObjectTypeId: objectTypeId(mapping.ObjectType),
+				ColumnName:         mapping.ColumnName,
+				PropertyName:       mapping.PropertyName,
+				IdColumnType:       mapping.IdColumnType,
+			}
+			if mapping.AssociateWith != "" {
+				mappings[j].ToColumnObjectTypeId = objectTypeId(mapping.AssociateWith)
+				mappings[j].ForeignKeyType = &importForeignKeyType{
+					AssociationTypeId:   mapping.AssociationTypeId,
+					AssociationCategory: "HUBSPOT_DEFINED",
+				}
+			}
+		}
+
+		requestJSON.Files[i] = importFileRequest{
+			FileName:   file.FileName,
+			FileFormat: fileFormat,
+			FileImportPage: importFilePage{
+				HasHeader:      !file.NoHeader,
+				ColumnMappings: mappings,
+			},
+		}
+	}
+
+	return requestJSON
+}
+
+// StartImport uploads the files of the import request and starts importing them
+func (api HubspotImportAPI) StartImport(request ImportRequest) (*Import, error) {
+	log.Infof("Starting import '%s'", request.Name)
+
+	var data bytes.Buffer
+	w := multipart.NewWriter(&data)
+
+	requestBytes, err := json.Marshal(request.json())
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while marshaling import request: %s", err.Error()))
+	}
+
+	err = w.WriteField("importRequest", string(requestBytes))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while writing import request: %s", err.Error()))
+	}
+
+	for _, file := range request.Files {
+		fileWriter, err := w.CreateFormFile("files", file.FileName)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Error while creating a file writer: %s", err.Error()))
+		}
+
+		_, err = io.Copy(fileWriter, file.Content)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Error while writing file '%s': %s", file.FileName, err.Error()))
+		}
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", api.url("", nil), &data)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	var started Import
+	err = doRequest(api.httpClient, req, &started)
+	if err != nil {
+		return nil, err
+	}
+
+	return &started, nil
+}
+
+// GetImport returns the import with the given id
+func (api HubspotImportAPI) GetImport(importId string) (*Import, error) {
+	var current Import
+	err := doJSONRequest(api.httpClient, "GET", api.url("/"+importId, nil), nil, &current)
+	if err != nil {
+		return nil, err
+	}
+
+	return &current, nil
+}
+
+// WaitForImport polls the import with the given id until it is finished, and returns it
+// An error is returned if the import is not finished after timeout, or if it failed or was canceled.
+func (api HubspotImportAPI) WaitForImport(importId string, pollInterval, timeout time.Duration) (*Import, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		current, err := api.GetImport(importId)
+		if err != nil {
+			return nil, err
+		}
+
+		if current.Finished() {
+			if current.State != ImportDone {
+				return current, errors.New(fmt.Sprintf("Import '%s' finished in state %s", importId, current.State))
+			}
+			return current, nil
+		}
+
+		if time.Now().Add(pollInterval).After(deadline) {
+			return current, errors.New(fmt.Sprintf("Import '%s' is still %s after %s", importId, current.State, timeout))
+		}
+
+		log.Infof("Import '%s' is %s, checking again in %s", importId, current.State, pollInterval)
+		time.Sleep(pollInterval)
+	}
+}
+
+// GetImportErrors returns all the rows of an import that could not be imported
+func (api HubspotImportAPI) GetImportErrors(importId string) ([]ImportError, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(listPageLimit))
+
+	importErrors := []ImportError{}
+	for {
+		var response importErrorsResponse
+		err := doJSONRequest(api.httpClient, "GET", api.url(fmt.Sprintf("/%s/errors", importId), query), nil, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		importErrors = append(importErrors, response.Results...)
+
+		if response.Paging == nil || response.Paging.Next["after"] == "" {
+			return importErrors, nil
+		}
+		query.Set("after", response.Paging.Next["after"])
+	}
+}
+
+// CancelImport cancels an import that is still running
+func (api HubspotImportAPI) CancelImport(importId string) error {
+	log.Infof("Cancelling import '%s'", importId)
+
+	return doJSONRequest(api.httpClient, "POST", api.url(fmt.Sprintf("/%s/cancel", importId), nil), nil, nil)
+}
@@ -0,0 +1,195 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockImportAPI(mockClient *IHTTPClientMock) HubspotImportAPI {
+	return HubspotImportAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+func TestStartImport(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "POST https://api.hubapi.com/crm/v3/imports?hapikey=api_key" {
+				err := req.ParseMultipartForm(1 << 20)
+				if err != nil {
+					t.Errorf("Expected a multipart request: %s", err.Error())
+					return w.Result(), nil
+				}
+
+				var request importRequestJSON
+				err = json.Unmarshal([]byte(req.FormValue("importRequest")), &request)
+				if err != nil {
+					t.Errorf("Invalid importRequest: %s", err.Error())
+				}
+
+				expectedRequest := importRequestJSON{
+					Name:             "Companies",
+					ImportOperations: map[string]string{"0-2": "UPSERT"},
+					Files: []importFileRequest{
+						{
+							FileName:   "companies.csv",
+							FileFormat: "CSV",
+							FileImportPage: importFilePage{
+								HasHeader: true,
+								ColumnMappings: []importColumnMappingRequest{
+									{ColumnObjectTypeId: "0-2", ColumnName: "Domain", PropertyName: "domain", IdColumnType: "HUBSPOT_ALTERNATE_ID"},
+									{ColumnObjectTypeId: "0-2", ColumnName: "Name", PropertyName: "name"},
+									{
+										ColumnObjectTypeId:   "0-2",
+										ColumnName:           "Contact email",
+										ToColumnObjectTypeId: "0-1",
+										ForeignKeyType:       &importForeignKeyType{AssociationTypeId: 280, AssociationCategory: "HUBSPOT_DEFINED"},
+									},
+								},
+							},
+						},
+					},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected import request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				file, header, err := req.FormFile("files")
+				if err != nil {
+					t.Errorf("Expected a file: %s", err.Error())
+					return w.Result(), nil
+				}
+				content, _ := ioutil.ReadAll(file)
+				if header.Filename != "companies.csv" || string(content) != "Domain,Name,Contact email\nacme.com,Acme,jane@acme.com\n" {
+					t.Errorf("Unexpected file %s: %s", header.Filename, string(content))
+				}
+
+				writeJSONResponse(t, w, 200, map[string]string{"id": "importId", "state": "STARTED"})
+			} else {
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockImportAPI(&mockHubspotHTTPClient)
+
+	started, err := api.StartImport(ImportRequest{
+		Name:       "Companies",
+		Operations: map[string]string{"companies": "UPSERT"},
+		Files: []ImportFile{
+			{
+				FileName: "companies.csv",
+				Content:  strings.NewReader("Domain,Name,Contact email\nacme.com,Acme,jane@acme.com\n"),
+				ColumnMappings: []ImportColumnMapping{
+					{ColumnName: "Domain", ObjectType: "companies", PropertyName: "domain", IdColumnType: "HUBSPOT_ALTERNATE_ID"},
+					{ColumnName: "Name", ObjectType: "companies", PropertyName: "name"},
+					{ColumnName: "Contact email", ObjectType: "companies", AssociateWith: "contacts", AssociationTypeId: 280},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("StartImport returned an error: %s", err.Error())
+		return
+	}
+
+	if started.Id != "importId" || started.State != ImportStarted {
+		t.Errorf("StartImport returned incorrect import: %v", started)
+	}
+}
+
+func TestWaitForImport(t *testing.T) {
+	states := []string{ImportProcessing, ImportDone}
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "GET https://api.hubapi.com/crm/v3/imports/importId?hapikey=api_key" {
+				state := states[0]
+				states = states[1:]
+				w.WriteHeader(200)
+				_, _ = fmt.Fprintf(w, `{"id": "importId", "state": "%s", "metadata": {"counters": {"CREATED_OBJECTS": 2, "ERRORS": 1}}}`, state)
+			} else {
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockImportAPI(&mockHubspotHTTPClient)
+
+	finished, err := api.WaitForImport("importId", time.Millisecond, time.Second)
+	if err != nil {
+		t.Errorf("WaitForImport returned an error: %s", err.Error())
+		return
+	}
+
+	if finished.State != ImportDone || finished.Metadata.Counters["ERRORS"] != 1 {
+		t.Errorf("WaitForImport returned incorrect import: %v", finished)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 2 {
+		t.Errorf("Expected the import to be polled twice")
+	}
+}
+
+func TestGetImportErrors(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "https://api.hubapi.com/crm/v3/imports/importId/errors?hapikey=api_key&limit=100":
+				w.WriteHeader(200)
+				_, _ = w.Write([]byte(`{
+					"results": [{"id": "1", "errorType": "INVALID_EMAIL", "invalidValue": "jane@", "sourceData": {"rowData": "acme.com,Acme,jane@", "lineNumber": 2}}],
+					"paging": {"next": {"after": "1"}}
+				}`))
+			case "https://api.hubapi.com/crm/v3/imports/importId/errors?after=1&hapikey=api_key&limit=100":
+				w.WriteHeader(200)
+				_, _ = w.Write([]byte(`{"results": [{"id": "2", "errorType": "DUPLICATE_ALTERNATE_ID"}]}`))
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockImportAPI(&mockHubspotHTTPClient)
+
+	importErrors, err := api.GetImportErrors("importId")
+	if err != nil {
+		t.Errorf("GetImportErrors returned an error: %s", err.Error())
+		return
+	}
+
+	if len(importErrors) != 2 {
+		t.Errorf("Expected 2 import errors, got: %v", importErrors)
+		return
+	}
+
+	if importErrors[0].SourceData.LineNumber != 2 || importErrors[0].SourceData.RowData != "acme.com,Acme,jane@" {
+		t.Errorf("Unexpected first import error: %v", importErrors[0])
+	}
+}
@@ -0,0 +1,45 @@
+package crm
+
+// SearchResultLimit is the maximum number of results HubSpot returns for a single search query, whatever the paging
+const SearchResultLimit = 10000
+
+// pluralObjectTypes maps the singular object type names used by the associations API to the plural names of the objects API
+var pluralObjectTypes = map[string]string{
+	"contact":   "contacts",
+	"company":   "companies",
+	"deal":      "deals",
+	"line_item": "line_items",
+	"product":   "products",
+	"ticket":    "tickets",
+	"note":      "notes",
+	"task":      "tasks",
+	"call":      "calls",
+	"meeting":   "meetings",
+	"email":     "emails",
+}
+
+// PluralObjectType returns the plural name of a CRM object type, accepting both plural and singular names
+func PluralObjectType(objectType string) string {
+	if plural, ok := pluralObjectTypes[objectType]; ok {
+		return plural
+	}
+	return objectType
+}
+
+// SingularObjectType returns the singular name of a CRM object type, accepting both plural and singular names
+func SingularObjectType(objectType string) string {
+	for singular, plural := range pluralObjectTypes {
+		if plural == objectType {
+			return singular
+		}
+	}
+	return objectType
+}
+
+// LastModifiedProperty returns the property HubSpot keeps the last modification time of an object type in
+func LastModifiedProperty(objectType string) string {
+	if PluralObjectType(objectType) == "contacts" {
+		return "lastmodifieddate"
+	}
+	return "hs_lastmodifieddate"
+}
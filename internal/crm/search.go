@@ -0,0 +1,171 @@
+// Package crm holds the HubSpot CRM semantics shared by the client and the fake server of hubspottest
+package crm
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter is a filter on a single property of a search query, with the fields of the client's SearchFilter
+type Filter struct {
+	PropertyName string
+	Operator     string
+	Value        string
+	HighValue    string
+	Values       []string
+}
+
+// Sort is a sort on a single property of a search query, with the fields of the client's SearchSort
+type Sort struct {
+	PropertyName string
+	Direction    string
+}
+
+// Matches returns whether properties match the filter, the same way HubSpot search does
+// Values are compared as numbers or dates when both sides can be parsed as such, and as case insensitive strings otherwise.
+// "CONTAINS_TOKEN" matches whole words, and supports "*" wildcards.
+func (filter Filter) Matches(properties map[string]string) bool {
+	value, ok := properties[filter.PropertyName]
+	hasValue := ok && value != ""
+
+	switch filter.Operator {
+	case "HAS_PROPERTY":
+		return hasValue
+	case "NOT_HAS_PROPERTY":
+		return !hasValue
+	case "NEQ":
+		return CompareValues(value, filter.Value) != 0
+	case "NOT_IN":
+		for _, candidate := range filter.Values {
+			if CompareValues(value, candidate) == 0 {
+				return false
+			}
+		}
+		return true
+	case "NOT_CONTAINS_TOKEN":
+		return !containsToken(value, filter.Value)
+	}
+
+	if !hasValue {
+		return false
+	}
+
+	switch filter.Operator {
+	case "EQ":
+		return CompareValues(value, filter.Value) == 0
+	case "LT":
+		return CompareValues(value, filter.Value) < 0
+	case "LTE":
+		return CompareValues(value, filter.Value) <= 0
+	case "GT":
+		return CompareValues(value, filter.Value) > 0
+	case "GTE":
+		return CompareValues(value, filter.Value) >= 0
+	case "BETWEEN":
+		return CompareValues(value, filter.Value) >= 0 && CompareValues(value, filter.HighValue) <= 0
+	case "IN":
+		for _, candidate := range filter.Values {
+			if CompareValues(value, candidate) == 0 {
+				return true
+			}
+		}
+		return false
+	case "CONTAINS_TOKEN":
+		return containsToken(value, filter.Value)
+	default:
+		return false
+	}
+}
+
+// Less returns whether properties a sort before properties b, the first of the sorts that tells them apart decides
+func Less(a, b map[string]string, sorts []Sort) bool {
+	for _, sort := range sorts {
+		comparison := CompareValues(a[sort.PropertyName], b[sort.PropertyName])
+		if comparison == 0 {
+			continue
+		}
+		if sort.Direction == "DESCENDING" {
+			return comparison > 0
+		}
+		return comparison < 0
+	}
+	return false
+}
+
+// CompareValues compares two property values as numbers, dates or case insensitive strings, in that order of preference
+func CompareValues(a, b string) int {
+	if x, err := strconv.ParseFloat(a, 64); err == nil {
+		if y, err := strconv.ParseFloat(b, 64); err == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	if x, err := ParseTime(a); err == nil {
+		if y, err := ParseTime(b); err == nil {
+			switch {
+			case x.Before(y):
+				return -1
+			case x.After(y):
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// ParseTime parses a datetime property value, which is either an ISO 8601 string or milliseconds since the epoch
+func ParseTime(value string) (time.Time, error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(0, millis*int64(time.Millisecond)).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// containsToken returns whether any word of value matches token, which may contain "*" wildcards
+func containsToken(value, token string) bool {
+	token = strings.ToLower(token)
+	for _, word := range strings.FieldsFunc(strings.ToLower(value), isTokenSeparator) {
+		if matchWildcard(word, token) {
+			return true
+		}
+	}
+	return matchWildcard(strings.ToLower(value), token)
+}
+
+func isTokenSeparator(r rune) bool {
+	return strings.ContainsRune(" \t\n,;:.!?()[]{}\"'/\\|-_@", r)
+}
+
+// matchWildcard returns whether value matches pattern, where "*" matches any number of characters
+func matchWildcard(value, pattern string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return value == pattern
+	}
+
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		index := strings.Index(value, part)
+		if index < 0 {
+			return false
+		}
+		value = value[index+len(part):]
+	}
+
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
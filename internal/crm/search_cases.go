@@ -0,0 +1,59 @@
+package crm
+
+// FilterCase is a search filter and whether HubSpot search matches FilterCaseProperties with it
+type FilterCase struct {
+	Name     string
+	Filter   Filter
+	Expected bool
+}
+
+// FilterCaseProperties are the properties of the object FilterCases are checked against
+var FilterCaseProperties = map[string]string{
+	"email":               "Jane.Doe@Acme.com",
+	"firstname":           "Jane",
+	"num_employees":       "25",
+	"amount":              "1500.50",
+	"createdate":          "2021-06-30T12:00:00.000Z",
+	"hs_lastmodifieddate": "1625054400000",
+	"lifecyclestage":      "lead",
+	"notes":               "Interested in the seed round",
+	"phone":               "",
+}
+
+// FilterCases are the filters the tests of the client and of the fake server of hubspottest both check,
+// so that they keep matching objects the same way
+var FilterCases = []FilterCase{
+	{"EQ ignores case", Filter{PropertyName: "email", Operator: "EQ", Value: "jane.doe@acme.com"}, true},
+	{"EQ other value", Filter{PropertyName: "firstname", Operator: "EQ", Value: "John"}, false},
+	{"EQ empty", Filter{PropertyName: "phone", Operator: "EQ", Value: ""}, false},
+	{"EQ unset", Filter{PropertyName: "lastname", Operator: "EQ", Value: ""}, false},
+	{"NEQ unset", Filter{PropertyName: "lastname", Operator: "NEQ", Value: "Doe"}, true},
+	{"NEQ same value", Filter{PropertyName: "firstname", Operator: "NEQ", Value: "JANE"}, false},
+	{"GT numbers", Filter{PropertyName: "num_employees", Operator: "GT", Value: "9"}, true},
+	{"GT decimals", Filter{PropertyName: "amount", Operator: "GT", Value: "1000"}, true},
+	{"LT numbers", Filter{PropertyName: "num_employees", Operator: "LT", Value: "100"}, true},
+	{"LTE decimals", Filter{PropertyName: "amount", Operator: "LTE", Value: "1500"}, false},
+	{"GT unset", Filter{PropertyName: "annualrevenue", Operator: "GT", Value: "0"}, false},
+	{"GTE date by millisecond timestamp", Filter{PropertyName: "createdate", Operator: "GTE", Value: "1625054400000"}, true},
+	{"LT date by millisecond timestamp", Filter{PropertyName: "createdate", Operator: "LT", Value: "1625054400000"}, false},
+	{"LTE timestamp by date", Filter{PropertyName: "hs_lastmodifieddate", Operator: "LTE", Value: "2021-06-30T12:00:00Z"}, true},
+	{"BETWEEN is inclusive", Filter{PropertyName: "num_employees", Operator: "BETWEEN", Value: "10", HighValue: "25"}, true},
+	{"BETWEEN outside", Filter{PropertyName: "num_employees", Operator: "BETWEEN", Value: "26", HighValue: "50"}, false},
+	{"IN", Filter{PropertyName: "lifecyclestage", Operator: "IN", Values: []string{"lead", "customer"}}, true},
+	{"IN ignores case", Filter{PropertyName: "email", Operator: "IN", Values: []string{"john@acme.com", "JANE.DOE@ACME.COM"}}, true},
+	{"NOT_IN", Filter{PropertyName: "lifecyclestage", Operator: "NOT_IN", Values: []string{"lead"}}, false},
+	{"NOT_IN unset", Filter{PropertyName: "hs_lead_status", Operator: "NOT_IN", Values: []string{"NEW"}}, true},
+	{"HAS_PROPERTY", Filter{PropertyName: "email", Operator: "HAS_PROPERTY"}, true},
+	{"HAS_PROPERTY empty", Filter{PropertyName: "phone", Operator: "HAS_PROPERTY"}, false},
+	{"NOT_HAS_PROPERTY empty", Filter{PropertyName: "phone", Operator: "NOT_HAS_PROPERTY"}, true},
+	{"NOT_HAS_PROPERTY unset", Filter{PropertyName: "lastname", Operator: "NOT_HAS_PROPERTY"}, true},
+	{"CONTAINS_TOKEN email domain wildcard", Filter{PropertyName: "email", Operator: "CONTAINS_TOKEN", Value: "*@acme.com"}, true},
+	{"CONTAINS_TOKEN other domain", Filter{PropertyName: "email", Operator: "CONTAINS_TOKEN", Value: "*@globex.com"}, false},
+	{"CONTAINS_TOKEN email word", Filter{PropertyName: "email", Operator: "CONTAINS_TOKEN", Value: "doe"}, true},
+	{"CONTAINS_TOKEN word", Filter{PropertyName: "notes", Operator: "CONTAINS_TOKEN", Value: "seed"}, true},
+	{"CONTAINS_TOKEN word wildcard", Filter{PropertyName: "notes", Operator: "CONTAINS_TOKEN", Value: "inter*"}, true},
+	{"CONTAINS_TOKEN partial word", Filter{PropertyName: "firstname", Operator: "CONTAINS_TOKEN", Value: "jan"}, false},
+	{"NOT_CONTAINS_TOKEN", Filter{PropertyName: "email", Operator: "NOT_CONTAINS_TOKEN", Value: "*@acme.com"}, false},
+	{"NOT_CONTAINS_TOKEN other word", Filter{PropertyName: "notes", Operator: "NOT_CONTAINS_TOKEN", Value: "series"}, true},
+	{"unknown operator", Filter{PropertyName: "email", Operator: "LIKE", Value: "jane"}, false},
+}
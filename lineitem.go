@@ -0,0 +1,146 @@
+package go_hubspot
+
+type IHubspotLineItemAPI interface {
+	CreateLineItem(properties map[string]string) (*LineItem, error)
+	GetLineItem(lineItemId string) (*LineItem, error)
+	UpdateLineItem(lineItemId string, properties map[string]string) (*LineItem, error)
+	ArchiveLineItem(lineItemId string) error
+	BatchCreateLineItems(properties []map[string]string) ([]LineItem, error)
+	BatchReadLineItems(lineItemIds []string) ([]LineItem, error)
+	BatchUpdateLineItems(inputs []BatchUpdateInput) ([]LineItem, error)
+	BatchArchiveLineItems(lineItemIds []string) error
+}
+
+// HubspotLineItemAPI is the structure to interact with HubSpot Line Items API
+type HubspotLineItemAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+}
+
+// LineItemProperties is a representation of the default properties of a line item
+type LineItemProperties struct {
+	Name               string `json:"name"`
+	ProductId          string `json:"hs_product_id"`
+	Quantity           string `json:"quantity"`
+	Price              string `json:"price"`
+	Amount             string `json:"amount"`
+	Discount           string `json:"discount"`
+	Sku                string `json:"hs_sku"`
+	CreateDate         string `json:"createdate"`
+	HsLastModifiedDate string `json:"hs_lastmodifieddate"`
+}
+
+// LineItem is a representation of a line item in HubSpot
+type LineItem struct {
+	Id         string             `json:"id"`
+	Properties LineItemProperties `json:"properties"`
+	CreatedAt  string             `json:"createdAt"`
+	UpdatedAt  string             `json:"updatedAt"`
+	Archived   bool               `json:"archived"`
+}
+
+// lineItemProperties are the properties requested when reading line items
+var lineItemProperties = []string{"name", "hs_product_id", "quantity", "price", "amount", "discount", "hs_sku"}
+
+// NewHubspotLineItemAPI creates new HubspotLineItemAPI with API key
+func NewHubspotLineItemAPI(apiKey string) HubspotLineItemAPI {
+	return NewHubspotLineItemAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotLineItemAPIWithClient creates new HubspotLineItemAPI with API key and HTTP client
+func NewHubspotLineItemAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotLineItemAPI {
+	return HubspotLineItemAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (api HubspotLineItemAPI) objects() crmObjects {
+	return crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: "line_items"}
+}
+
+// CreateLineItem creates a line item with the given properties
+func (api HubspotLineItemAPI) CreateLineItem(properties map[string]string) (*LineItem, error) {
+	return api.createLineItem(objectCreationRequest{Properties: properties})
+}
+
+func (api HubspotLineItemAPI) createLineItem(request objectCreationRequest) (*LineItem, error) {
+	var lineItem LineItem
+	err := api.objects().create(request, &lineItem)
+	if err != nil {
+		return nil, err
+	}
+	return &lineItem, nil
+}
+
+// GetLineItem returns the line item with the given id
+func (api HubspotLineItemAPI) GetLineItem(lineItemId string) (*LineItem, error) {
+	var lineItem LineItem
+	err := api.objects().get(lineItemId, lineItemProperties, &lineItem)
+	if err != nil {
+		return nil, err
+	}
+	return &lineItem, nil
+}
+
+// UpdateLineItem updates the given properties of a line item
+func (api HubspotLineItemAPI) UpdateLineItem(lineItemId string, properties map[string]string) (*LineItem, error) {
+	var lineItem LineItem
+	err := api.objects().update(lineItemId, properties, &lineItem)
+	if err != nil {
+		return nil, err
+	}
+	return &lineItem, nil
+}
+
+// ArchiveLineItem archives the line item with the given id
+func (api HubspotLineItemAPI) ArchiveLineItem(lineItemId string) error {
+	return api.objects().archive(lineItemId)
+}
+
+// BatchCreateLineItems creates a line item for each of the given property maps
+func (api HubspotLineItemAPI) BatchCreateLineItems(properties []map[string]string) ([]LineItem, error) {
+	inputs := make([]objectCreationRequest, len(properties))
+	for i, objectProperties := range properties {
+		inputs[i] = objectCreationRequest{Properties: objectProperties}
+	}
+
+	raw, err := api.objects().batchCreate(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	lineItems := []LineItem{}
+	err = unmarshalAll(raw, &lineItems)
+	return lineItems, err
+}
+
+// BatchReadLineItems returns the line items with the given ids, line items that are not found are omitted
+func (api HubspotLineItemAPI) BatchReadLineItems(lineItemIds []string) ([]LineItem, error) {
+	raw, _, err := api.objects().batchRead(lineItemIds, lineItemProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	lineItems := []LineItem{}
+	err = unmarshalAll(raw, &lineItems)
+	return lineItems, err
+}
+
+// BatchUpdateLineItems updates the properties of the given line items
+func (api HubspotLineItemAPI) BatchUpdateLineItems(inputs []BatchUpdateInput) ([]LineItem, error) {
+	raw, updateErrs := api.objects().batchUpdate(inputs)
+	err := firstBatchError(inputs, updateErrs)
+	if err != nil {
+		return nil, err
+	}
+
+	lineItems := []LineItem{}
+	err = unmarshalAll(raw, &lineItems)
+	return lineItems, err
+}
+
+// BatchArchiveLineItems archives the line items with the given ids
+func (api HubspotLineItemAPI) BatchArchiveLineItems(lineItemIds []string) error {
+	return api.objects().batchArchive(lineItemIds)
+}
@@ -0,0 +1,107 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockLineItemAPI(mockClient *IHTTPClientMock) HubspotLineItemAPI {
+	return HubspotLineItemAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+func TestLineItemCRUD(t *testing.T) {
+	lineItem := LineItem{
+		Id:         "lineItemId",
+		Properties: LineItemProperties{Name: "Workshop", Quantity: "2", Price: "100"},
+	}
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "POST https://api.hubapi.com/crm/v3/objects/line_items?hapikey=api_key":
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+				if request.Properties["name"] != "Workshop" {
+					t.Errorf("Unexpected line item creation request: %v", request)
+				}
+				writeJSONResponse(t, w, 201, lineItem)
+			case "GET https://api.hubapi.com/crm/v3/objects/line_items/lineItemId?hapikey=api_key&properties=name%2Chs_product_id%2Cquantity%2Cprice%2Camount%2Cdiscount%2Chs_sku":
+				writeJSONResponse(t, w, 200, lineItem)
+			case "PATCH https://api.hubapi.com/crm/v3/objects/line_items/lineItemId?hapikey=api_key":
+				var request dealUpdateRequest
+				readJSONRequest(t, req, &request)
+				if request.Properties["quantity"] != "2" {
+					t.Errorf("Unexpected line item update request: %v", request)
+				}
+				writeJSONResponse(t, w, 200, lineItem)
+			case "DELETE https://api.hubapi.com/crm/v3/objects/line_items/lineItemId?hapikey=api_key":
+				w.WriteHeader(204)
+			case "POST https://api.hubapi.com/crm/v3/objects/line_items/batch/create?hapikey=api_key":
+				var request batchCreateRequest
+				readJSONRequest(t, req, &request)
+				if len(request.Inputs) != 2 {
+					t.Errorf("Expected 2 inputs in batch create request, got %d", len(request.Inputs))
+				}
+				writeJSONResponse(t, w, 201, map[string][]LineItem{"results": {lineItem, lineItem}})
+			case "POST https://api.hubapi.com/crm/v3/objects/line_items/batch/archive?hapikey=api_key":
+				var request batchArchiveRequest
+				readJSONRequest(t, req, &request)
+				if !cmp.Equal(batchArchiveRequest{[]batchId{{"1"}, {"2"}}}, request) {
+					t.Errorf("Unexpected batch archive request: %v", request)
+				}
+				w.WriteHeader(204)
+			default:
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockLineItemAPI(&mockHubspotHTTPClient)
+
+	created, err := api.CreateLineItem(map[string]string{"name": "Workshop"})
+	if err != nil || !cmp.Equal(lineItem, *created) {
+		t.Errorf("CreateLineItem returned %v, %v", created, err)
+	}
+
+	got, err := api.GetLineItem("lineItemId")
+	if err != nil || !cmp.Equal(lineItem, *got) {
+		t.Errorf("GetLineItem returned %v, %v", got, err)
+	}
+
+	_, err = api.UpdateLineItem("lineItemId", map[string]string{"quantity": "2"})
+	if err != nil {
+		t.Errorf("UpdateLineItem returned an error: %s", err.Error())
+	}
+
+	err = api.ArchiveLineItem("lineItemId")
+	if err != nil {
+		t.Errorf("ArchiveLineItem returned an error: %s", err.Error())
+	}
+
+	batch, err := api.BatchCreateLineItems([]map[string]string{{"name": "Workshop"}, {"name": "Workshop"}})
+	if err != nil || len(batch) != 2 {
+		t.Errorf("BatchCreateLineItems returned %v, %v", batch, err)
+	}
+
+	err = api.BatchArchiveLineItems([]string{"1", "2"})
+	if err != nil {
+		t.Errorf("BatchArchiveLineItems returned an error: %s", err.Error())
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 6 {
+		t.Errorf("Expected 6 calls to HubSpot API")
+	}
+}
@@ -0,0 +1,336 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotListAPI interface {
+	CreateStaticList(name, objectType string) (*List, error)
+	CreateDynamicList(name, objectType string, filterGroups [][]ListFilter) (*List, error)
+	GetList(listId string) (*List, error)
+	DeleteList(listId string) error
+	SearchLists(name string) ([]List, error)
+	GetListMembershipsPage(listId, after string) (*ListMembershipsPage, error)
+	GetListMemberships(listId string) ([]ListMembership, error)
+	AddToList(listId string, recordIds []string) (*ListMembershipChange, error)
+	RemoveFromList(listId string, recordIds []string) (*ListMembershipChange, error)
+}
+
+// HubspotListAPI is the structure to interact with HubSpot Lists API
+type HubspotListAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+}
+
+// List processing types, static lists are "MANUAL" and filter based lists "DYNAMIC"
+const (
+	ListManual   = "MANUAL"
+	ListDynamic  = "DYNAMIC"
+	ListSnapshot = "SNAPSHOT"
+)
+
+// listMembershipLimit is the maximum number of records that can be added to or removed from a list in a single request
+const listMembershipLimit = 100000
+
+// List is a representation of a HubSpot list, FilterBranch holds the filters of dynamic lists as returned by HubSpot
+type List struct {
+	ListId         string          `json:"listId"`
+	Name           string          `json:"name"`
+	ObjectTypeId   string          `json:"objectTypeId"`
+	ProcessingType string          `json:"processingType"`
+	Size           int             `json:"size"`
+	CreatedAt      string          `json:"createdAt"`
+	UpdatedAt      string          `json:"updatedAt"`
+	FilterBranch   json.RawMessage `json:"filterBranch,omitempty"`
+}
+
+// ListFilter is a filter on a property of the records of a dynamic list
+// OperationType is the type of the property, e.g. "STRING", "MULTISTRING", "NUMBER" or "BOOL",
+// Operator is one of the operators of that type, e.g. "IS_EQUAL_TO" or "CONTAINS".
+// Values is used instead of Value for the operation types taking multiple values.
+type ListFilter struct {
+	Property      string
+	OperationType string
+	Operator      string
+	Value         string
+	Values        []string
+}
+
+// ListMembership is a record that is a member of a list
+type ListMembership struct {
+	RecordId            string `json:"recordId"`
+	MembershipTimestamp string `json:"membershipTimestamp"`
+}
+
+// ListMembershipsPage is a page of the members of a list, After is "" on the last page
+type ListMembershipsPage struct {
+	Results []ListMembership
+	After   string
+}
+
+// ListMembershipChange is the result of adding records to or removing records from a list
+// Missing are the ids of the records that do not exist.
+type ListMembershipChange struct {
+	Added   []string `json:"recordIdsAdded"`
+	Removed []string `json:"recordIdsRemoved"`
+	Missing []string `json:"recordIdsMissing"`
+}
+
+type listFilterOperation struct {
+	OperationType                string   `json:"operationType"`
+	Operator                     string   `json:"operator"`
+	Value                        string   `json:"value,omitempty"`
+	Values                       []string `json:"values,omitempty"`
+	IncludeObjectsWithNoValueSet bool     `json:"includeObjectsWithNoValueSet"`
+}
+
+type listFilterRequest struct {
+	FilterType string              `json:"filterType"`
+	Property   string              `json:"property"`
+	Operation  listFilterOperation `json:"operation"`
+}
+
+type listFilterBranch struct {
+	FilterBranchType string              `json:"filterBranchType"`
+	FilterBranches   []listFilterBranch  `json:"filterBranches"`
+	Filters          []listFilterRequest `json:"filters"`
+}
+
+type listCreationRequest struct {
+	Name           string            `json:"name"`
+	ObjectTypeId   string            `json:"objectTypeId"`
+	ProcessingType string            `json:"processingType"`
+	FilterBranch   *listFilterBranch `json:"filterBranch,omitempty"`
+}
+
+type listResponse struct {
+	List List `json:"list"`
+}
+
+type listSearchRequest struct {
+	Query  string `json:"query"`
+	Offset int    `json:"offset"`
+	Count  int    `json:"count"`
+}
+
+type listSearchResponse struct {
+	Lists   []List `json:"lists"`
+	HasMore bool   `json:"hasMore"`
+	Offset  int    `json:"offset"`
+}
+
+type listMembershipsResponse struct {
+	Results []ListMembership `json:"results"`
+	Paging  *Paging          `json:"paging"`
+}
+
+// NewHubspotListAPI creates new HubspotListAPI with API key
+func NewHubspotListAPI(apiKey string) HubspotListAPI {
+	return NewHubspotListAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotListAPIWithClient creates new HubspotListAPI with API key and HTTP client
+func NewHubspotListAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotListAPI {
+	return HubspotListAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (api HubspotListAPI) url(path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("hapikey", api.APIKey)
+
+	return fmt.Sprintf("https://api.hubapi.com/crm/v3/lists%s?%s", path, query.Encode())
+}
+
+// newListFilterBranch returns the filter branch of a dynamic list matching any of the filter groups,
+// records match a filter group when they match all of its filters
+func newListFilterBranch(filterGroups [][]ListFilter) *listFilterBranch {
+	root := listFilterBranch{
+		FilterBranchType: "OR",
+		FilterBranches:   make([]listFilterBranch, len(filterGroups)),
+		Filters:          []listFilterRequest{},
+	}
+
+	for i, filterGroup := range filterGroups {
+		branch := listFilterBranch{
+			FilterBranchType: "AND",
+			FilterBranches:   []listFilterBranch{},
+			Filters:          make([]listFilterRequest, len(filterGroup)),
+		}
+		for j, filter := range filterGroup {
+			branch.Filters[j] = listFilterRequest{
+				FilterType: "PROPERTY",
+				Property:   filter.Property,
+				Operation: listFilterOperation{
+					OperationType: filter.OperationType,
+					Operator:      filter.Operator,
+					Value:         filter.Value,
+					Values:        filter.Values,
+				},
+			}
+		}
+		root.FilterBranches[i] = branch
+	}
+
+	return &root
+}
+
+func (api HubspotListAPI) createList(request listCreationRequest) (*List, error) {
+	log.Infof("Creating %s list '%s'", request.ProcessingType, request.Name)
+
+	var response listResponse
+	err := doJSONRequest(api.httpClient, "POST", api.url("", nil), request, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.List, nil
+}
+
+// CreateStaticList creates a list of records of an object type, e.g. "contacts", whose members are added and removed manually
+func (api HubspotListAPI) CreateStaticList(name, objectType string) (*List, error) {
+	return api.createList(listCreationRequest{
+		Name:           name,
+		ObjectTypeId:   objectTypeId(objectType),
+		ProcessingType: ListManual,
+	})
+}
+
+// CreateDynamicList creates a list of the records of an object type matching any of the filter groups,
+// records match a filter group when they match all of its filters
+func (api HubspotListAPI) CreateDynamicList(name, objectType string, filterGroups [][]ListFilter) (*List, error) {
+	return api.createList(listCreationRequest{
+		Name:           name,
+		ObjectTypeId:   objectTypeId(objectType),
+		ProcessingType: ListDynamic,
+		FilterBranch:   newListFilterBranch(filterGroups),
+	})
+}
+
+// GetList returns the list with the given id
+func (api HubspotListAPI) GetList(listId string) (*List, error) {
+	query := url.Values{}
+	query.Set("includeFilters", "true")
+
+	var response listResponse
+	err := doJSONRequest(api.httpClient, "GET", api.url("/"+listId, query), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.List, nil
+}
+
+// DeleteList deletes the list with the given id, the records of the list are not deleted
+func (api HubspotListAPI) DeleteList(listId string) error {
+	log.Infof("Deleting list '%s'", listId)
+
+	return doJSONRequest(api.httpClient, "DELETE", api.url("/"+listId, nil), nil, nil)
+}
+
+// SearchLists returns all the lists whose name contains the given name
+func (api HubspotListAPI) SearchLists(name string) ([]List, error) {
+	request := listSearchRequest{Query: name, Count: 100}
+
+	lists := []List{}
+	for {
+		var response listSearchResponse
+		err := doJSONRequest(api.httpClient, "POST", api.url("/search", nil), request, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		lists = append(lists, response.Lists...)
+
+		if !response.HasMore || len(response.Lists) == 0 {
+			return lists, nil
+		}
+		request.Offset = response.Offset
+	}
+}
+
+// GetListMembershipsPage returns the page of members of a list after the given cursor, "" for the first page
+func (api HubspotListAPI) GetListMembershipsPage(listId, after string) (*ListMembershipsPage, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(listPageLimit))
+	if after != "" {
+		query.Set("after", after)
+	}
+
+	var response listMembershipsResponse
+	err := doJSONRequest(api.httpClient, "GET", api.url(fmt.Sprintf("/%s/memberships", listId), query), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	page := ListMembershipsPage{Results: response.Results}
+	if response.Paging != nil {
+		page.After = response.Paging.Next["after"]
+	}
+
+	return &page, nil
+}
+
+// GetListMemberships returns all the members of a list
+func (api HubspotListAPI) GetListMemberships(listId string) ([]ListMembership, error) {
+	memberships := []ListMembership{}
+	after := ""
+	for {
+		page, err := api.GetListMembershipsPage(listId, after)
+		if err != nil {
+			return nil, err
+		}
+
+		memberships = append(memberships, page.Results...)
+
+		if page.After == "" {
+			return memberships, nil
+		}
+		after = page.After
+	}
+}
+
+// AddToList adds the records with the given ids to a static list, in as many requests as needed
+func (api HubspotListAPI) AddToList(listId string, recordIds []string) (*ListMembershipChange, error) {
+	log.Infof("Adding %d records to list '%s'", len(recordIds), listId)
+
+	return api.changeMemberships(listId, "add", recordIds)
+}
+
+// RemoveFromList removes the records with the given ids from a static list, in as many requests as needed
+func (api HubspotListAPI) RemoveFromList(listId string, recordIds []string) (*ListMembershipChange, error) {
+	log.Infof("Removing %d records from list '%s'", len(recordIds), listId)
+
+	return api.changeMemberships(listId, "remove", recordIds)
+}
+
+func (api HubspotListAPI) changeMemberships(listId, operation string, recordIds []string) (*ListMembershipChange, error) {
+	change := ListMembershipChange{Added: []string{}, Removed: []string{}, Missing: []string{}}
+	for start := 0; start < len(recordIds); start += listMembershipLimit {
+		end := start + listMembershipLimit
+		if end > len(recordIds) {
+			end = len(recordIds)
+		}
+
+		var response ListMembershipChange
+		err := doJSONRequest(api.httpClient, "PUT", api.url(fmt.Sprintf("/%s/memberships/%s", listId, operation), nil), recordIds[start:end], &response)
+		if err != nil {
+			return &change, err
+		}
+
+		change.Added = append(change.Added, response.Added...)
+		change.Removed = append(change.Removed, response.Removed...)
+		change.Missing = append(change.Missing, response.Missing...)
+	}
+
+	return &change, nil
+}
@@ -0,0 +1,193 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockListAPI(mockClient *IHTTPClientMock) HubspotListAPI {
+	return HubspotListAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+func TestCreateDynamicList(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "POST https://api.hubapi.com/crm/v3/lists?hapikey=api_key" {
+				var request listCreationRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := listCreationRequest{
+					Name:           "Founders",
+					ObjectTypeId:   "0-1",
+					ProcessingType: "DYNAMIC",
+					FilterBranch: &listFilterBranch{
+						FilterBranchType: "OR",
+						Filters:          []listFilterRequest{},
+						FilterBranches: []listFilterBranch{
+							{
+								FilterBranchType: "AND",
+								FilterBranches:   []listFilterBranch{},
+								Filters: []listFilterRequest{
+									{
+										FilterType: "PROPERTY",
+										Property:   "jobtitle",
+										Operation:  listFilterOperation{OperationType: "MULTISTRING", Operator: "CONTAINS", Values: []string{"founder", "ceo"}},
+									},
+								},
+							},
+						},
+					},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected list creation request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 200, listResponse{List: List{ListId: "42", Name: "Founders", ProcessingType: "DYNAMIC"}})
+			} else {
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockListAPI(&mockHubspotHTTPClient)
+
+	list, err := api.CreateDynamicList("Founders", "contacts", [][]ListFilter{
+		{{Property: "jobtitle", OperationType: "MULTISTRING", Operator: "CONTAINS", Values: []string{"founder", "ceo"}}},
+	})
+	if err != nil {
+		t.Errorf("CreateDynamicList returned an error: %s", err.Error())
+		return
+	}
+
+	if list.ListId != "42" {
+		t.Errorf("CreateDynamicList returned incorrect list: %v", list)
+	}
+}
+
+func TestSearchLists(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "POST https://api.hubapi.com/crm/v3/lists/search?hapikey=api_key" {
+				var request listSearchRequest
+				readJSONRequest(t, req, &request)
+
+				if request.Query != "Investors" {
+					t.Errorf("Unexpected search query: %v", request)
+				}
+
+				if request.Offset == 0 {
+					writeJSONResponse(t, w, 200, listSearchResponse{Lists: []List{{ListId: "1"}}, HasMore: true, Offset: 1})
+				} else {
+					writeJSONResponse(t, w, 200, listSearchResponse{Lists: []List{{ListId: "2"}}, Offset: 2})
+				}
+			} else {
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockListAPI(&mockHubspotHTTPClient)
+
+	lists, err := api.SearchLists("Investors")
+	if err != nil {
+		t.Errorf("SearchLists returned an error: %s", err.Error())
+		return
+	}
+
+	if !cmp.Equal([]List{{ListId: "1"}, {ListId: "2"}}, lists) {
+		t.Errorf("SearchLists returned incorrect lists: %v", lists)
+	}
+}
+
+func TestGetListMemberships(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "https://api.hubapi.com/crm/v3/lists/42/memberships?hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, listMembershipsResponse{
+					Results: []ListMembership{{RecordId: "1"}},
+					Paging:  &Paging{Next: map[string]string{"after": "cursor"}},
+				})
+			case "https://api.hubapi.com/crm/v3/lists/42/memberships?after=cursor&hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, listMembershipsResponse{Results: []ListMembership{{RecordId: "2"}}})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockListAPI(&mockHubspotHTTPClient)
+
+	memberships, err := api.GetListMemberships("42")
+	if err != nil {
+		t.Errorf("GetListMemberships returned an error: %s", err.Error())
+		return
+	}
+
+	if !cmp.Equal([]ListMembership{{RecordId: "1"}, {RecordId: "2"}}, memberships) {
+		t.Errorf("GetListMemberships returned incorrect memberships: %v", memberships)
+	}
+}
+
+func TestAddToList(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "PUT https://api.hubapi.com/crm/v3/lists/42/memberships/add?hapikey=api_key" {
+				var ids []string
+				readJSONRequest(t, req, &ids)
+
+				if !cmp.Equal([]string{"1", "2"}, ids) {
+					t.Errorf("Unexpected record ids: %v", ids)
+				}
+
+				writeJSONResponse(t, w, 200, map[string][]string{"recordIdsAdded": {"1"}, "recordIdsMissing": {"2"}})
+			} else {
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockListAPI(&mockHubspotHTTPClient)
+
+	change, err := api.AddToList("42", []string{"1", "2"})
+	if err != nil {
+		t.Errorf("AddToList returned an error: %s", err.Error())
+		return
+	}
+
+	expectedChange := ListMembershipChange{Added: []string{"1"}, Removed: []string{}, Missing: []string{"2"}}
+	if !cmp.Equal(expectedChange, *change) {
+		t.Errorf("AddToList returned incorrect change, expected:\n%v\ngot:\n%v", expectedChange, *change)
+	}
+}
@@ -0,0 +1,271 @@
+package go_hubspot
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// MirrorObject is an object type to mirror, with the properties to copy and the object types of the associations to copy
+type MirrorObject struct {
+	ObjectType   string
+	Properties   []string
+	Associations []string
+}
+
+// Mirror keeps a local copy of HubSpot records in Store, so that they can be queried without calling HubSpot
+// Load copies all the records of the mirrored object types, and Sync the records modified since,
+// using a change feed whose checkpoints are kept in Checkpoints.
+//
+// Associations are copied with the records, but associating records does not always modify them,
+// and deleted records stay in the mirror, a periodic Load brings the mirror fully up to date.
+type Mirror struct {
+	Store       MirrorStore
+	Checkpoints CheckpointStore
+	Objects     []MirrorObject
+	ClockSkew   time.Duration
+
+	apiKey     string
+	httpClient IHTTPClient
+	now        func() time.Time
+}
+
+// listedObject is a representation of an object listed with its associations
+type listedObject struct {
+	Id           string                  `json:"id"`
+	Properties   map[string]string       `json:"properties"`
+	UpdatedAt    string                  `json:"updatedAt"`
+	Associations map[string]Associations `json:"associations"`
+}
+
+// Mirror creates a mirror of the given object types into store, keeping its checkpoints in checkpoints
+func (api HubspotCRMAPI) Mirror(store MirrorStore, checkpoints CheckpointStore, objects ...MirrorObject) *Mirror {
+	return &Mirror{
+		Store:       store,
+		Checkpoints: checkpoints,
+		Objects:     objects,
+		ClockSkew:   defaultChangeFeedClockSkew,
+		apiKey:      api.APIKey,
+		httpClient:  api.httpClient,
+		now:         time.Now,
+	}
+}
+
+// feedName returns the name of the checkpoint of the change feed of an object type
+func (m *Mirror) feedName(objectType string) string {
+	return "mirror:" + objectType
+}
+
+func (m *Mirror) currentTime() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// uniqueIds returns the ids without duplicates, in their original order
+func uniqueIds(ids []string) []string {
+	unique := []string{}
+	seen := map[string]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// Load copies all the records of the mirrored object types, replacing the records in the mirror
+func (m *Mirror) Load() error {
+	for _, object := range m.Objects {
+		err := m.load(object)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Mirror) load(object MirrorObject) error {
+	log.Infof("Loading all %s into the mirror", object.ObjectType)
+
+	// The records modified while loading are synced again from the time the load started
+	started := m.currentTime()
+
+	objects := crmObjects{apiKey: m.apiKey, httpClient: m.httpClient, objectType: object.ObjectType}
+	raw, err := objects.listAssociated(object.Properties, object.Associations)
+	if err != nil {
+		return err
+	}
+
+	listed := []listedObject{}
+	err = unmarshalAll(raw, &listed)
+	if err != nil {
+		return err
+	}
+
+	records := make([]MirrorRecord, len(listed))
+	for i, o := range listed {
+		records[i] = MirrorRecord{Id: o.Id, Properties: o.Properties}
+		if updatedAt, err := parseHubSpotTime(o.UpdatedAt); err == nil {
+			records[i].UpdatedAt = updatedAt
+		}
+		if len(object.Associations) > 0 {
+			records[i].Associations = map[string][]string{}
+			for _, toObjectType := range object.Associations {
+				ids := []string{}
+				for _, association := range o.Associations[toObjectType].Results {
+					ids = append(ids, association.Id)
+				}
+				records[i].Associations[toObjectType] = uniqueIds(ids)
+			}
+		}
+	}
+
+	err = m.Store.Replace(object.ObjectType, records)
+	if err != nil {
+		return err
+	}
+
+	return m.Checkpoints.SaveCheckpoint(m.feedName(object.ObjectType), ChangeCheckpoint{LastModified: started.Add(-m.ClockSkew)})
+}
+
+// Sync copies the records of the mirrored object types modified since the last Load or Sync, and returns their number
+// The object types that were never loaded are loaded in full.
+func (m *Mirror) Sync() (int, error) {
+	synced := 0
+	for _, object := range m.Objects {
+		count, err := m.sync(object)
+		synced += count
+		if err != nil {
+			return synced, err
+		}
+	}
+
+	return synced, nil
+}
+
+func (m *Mirror) sync(object MirrorObject) (int, error) {
+	name := m.feedName(object.ObjectType)
+
+	before, err := m.Checkpoints.LoadCheckpoint(name)
+	if err != nil {
+		return 0, err
+	}
+	if before == nil {
+		return 0, m.load(object)
+	}
+
+	feed := HubspotCRMAPI{APIKey: m.apiKey, httpClient: m.httpClient}.ChangeFeed(object.ObjectType, object.Properties, m.Checkpoints)
+	feed.Name = name
+	feed.ClockSkew = m.ClockSkew
+	feed.now = m.currentTime
+
+	// The records of each search batch are stored before the feed saves its checkpoint past them
+	stored := 0
+	records := []MirrorRecord{}
+	feed.flush = func() error {
+		if len(records) == 0 {
+			return nil
+		}
+
+		err := m.addAssociations(object, records)
+		if err != nil {
+			return err
+		}
+		err = m.Store.Put(object.ObjectType, records)
+		if err != nil {
+			return err
+		}
+
+		stored += len(records)
+		records = []MirrorRecord{}
+		return nil
+	}
+
+	_, err = feed.Poll(func(change Change) error {
+		records = append(records, MirrorRecord{Id: change.Record.Id, Properties: change.Record.Properties, UpdatedAt: change.LastModified})
+		return nil
+	})
+
+	return stored, err
+}
+
+// addAssociations reads the associations of the records
+func (m *Mirror) addAssociations(object MirrorObject, records []MirrorRecord) error {
+	if len(object.Associations) == 0 || len(records) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(records))
+	for i, record := range records {
+		ids[i] = record.Id
+		records[i].Associations = map[string][]string{}
+	}
+
+	for _, toObjectType := range object.Associations {
+		associated, err := batchReadAssociations(m.httpClient, m.apiKey, object.ObjectType, toObjectType, ids)
+		if err != nil {
+			return err
+		}
+
+		for i, record := range records {
+			records[i].Associations[toObjectType] = uniqueIds(associated[record.Id])
+		}
+	}
+
+	return nil
+}
+
+// Run syncs the mirror every interval, until stop is closed
+func (m *Mirror) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := m.Sync()
+		if err != nil {
+			log.Errorf("Failed to sync the mirror: %s", err.Error())
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Get returns the mirrored record of an object type with the given id, or nil if there is none
+func (m *Mirror) Get(objectType, id string) (*MirrorRecord, error) {
+	return m.Store.Get(objectType, id)
+}
+
+// Search returns the mirrored records of an object type matching the query, with the same semantics as HubSpot search
+func (m *Mirror) Search(objectType string, query SearchQuery) ([]MirrorRecord, error) {
+	records, err := m.Store.All(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []HubSpotSearchResult{}
+	byId := map[string]MirrorRecord{}
+	for _, record := range records {
+		if query.matches(record.Properties) {
+			results = append(results, HubSpotSearchResult{Id: record.Id, Properties: record.Properties})
+			byId[record.Id] = record
+		}
+	}
+
+	// The records are ordered by id unless the query sorts them
+	query.sortResults(results)
+
+	matches := make([]MirrorRecord, len(results))
+	for i, result := range results {
+		matches[i] = byId[result.Id]
+	}
+
+	return matches, nil
+}
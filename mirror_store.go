@@ -0,0 +1,211 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MirrorRecord is the local copy of a HubSpot record, Associations are the ids of the associated records by object type
+type MirrorRecord struct {
+	Id           string              `json:"id"`
+	Properties   map[string]string   `json:"properties"`
+	Associations map[string][]string `json:"associations,omitempty"`
+	UpdatedAt    time.Time           `json:"updatedAt"`
+}
+
+// MirrorStore keeps the local copies of HubSpot records by object type, implementations must be safe for concurrent use
+type MirrorStore interface {
+	// Replace replaces all the records of an object type
+	Replace(objectType string, records []MirrorRecord) error
+	// Put creates or replaces records of an object type
+	Put(objectType string, records []MirrorRecord) error
+	// Get returns the record of an object type with the given id, or nil if there is none
+	Get(objectType, id string) (*MirrorRecord, error)
+	// All returns all the records of an object type, ordered by id
+	All(objectType string) ([]MirrorRecord, error)
+}
+
+// mirrorRecords are the records of an object type by id
+type mirrorRecords map[string]MirrorRecord
+
+func newMirrorRecords(records []MirrorRecord) mirrorRecords {
+	byId := mirrorRecords{}
+	for _, record := range records {
+		byId[record.Id] = record
+	}
+	return byId
+}
+
+func (records mirrorRecords) all() []MirrorRecord {
+	all := make([]MirrorRecord, 0, len(records))
+	for _, record := range records {
+		all = append(all, record)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return compareRecordIds(all[i].Id, all[j].Id) < 0
+	})
+
+	return all
+}
+
+// MemoryMirrorStore is a MirrorStore keeping records in memory
+type MemoryMirrorStore struct {
+	mu      sync.RWMutex
+	objects map[string]mirrorRecords
+}
+
+// NewMemoryMirrorStore creates an empty MemoryMirrorStore
+func NewMemoryMirrorStore() *MemoryMirrorStore {
+	return &MemoryMirrorStore{objects: map[string]mirrorRecords{}}
+}
+
+// Replace replaces all the records of an object type
+func (s *MemoryMirrorStore) Replace(objectType string, records []MirrorRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[objectType] = newMirrorRecords(records)
+	return nil
+}
+
+// Put creates or replaces records of an object type
+func (s *MemoryMirrorStore) Put(objectType string, records []MirrorRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.objects[objectType] == nil {
+		s.objects[objectType] = mirrorRecords{}
+	}
+	for _, record := range records {
+		s.objects[objectType][record.Id] = record
+	}
+
+	return nil
+}
+
+// Get returns the record of an object type with the given id, or nil if there is none
+func (s *MemoryMirrorStore) Get(objectType, id string) (*MirrorRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.objects[objectType][id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &record, nil
+}
+
+// All returns all the records of an object type, ordered by id
+func (s *MemoryMirrorStore) All(objectType string) ([]MirrorRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.objects[objectType].all(), nil
+}
+
+// FileMirrorStore is a MirrorStore keeping the records of each object type in a JSON file of a directory,
+// e.g. "companies.json". The records are held in memory and a file is rewritten atomically when its records change,
+// it suits tests and small deployments.
+type FileMirrorStore struct {
+	memory *MemoryMirrorStore
+	dir    string
+	mu     sync.Mutex
+}
+
+// NewFileMirrorStore creates a FileMirrorStore with the records stored in dir, which is created if needed
+func NewFileMirrorStore(dir string) (*FileMirrorStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	store := FileMirrorStore{memory: NewMemoryMirrorStore(), dir: dir}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var records []MirrorRecord
+		err = json.Unmarshal(data, &records)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("Error while reading mirrored records from '%s': %s", path, err.Error()))
+		}
+
+		objectType := filepath.Base(path)
+		objectType = objectType[:len(objectType)-len(".json")]
+		store.memory.objects[objectType] = newMirrorRecords(records)
+	}
+
+	return &store, nil
+}
+
+// save writes the new records of an object type to its file, and keeps them in memory only once they are written
+func (s *FileMirrorStore) save(objectType string, records mirrorRecords) error {
+	data, err := json.Marshal(records.all())
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(filepath.Join(s.dir, objectType+".json"), data)
+	if err != nil {
+		return err
+	}
+
+	s.memory.mu.Lock()
+	defer s.memory.mu.Unlock()
+
+	s.memory.objects[objectType] = records
+	return nil
+}
+
+// Replace replaces all the records of an object type
+func (s *FileMirrorStore) Replace(objectType string, records []MirrorRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.save(objectType, newMirrorRecords(records))
+}
+
+// Put creates or replaces records of an object type
+func (s *FileMirrorStore) Put(objectType string, records []MirrorRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, err := s.memory.All(objectType)
+	if err != nil {
+		return err
+	}
+
+	updated := newMirrorRecords(existing)
+	for _, record := range records {
+		updated[record.Id] = record
+	}
+
+	return s.save(objectType, updated)
+}
+
+// Get returns the record of an object type with the given id, or nil if there is none
+func (s *FileMirrorStore) Get(objectType, id string) (*MirrorRecord, error) {
+	return s.memory.Get(objectType, id)
+}
+
+// All returns all the records of an object type, ordered by id
+func (s *FileMirrorStore) All(objectType string) ([]MirrorRecord, error) {
+	return s.memory.All(objectType)
+}
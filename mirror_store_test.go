@@ -0,0 +1,95 @@
+package go_hubspot
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFileMirrorStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileMirrorStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	updatedAt := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	err = store.Replace("companies", []MirrorRecord{
+		{Id: "10", Properties: map[string]string{"name": "Acme"}, UpdatedAt: updatedAt},
+		{Id: "9", Properties: map[string]string{"name": "Globex"}, Associations: map[string][]string{"contacts": {"1"}}, UpdatedAt: updatedAt},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	err = store.Put("companies", []MirrorRecord{{Id: "10", Properties: map[string]string{"name": "Acme Corp"}, UpdatedAt: updatedAt}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	reopened, err := NewFileMirrorStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	records, err := reopened.All("companies")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	expectedRecords := []MirrorRecord{
+		{Id: "9", Properties: map[string]string{"name": "Globex"}, Associations: map[string][]string{"contacts": {"1"}}, UpdatedAt: updatedAt},
+		{Id: "10", Properties: map[string]string{"name": "Acme Corp"}, UpdatedAt: updatedAt},
+	}
+	if !cmp.Equal(expectedRecords, records) {
+		t.Errorf("Unexpected records, expected:\n%v\ngot:\n%v", expectedRecords, records)
+	}
+
+	missing, err := reopened.Get("deals", "1")
+	if err != nil || missing != nil {
+		t.Errorf("Unexpected record %v, %v", missing, err)
+	}
+}
+
+func TestFileMirrorStoreWriteFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mirror")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileMirrorStore(dir)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	acme := MirrorRecord{Id: "1", Properties: map[string]string{"name": "Acme"}}
+	if err := store.Put("companies", []MirrorRecord{acme}); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	// The store files cannot be written while their directory is missing
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put("companies", []MirrorRecord{{Id: "2", Properties: map[string]string{"name": "Globex"}}}); err == nil {
+		t.Errorf("Expected an error putting a record")
+	}
+	if err := store.Replace("companies", []MirrorRecord{}); err == nil {
+		t.Errorf("Expected an error replacing the records")
+	}
+
+	// Nothing that failed to be written is kept in memory
+	records, err := store.All("companies")
+	if err != nil || !cmp.Equal([]MirrorRecord{acme}, records) {
+		t.Errorf("Unexpected records %v, %v", records, err)
+	}
+}
@@ -0,0 +1,200 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMirrorLoadAndSync(t *testing.T) {
+	base := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := base.Add(time.Hour)
+
+	modified := []HubSpotSearchResult{}
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/objects/companies?associations=contacts&hapikey=api_key&limit=100&properties=name%2Cdomain":
+				writeJSONResponse(t, w, 200, map[string]interface{}{
+					"results": []map[string]interface{}{
+						{
+							"id":         "1",
+							"properties": map[string]string{"name": "Acme", "domain": "acme.com"},
+							"updatedAt":  "2021-01-01T12:00:00Z",
+							"associations": map[string]interface{}{
+								"contacts": map[string]interface{}{
+									"results": []Association{{"101", "company_to_contact"}, {"101", "primary"}, {"102", "company_to_contact"}},
+								},
+							},
+						},
+						{
+							"id":         "2",
+							"properties": map[string]string{"name": "Globex", "domain": "globex.com"},
+							"updatedAt":  "2021-01-01T12:00:00Z",
+						},
+					},
+				})
+			case "POST https://api.hubapi.com/crm/v3/objects/companies/search?hapikey=api_key":
+				var request searchPageRequest
+				readJSONRequest(t, req, &request)
+
+				expectedFilters := []SearchFilter{
+					{PropertyName: "hs_lastmodifieddate", Operator: "LTE", Value: formatHubSpotTime(now.Add(-time.Minute))},
+					{PropertyName: "hs_lastmodifieddate", Operator: "GTE", Value: formatHubSpotTime(base.Add(59 * time.Minute))},
+				}
+				if !cmp.Equal(expectedFilters, request.FilterGroups[0].Filters) {
+					t.Errorf("Unexpected search filters, expected:\n%v\ngot:\n%v", expectedFilters, request.FilterGroups[0].Filters)
+				}
+
+				writeJSONResponse(t, w, 200, searchPageResponse{Total: len(modified), Results: modified})
+			case "POST https://api.hubapi.com/crm/v3/associations/companies/contacts/batch/read?hapikey=api_key":
+				var request batchReadRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := batchReadRequest{Inputs: []batchId{{"2"}, {"3"}}}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected associations request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				w.WriteHeader(207)
+				_, _ = w.WriteString(`{"results": [{"from": {"id": "2"}, "to": [{"id": "201", "type": "company_to_contact"}]}]}`)
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := HubspotCRMAPI{APIKey: "api_key", httpClient: &mockHubspotHTTPClient}
+	mirror := api.Mirror(NewMemoryMirrorStore(), NewMemoryCheckpointStore(), MirrorObject{
+		ObjectType:   "companies",
+		Properties:   []string{"name", "domain"},
+		Associations: []string{"contacts"},
+	})
+	mirror.now = func() time.Time { return now }
+
+	// The first sync loads all the companies
+	_, err := mirror.Sync()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	acme, _ := mirror.Get("companies", "1")
+	expectedAcme := MirrorRecord{
+		Id:           "1",
+		Properties:   map[string]string{"name": "Acme", "domain": "acme.com"},
+		Associations: map[string][]string{"contacts": {"101", "102"}},
+		UpdatedAt:    base,
+	}
+	if !cmp.Equal(&expectedAcme, acme) {
+		t.Errorf("Unexpected record, expected:\n%v\ngot:\n%v", expectedAcme, acme)
+	}
+
+	// The next sync copies the companies modified since the load
+	modified = []HubSpotSearchResult{
+		{Id: "2", Properties: map[string]string{"name": "Globex Corp", "domain": "globex.com", "hs_lastmodifieddate": base.Add(62 * time.Minute).Format(time.RFC3339)}},
+		{Id: "3", Properties: map[string]string{"name": "Initech", "domain": "initech.com", "hs_lastmodifieddate": base.Add(63 * time.Minute).Format(time.RFC3339)}},
+	}
+	now = now.Add(5 * time.Minute)
+
+	count, err := mirror.Sync()
+	if err != nil || count != 2 {
+		t.Fatalf("Unexpected Sync result %d, %v", count, err)
+	}
+
+	results, err := mirror.Search("companies", SearchQuery{
+		Filters: []SearchFilter{{PropertyName: "domain", Operator: "NEQ", Value: "acme.com"}},
+		Sorts:   []SearchSort{{PropertyName: "name", Direction: "DESCENDING"}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	names := []string{}
+	for _, result := range results {
+		names = append(names, result.Properties["name"])
+	}
+	if !cmp.Equal([]string{"Initech", "Globex Corp"}, names) {
+		t.Errorf("Unexpected search results %v", names)
+	}
+
+	globex, _ := mirror.Get("companies", "2")
+	if !cmp.Equal(map[string][]string{"contacts": {"201"}}, globex.Associations) {
+		t.Errorf("Unexpected associations %v", globex.Associations)
+	}
+	initech, _ := mirror.Get("companies", "3")
+	if initech == nil || !cmp.Equal(map[string][]string{"contacts": {}}, initech.Associations) {
+		t.Errorf("Unexpected record %v", initech)
+	}
+}
+
+// failingMirrorStore is a MemoryMirrorStore whose Put fails from the given call on
+type failingMirrorStore struct {
+	*MemoryMirrorStore
+	puts   int
+	failAt int
+}
+
+func (s *failingMirrorStore) Put(objectType string, records []MirrorRecord) error {
+	s.puts++
+	if s.failAt > 0 && s.puts >= s.failAt {
+		return errors.New("disk full")
+	}
+	return s.MemoryMirrorStore.Put(objectType, records)
+}
+
+func TestMirrorSyncStoreFailure(t *testing.T) {
+	base := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// More records than a single search can return, so that they are synced in two batches
+	records := []HubSpotSearchResult{}
+	for i := 0; i < crm.SearchResultLimit+50; i++ {
+		records = append(records, company(strconv.Itoa(i+1), base, base.Add(time.Duration(i)*time.Second)))
+	}
+	requests := 0
+
+	api := HubspotCRMAPI{APIKey: "api_key", httpClient: mockSearchClient(t, &records, &requests)}
+	store := &failingMirrorStore{MemoryMirrorStore: NewMemoryMirrorStore(), failAt: 2}
+	checkpoints := NewMemoryCheckpointStore()
+	mirror := api.Mirror(store, checkpoints, MirrorObject{ObjectType: "companies", Properties: []string{"name"}})
+	mirror.now = func() time.Time { return base.Add(24 * time.Hour) }
+
+	_ = checkpoints.SaveCheckpoint(mirror.feedName("companies"), ChangeCheckpoint{LastModified: base.Add(-time.Second)})
+
+	// The checkpoint only moves past the batches that were stored
+	count, err := mirror.Sync()
+	if err == nil || count != crm.SearchResultLimit-1 {
+		t.Fatalf("Unexpected Sync result %d, %v", count, err)
+	}
+
+	checkpoint, _ := checkpoints.LoadCheckpoint(mirror.feedName("companies"))
+	stored, _ := store.All("companies")
+	last := records[len(stored)-1]
+	if checkpoint == nil || checkpoint.Id != last.Id {
+		t.Errorf("Expected the checkpoint to be at the last stored record %s, got %v", last.Id, checkpoint)
+	}
+
+	// The next sync stores the records that were not stored
+	store.failAt = 0
+	count, err = mirror.Sync()
+	if err != nil || count != len(records)-len(stored) {
+		t.Fatalf("Unexpected Sync result %d, %v", count, err)
+	}
+
+	stored, _ = store.All("companies")
+	if len(stored) != len(records) {
+		t.Errorf("Expected all %d records to be stored, got %d", len(records), len(stored))
+	}
+}
@@ -5,3 +5,13 @@ package go_hubspot
 //go:generate moq -out dealflow_mock.go . IHubspotDealFlowAPI
 //go:generate moq -out form_mock.go . IHubspotFormAPI
 //go:generate moq -out file_mock.go . IHubspotFileAPI
+//go:generate moq -out lineitem_mock.go . IHubspotLineItemAPI
+//go:generate moq -out product_mock.go . IHubspotProductAPI
+//go:generate moq -out ticket_mock.go . IHubspotTicketAPI
+//go:generate moq -out engagement_mock.go . IHubspotEngagementAPI
+//go:generate moq -out owner_mock.go . IHubspotOwnerAPI
+//go:generate moq -out gdpr_mock.go . IHubspotGDPRAPI
+//go:generate moq -out import_mock.go . IHubspotImportAPI
+//go:generate moq -out export_mock.go . IHubspotExportAPI
+//go:generate moq -out list_mock.go . IHubspotListAPI
+//go:generate moq -out webhook_mock.go . IHubspotWebhookAPI
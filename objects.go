@@ -0,0 +1,465 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	log "github.com/sirupsen/logrus"
+)
+
+// listPageLimit is the maximum number of objects HubSpot returns in a single page of a list
+const listPageLimit = 100
+
+// crmObjects makes the standard CRUD and batch requests for a CRM object type,
+// objects are returned as raw JSON for the typed clients to unmarshal
+type crmObjects struct {
+	apiKey     string
+	httpClient IHTTPClient
+	objectType string
+}
+
+type batchCreateRequest struct {
+	Inputs []objectCreationRequest `json:"inputs"`
+}
+
+type batchArchiveRequest struct {
+	Inputs []batchId `json:"inputs"`
+}
+
+type rawListResponse struct {
+	Results []json.RawMessage `json:"results"`
+	Paging  *Paging           `json:"paging"`
+}
+
+type rawBatchResponse struct {
+	Results []json.RawMessage `json:"results"`
+	Errors  []BatchError      `json:"errors"`
+}
+
+// errorsById maps the ids in the context of the batch errors to their error
+func (r rawBatchResponse) errorsById() map[string]error {
+	errs := map[string]error{}
+	for _, batchErr := range r.Errors {
+		for _, id := range batchErr.Context["ids"] {
+			errs[id] = batchErr
+		}
+	}
+	return errs
+}
+
+// PartialCreationFailure is returned when an object was created but could not be associated,
+// and archiving the object afterwards failed too, leaving the half-built object in HubSpot
+type PartialCreationFailure struct {
+	ObjectType     string
+	ObjectId       string
+	AssociationErr error
+	RollbackErr    error
+}
+
+func (e *PartialCreationFailure) Error() string {
+	return fmt.Sprintf(
+		"%s '%s' was created but could not be associated (%s), and archiving it failed: %s",
+		crm.SingularObjectType(e.ObjectType),
+		e.ObjectId,
+		e.AssociationErr.Error(),
+		e.RollbackErr.Error(),
+	)
+}
+
+// Unwrap returns the association error that caused the failure
+func (e *PartialCreationFailure) Unwrap() error {
+	return e.AssociationErr
+}
+
+// url returns the URL of the object type API, with path appended and the API key and query added
+func (o crmObjects) url(path string, query url.Values) string {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("hapikey", o.apiKey)
+
+	return fmt.Sprintf("https://api.hubapi.com/crm/v3/objects/%s%s?%s", o.objectType, path, query.Encode())
+}
+
+// create creates an object with the given properties and inline associations
+func (o crmObjects) create(request objectCreationRequest, result interface{}) error {
+	return doJSONRequest(o.httpClient, "POST", o.url("", nil), request, result)
+}
+
+// createAssociated creates an object with the given properties and associations, and unmarshals the created object into result
+// When all association types are known, the associations are made in the same request as the object creation.
+// Otherwise the associations are made separately, by type id when one is given and by label otherwise, and if any of them fails
+// the object is archived, so that no half-built objects are left behind. If archiving fails as well, a *PartialCreationFailure is returned.
+func (o crmObjects) createAssociated(properties map[string]string, associations []ObjectAssociation, result interface{}) error {
+	creationRequest := objectCreationRequest{
+		Properties: properties,
+	}
+
+	inlineAssociations := make([]inlineAssociation, 0, len(associations))
+	for _, association := range associations {
+		inline, ok := association.inline(o.objectType)
+		if !ok {
+			inlineAssociations = nil
+			break
+		}
+		inlineAssociations = append(inlineAssociations, inline)
+	}
+
+	if len(inlineAssociations) > 0 {
+		creationRequest.Associations = inlineAssociations
+	}
+
+	var rawResp json.RawMessage
+	err := o.create(creationRequest, &rawResp)
+	if err != nil {
+		return err
+	}
+
+	var created struct {
+		Id string `json:"id"`
+	}
+	err = json.Unmarshal(rawResp, &created)
+	if err != nil {
+		return err
+	}
+
+	if inlineAssociations == nil {
+		for _, association := range associations {
+			if inline, ok := association.inline(o.objectType); ok && association.TypeId != 0 {
+				err = o.associateWithTypes(created.Id, association.ObjectType, inline)
+			} else {
+				err = o.associate(created.Id, association.ObjectType, association.Id, association.label(o.objectType))
+			}
+			if err != nil {
+				return o.rollback(created.Id, err)
+			}
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(rawResp, result)
+}
+
+// associate associates the object with the given id with an object of toObjectType, using the association label
+func (o crmObjects) associate(id, toObjectType, toId, label string) error {
+	requestUrl := fmt.Sprintf(
+		"https://api.hubapi.com/crm/v3/associations/%s/%s/batch/create?hapikey=%s",
+		crm.SingularObjectType(o.objectType),
+		crm.SingularObjectType(toObjectType),
+		o.apiKey,
+	)
+
+	associationRequest := DealAssociationBatchRequest{
+		Inputs: []DealAssociation{
+			{
+				From: DealAssociationFromTo{Id: id},
+				To:   DealAssociationFromTo{Id: toId},
+				Type: label,
+			},
+		},
+	}
+
+	return doJSONRequest(o.httpClient, "POST", requestUrl, associationRequest, nil)
+}
+
+// typedAssociationBatchRequest is a representation of a request to the v4 associations API, referring to association types by id
+type typedAssociationBatchRequest struct {
+	Inputs []typedAssociation `json:"inputs"`
+}
+
+type typedAssociation struct {
+	From  inlineAssociationTo   `json:"from"`
+	To    inlineAssociationTo   `json:"to"`
+	Types []associationTypeSpec `json:"types"`
+}
+
+// associateWithTypes associates the object with the given id with an object of toObjectType, using the association type ids
+func (o crmObjects) associateWithTypes(id, toObjectType string, association inlineAssociation) error {
+	requestUrl := fmt.Sprintf(
+		"https://api.hubapi.com/crm/v4/associations/%s/%s/batch/create?hapikey=%s",
+		o.objectType,
+		toObjectType,
+		o.apiKey,
+	)
+
+	associationRequest := typedAssociationBatchRequest{
+		Inputs: []typedAssociation{
+			{
+				From:  inlineAssociationTo{Id: id},
+				To:    association.To,
+				Types: association.Types,
+			},
+		},
+	}
+
+	return doJSONRequest(o.httpClient, "POST", requestUrl, associationRequest, nil)
+}
+
+// rollback archives an object whose associations could not be created, and returns the error to report to the caller
+func (o crmObjects) rollback(id string, associationErr error) error {
+	log.Warnf("Failed to associate %s '%s', archiving it: %s", crm.SingularObjectType(o.objectType), id, associationErr.Error())
+
+	err := o.archive(id)
+	if err != nil {
+		return &PartialCreationFailure{
+			ObjectType:     o.objectType,
+			ObjectId:       id,
+			AssociationErr: associationErr,
+			RollbackErr:    err,
+		}
+	}
+
+	return fmt.Errorf("Failed to associate %s '%s', it has been archived: %w", crm.SingularObjectType(o.objectType), id, associationErr)
+}
+
+// get fetches the object with the given id and properties
+func (o crmObjects) get(id string, properties []string, result interface{}) error {
+	query := url.Values{}
+	if len(properties) > 0 {
+		query.Set("properties", strings.Join(properties, ","))
+	}
+
+	return doJSONRequest(o.httpClient, "GET", o.url("/"+id, query), nil, result)
+}
+
+// update updates the given properties of the object with the given id
+func (o crmObjects) update(id string, properties map[string]string, result interface{}) error {
+	return doJSONRequest(o.httpClient, "PATCH", o.url("/"+id, nil), dealUpdateRequest{properties}, result)
+}
+
+// archive archives the object with the given id
+func (o crmObjects) archive(id string) error {
+	return doJSONRequest(o.httpClient, "DELETE", o.url("/"+id, nil), nil, nil)
+}
+
+// batchCreate creates the given objects, in as many batch requests as needed
+func (o crmObjects) batchCreate(inputs []objectCreationRequest) ([]json.RawMessage, error) {
+	results := []json.RawMessage{}
+	for start := 0; start < len(inputs); start += batchLimit {
+		end := start + batchLimit
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+
+		var response rawBatchResponse
+		err := doJSONRequest(o.httpClient, "POST", o.url("/batch/create", nil), batchCreateRequest{inputs[start:end]}, &response)
+		if err != nil {
+			return results, err
+		}
+		if len(response.Errors) > 0 {
+			return results, response.Errors[0]
+		}
+
+		results = append(results, response.Results...)
+	}
+
+	return results, nil
+}
+
+// list fetches all the objects of the type with the given properties, or all the archived ones if archived is set
+func (o crmObjects) list(properties []string, archived bool) ([]json.RawMessage, error) {
+	query := url.Values{}
+	if archived {
+		query.Set("archived", "true")
+	}
+
+	return o.listQuery(properties, query)
+}
+
+// listAssociated fetches all the objects of the type with the given properties, and their associations with the given object types
+func (o crmObjects) listAssociated(properties []string, associations []string) ([]json.RawMessage, error) {
+	query := url.Values{}
+	if len(associations) > 0 {
+		query.Set("associations", strings.Join(associations, ","))
+	}
+
+	return o.listQuery(properties, query)
+}
+
+func (o crmObjects) listQuery(properties []string, query url.Values) ([]json.RawMessage, error) {
+	query.Set("limit", strconv.Itoa(listPageLimit))
+	if len(properties) > 0 {
+		query.Set("properties", strings.Join(properties, ","))
+	}
+
+	results := []json.RawMessage{}
+	for {
+		var response rawListResponse
+		err := doJSONRequest(o.httpClient, "GET", o.url("", query), nil, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, response.Results...)
+
+		if response.Paging == nil || response.Paging.Next["after"] == "" {
+			return results, nil
+		}
+		query.Set("after", response.Paging.Next["after"])
+	}
+}
+
+// batchRead reads the objects with the given ids and properties, in as many batch requests as needed,
+// and returns the objects found with the errors HubSpot reported for the ids that were not
+func (o crmObjects) batchRead(ids []string, properties []string) ([]json.RawMessage, map[string]error, error) {
+	return o.batchReadQuery(ids, properties, nil)
+}
+
+// batchReadArchived reads the archived objects with the given ids and properties
+func (o crmObjects) batchReadArchived(ids []string, properties []string) ([]json.RawMessage, map[string]error, error) {
+	query := url.Values{}
+	query.Set("archived", "true")
+	return o.batchReadQuery(ids, properties, query)
+}
+
+func (o crmObjects) batchReadQuery(ids []string, properties []string, query url.Values) ([]json.RawMessage, map[string]error, error) {
+	results := []json.RawMessage{}
+	errs := map[string]error{}
+	for _, chunk := range chunkIds(ids) {
+		request := batchReadRequest{
+			Properties: properties,
+			Inputs:     make([]batchId, len(chunk)),
+		}
+		for i, id := range chunk {
+			request.Inputs[i] = batchId{Id: id}
+		}
+
+		var response rawBatchResponse
+		err := doJSONRequest(o.httpClient, "POST", o.url("/batch/read", query), request, &response)
+		if err != nil {
+			return results, errs, err
+		}
+
+		results = append(results, response.Results...)
+		for id, batchErr := range response.errorsById() {
+			errs[id] = batchErr
+		}
+	}
+
+	return results, errs, nil
+}
+
+// batchUpdate updates the given objects, in as many batch requests as needed,
+// and returns the updated objects with the error for each id that failed to update
+// When a whole batch request fails, all the ids of that batch are reported with the request error.
+func (o crmObjects) batchUpdate(inputs []BatchUpdateInput) ([]json.RawMessage, map[string]error) {
+	results := []json.RawMessage{}
+	errs := map[string]error{}
+	for start := 0; start < len(inputs); start += batchLimit {
+		end := start + batchLimit
+		if end > len(inputs) {
+			end = len(inputs)
+		}
+		chunk := inputs[start:end]
+
+		var response rawBatchResponse
+		err := doJSONRequest(o.httpClient, "POST", o.url("/batch/update", nil), batchUpdateRequest{chunk}, &response)
+		if err != nil {
+			for _, input := range chunk {
+				errs[input.Id] = err
+			}
+			continue
+		}
+
+		results = append(results, response.Results...)
+		for id, batchErr := range response.errorsById() {
+			errs[id] = batchErr
+		}
+	}
+
+	return results, errs
+}
+
+// firstBatchError returns the error of the first of the inputs that failed to update, if any
+func firstBatchError(inputs []BatchUpdateInput, errs map[string]error) error {
+	for _, input := range inputs {
+		if err, ok := errs[input.Id]; ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchArchive archives the objects with the given ids, in as many batch requests as needed
+func (o crmObjects) batchArchive(ids []string) error {
+	for _, chunk := range chunkIds(ids) {
+		request := batchArchiveRequest{Inputs: make([]batchId, len(chunk))}
+		for i, id := range chunk {
+			request.Inputs[i] = batchId{Id: id}
+		}
+
+		err := doJSONRequest(o.httpClient, "POST", o.url("/batch/archive", nil), request, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type associatedIdsResponse struct {
+	Results []Association `json:"results"`
+	Paging  *Paging       `json:"paging"`
+}
+
+// associatedIds returns the ids of the objects of toObjectType associated with the object with the given id, reading all the pages
+func (o crmObjects) associatedIds(id, toObjectType string) ([]string, error) {
+	query := url.Values{}
+	query.Set("limit", "500")
+
+	ids := []string{}
+	for {
+		var response associatedIdsResponse
+		err := doJSONRequest(o.httpClient, "GET", o.url(fmt.Sprintf("/%s/associations/%s", id, toObjectType), query), nil, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, association := range response.Results {
+			ids = append(ids, association.Id)
+		}
+
+		if response.Paging == nil || response.Paging.Next["after"] == "" {
+			return ids, nil
+		}
+		query.Set("after", response.Paging.Next["after"])
+	}
+}
+
+// unmarshalAll unmarshals each of the raw objects into the slice pointed to by results
+func unmarshalAll(raw []json.RawMessage, results interface{}) error {
+	joined, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(joined, results)
+}
+
+// objectTypeIds are the ids of the standard CRM object types, used by the APIs that identify object types by id
+var objectTypeIds = map[string]string{
+	"contacts":   "0-1",
+	"companies":  "0-2",
+	"deals":      "0-3",
+	"tickets":    "0-5",
+	"products":   "0-7",
+	"line_items": "0-8",
+	"tasks":      "0-27",
+	"notes":      "0-46",
+	"meetings":   "0-47",
+	"calls":      "0-48",
+	"emails":     "0-49",
+}
+
+// objectTypeId returns the id of an object type, custom object types are expected to be given by id already
+func objectTypeId(objectType string) string {
+	if id, ok := objectTypeIds[objectType]; ok {
+		return id
+	}
+	return objectType
+}
@@ -0,0 +1,245 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotOwnerAPI interface {
+	ListOwners(includeArchived bool) ([]Owner, error)
+	GetOwner(ownerId string, includeArchived bool) (*Owner, error)
+	GetOwnerByEmail(email string) (*Owner, error)
+	ClearOwnerCache()
+}
+
+// HubspotOwnerAPI is the structure to interact with HubSpot Owners API
+// Owners that have been fetched are cached in memory, see ClearOwnerCache.
+type HubspotOwnerAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+	cache      *ownerCache
+}
+
+// OwnerTeam is a team an owner belongs to
+type OwnerTeam struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Primary bool   `json:"primary"`
+}
+
+// Owner is a representation of a HubSpot user that can own CRM records
+type Owner struct {
+	Id        string      `json:"id"`
+	Email     string      `json:"email"`
+	FirstName string      `json:"firstName"`
+	LastName  string      `json:"lastName"`
+	UserId    int64       `json:"userId"`
+	CreatedAt string      `json:"createdAt"`
+	UpdatedAt string      `json:"updatedAt"`
+	Archived  bool        `json:"archived"`
+	Teams     []OwnerTeam `json:"teams"`
+}
+
+// ownersPageLimit is the maximum number of owners HubSpot returns in a single page
+const ownersPageLimit = 100
+
+type ownersResponse struct {
+	Results []Owner `json:"results"`
+	Paging  *Paging `json:"paging"`
+}
+
+// ownerCache holds the owners fetched from HubSpot by id and by email, a nil cache caches nothing
+type ownerCache struct {
+	mutex   sync.RWMutex
+	byId    map[string]Owner
+	byEmail map[string]Owner
+}
+
+func newOwnerCache() *ownerCache {
+	return &ownerCache{
+		byId:    map[string]Owner{},
+		byEmail: map[string]Owner{},
+	}
+}
+
+func (c *ownerCache) add(owners ...Owner) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, owner := range owners {
+		c.byId[owner.Id] = owner
+		if owner.Email != "" && !owner.Archived {
+			c.byEmail[normaliseEmail(owner.Email)] = owner
+		}
+	}
+}
+
+func (c *ownerCache) get(ownerId string) (Owner, bool) {
+	if c == nil {
+		return Owner{}, false
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	owner, ok := c.byId[ownerId]
+	return owner, ok
+}
+
+func (c *ownerCache) getByEmail(email string) (Owner, bool) {
+	if c == nil {
+		return Owner{}, false
+	}
+
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	owner, ok := c.byEmail[normaliseEmail(email)]
+	return owner, ok
+}
+
+func (c *ownerCache) clear() {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.byId = map[string]Owner{}
+	c.byEmail = map[string]Owner{}
+}
+
+func normaliseEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// NewHubspotOwnerAPI creates new HubspotOwnerAPI with API key
+func NewHubspotOwnerAPI(apiKey string) HubspotOwnerAPI {
+	return NewHubspotOwnerAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotOwnerAPIWithClient creates new HubspotOwnerAPI with API key and HTTP client
+func NewHubspotOwnerAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotOwnerAPI {
+	return HubspotOwnerAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+		cache:      newOwnerCache(),
+	}
+}
+
+func (api HubspotOwnerAPI) url(path string, query url.Values) string {
+	query.Set("hapikey", api.APIKey)
+	return fmt.Sprintf("https://api.hubapi.com/crm/v3/owners%s?%s", path, query.Encode())
+}
+
+// listOwners fetches all the pages of owners matching the query
+func (api HubspotOwnerAPI) listOwners(query url.Values) ([]Owner, error) {
+	query.Set("limit", strconv.Itoa(ownersPageLimit))
+
+	owners := []Owner{}
+	for {
+		var response ownersResponse
+		err := doJSONRequest(api.httpClient, "GET", api.url("/", query), nil, &response)
+		if err != nil {
+			return nil, err
+		}
+
+		owners = append(owners, response.Results...)
+
+		if response.Paging == nil || response.Paging.Next["after"] == "" {
+			break
+		}
+		query.Set("after", response.Paging.Next["after"])
+	}
+
+	api.cache.add(owners...)
+
+	return owners, nil
+}
+
+// ListOwners returns all the owners of the account, including the archived ones if includeArchived is set
+func (api HubspotOwnerAPI) ListOwners(includeArchived bool) ([]Owner, error) {
+	log.Infof("Listing owners")
+
+	owners, err := api.listOwners(url.Values{})
+	if err != nil || !includeArchived {
+		return owners, err
+	}
+
+	// HubSpot only returns the archived owners when asked for them
+	query := url.Values{}
+	query.Set("archived", "true")
+
+	archived, err := api.listOwners(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(owners, archived...), nil
+}
+
+// GetOwner returns the owner with the given id, which may be archived if includeArchived is set
+func (api HubspotOwnerAPI) GetOwner(ownerId string, includeArchived bool) (*Owner, error) {
+	if owner, ok := api.cache.get(ownerId); ok && (includeArchived || !owner.Archived) {
+		return &owner, nil
+	}
+
+	query := url.Values{}
+	query.Set("idProperty", "id")
+
+	var owner Owner
+	err := doJSONRequest(api.httpClient, "GET", api.url("/"+ownerId, query), nil, &owner)
+
+	// HubSpot only finds archived owners when asked for them, which in turn hides the active ones
+	var apiErr HubSpotAPIError
+	if includeArchived && errors.As(err, &apiErr) && apiErr.StatusCode == 404 {
+		query.Set("archived", "true")
+		err = doJSONRequest(api.httpClient, "GET", api.url("/"+ownerId, query), nil, &owner)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	api.cache.add(owner)
+
+	return &owner, nil
+}
+
+// GetOwnerByEmail returns the active owner with the given email, emails are matched case insensitively
+func (api HubspotOwnerAPI) GetOwnerByEmail(email string) (*Owner, error) {
+	if owner, ok := api.cache.getByEmail(email); ok {
+		return &owner, nil
+	}
+
+	query := url.Values{}
+	query.Set("email", normaliseEmail(email))
+
+	owners, err := api.listOwners(query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, owner := range owners {
+		if normaliseEmail(owner.Email) == normaliseEmail(email) {
+			return &owner, nil
+		}
+	}
+
+	return nil, errors.New(fmt.Sprintf("There is no owner with email '%s'", email))
+}
+
+// ClearOwnerCache forgets all the owners fetched so far, so that they are fetched from HubSpot again
+func (api HubspotOwnerAPI) ClearOwnerCache() {
+	api.cache.clear()
+}
@@ -0,0 +1,205 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockOwnerAPI(mockClient *IHTTPClientMock) HubspotOwnerAPI {
+	return HubspotOwnerAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+		cache:      newOwnerCache(),
+	}
+}
+
+func TestListOwners(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "https://api.hubapi.com/crm/v3/owners/?hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, ownersResponse{
+					Results: []Owner{{Id: "1", Email: "alice@example.com"}},
+					Paging:  &Paging{Next: map[string]string{"after": "1"}},
+				})
+			case "https://api.hubapi.com/crm/v3/owners/?after=1&hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, ownersResponse{
+					Results: []Owner{{Id: "3", Email: "carol@example.com"}},
+				})
+			case "https://api.hubapi.com/crm/v3/owners/?archived=true&hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, ownersResponse{
+					Results: []Owner{{Id: "2", Email: "bob@example.com", Archived: true}},
+				})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockOwnerAPI(&mockHubspotHTTPClient)
+
+	owners, err := api.ListOwners(true)
+	if err != nil {
+		t.Errorf("ListOwners returned an error: %s", err.Error())
+		return
+	}
+
+	expectedOwners := []Owner{
+		{Id: "1", Email: "alice@example.com"},
+		{Id: "3", Email: "carol@example.com"},
+		{Id: "2", Email: "bob@example.com", Archived: true},
+	}
+	if !cmp.Equal(expectedOwners, owners) {
+		t.Errorf("ListOwners returned incorrect owners, expected:\n%v\ngot:\n%v", expectedOwners, owners)
+	}
+
+	// Listed owners are cached, archived owners are only returned when asked for
+	owner, err := api.GetOwner("2", true)
+	if err != nil || owner.Email != "bob@example.com" {
+		t.Errorf("Expected the archived owner from the cache, got: %v, %v", owner, err)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 3 {
+		t.Errorf("Expected 3 calls to HubSpot API")
+	}
+}
+
+func TestGetArchivedOwner(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "https://api.hubapi.com/crm/v3/owners/1?hapikey=api_key&idProperty=id":
+				writeJSONResponse(t, w, 200, Owner{Id: "1", Email: "alice@example.com"})
+			case "https://api.hubapi.com/crm/v3/owners/2?hapikey=api_key&idProperty=id":
+				w.WriteHeader(404)
+			case "https://api.hubapi.com/crm/v3/owners/2?archived=true&hapikey=api_key&idProperty=id":
+				writeJSONResponse(t, w, 200, Owner{Id: "2", Email: "bob@example.com", Archived: true})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockOwnerAPI(&mockHubspotHTTPClient)
+
+	// Active owners are found without looking for archived ones
+	owner, err := api.GetOwner("1", true)
+	if err != nil || owner.Email != "alice@example.com" || len(mockHubspotHTTPClient.DoCalls()) != 1 {
+		t.Errorf("Unexpected owner %v, %v", owner, err)
+	}
+
+	owner, err = api.GetOwner("2", true)
+	if err != nil || owner.Email != "bob@example.com" {
+		t.Errorf("Unexpected owner %v, %v", owner, err)
+	}
+
+	api.ClearOwnerCache()
+	_, err = api.GetOwner("2", false)
+	if err == nil {
+		t.Errorf("Expected archived owners not to be found unless asked for")
+	}
+}
+
+func TestGetOwner(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/owners/1?hapikey=api_key&idProperty=id" {
+				writeJSONResponse(t, w, 200, Owner{Id: "1", Email: "alice@example.com", FirstName: "Alice"})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockOwnerAPI(&mockHubspotHTTPClient)
+
+	for i := 0; i < 2; i++ {
+		owner, err := api.GetOwner("1", false)
+		if err != nil {
+			t.Errorf("GetOwner returned an error: %s", err.Error())
+			return
+		}
+
+		if owner.FirstName != "Alice" {
+			t.Errorf("GetOwner returned incorrect owner: %v", owner)
+		}
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 1 {
+		t.Errorf("Expected the owner to be fetched once, then cached")
+	}
+
+	api.ClearOwnerCache()
+	_, _ = api.GetOwner("1", false)
+	if len(mockHubspotHTTPClient.DoCalls()) != 2 {
+		t.Errorf("Expected the owner to be fetched again after clearing the cache")
+	}
+}
+
+func TestGetOwnerByEmail(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "https://api.hubapi.com/crm/v3/owners/?email=alice%40example.com&hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, ownersResponse{Results: []Owner{{Id: "1", Email: "Alice@Example.com"}}})
+			case "https://api.hubapi.com/crm/v3/owners/?email=nobody%40example.com&hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, ownersResponse{Results: []Owner{}})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockOwnerAPI(&mockHubspotHTTPClient)
+
+	owner, err := api.GetOwnerByEmail(" ALICE@example.com")
+	if err != nil {
+		t.Errorf("GetOwnerByEmail returned an error: %s", err.Error())
+		return
+	}
+	if owner.Id != "1" {
+		t.Errorf("GetOwnerByEmail returned incorrect owner: %v", owner)
+	}
+
+	owner, err = api.GetOwnerByEmail("alice@example.com")
+	if err != nil || owner.Id != "1" {
+		t.Errorf("Expected the owner from the cache, got: %v, %v", owner, err)
+	}
+
+	_, err = api.GetOwnerByEmail("nobody@example.com")
+	if err == nil {
+		t.Errorf("Expected an error for an unknown email")
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 2 {
+		t.Errorf("Expected 2 calls to HubSpot API")
+	}
+}
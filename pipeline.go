@@ -0,0 +1,73 @@
+package go_hubspot
+
+import (
+	"fmt"
+)
+
+// PipelineStage is a representation of a stage of a HubSpot pipeline
+type PipelineStage struct {
+	Id           string            `json:"id"`
+	Label        string            `json:"label"`
+	DisplayOrder int               `json:"displayOrder"`
+	Metadata     map[string]string `json:"metadata"`
+	Archived     bool              `json:"archived"`
+}
+
+// Pipeline is a representation of a HubSpot pipeline, e.g. a deal flow board
+type Pipeline struct {
+	Id           string          `json:"id"`
+	Label        string          `json:"label"`
+	DisplayOrder int             `json:"displayOrder"`
+	Stages       []PipelineStage `json:"stages"`
+	Archived     bool            `json:"archived"`
+}
+
+type pipelinesResponse struct {
+	Results []Pipeline `json:"results"`
+}
+
+// Stage returns the stage of the pipeline with the given id, returns false if the stage is not in the pipeline
+func (pipeline Pipeline) Stage(stageId string) (PipelineStage, bool) {
+	for _, stage := range pipeline.Stages {
+		if stage.Id == stageId {
+			return stage, true
+		}
+	}
+	return PipelineStage{}, false
+}
+
+// StageByLabel returns the stage of the pipeline with the given label, returns false if there is no such stage
+func (pipeline Pipeline) StageByLabel(label string) (PipelineStage, bool) {
+	for _, stage := range pipeline.Stages {
+		if stage.Label == label {
+			return stage, true
+		}
+	}
+	return PipelineStage{}, false
+}
+
+// getPipelines returns all the pipelines of an object type, e.g. "deals" or "tickets"
+func getPipelines(httpClient IHTTPClient, apiKey, objectType string) ([]Pipeline, error) {
+	url := fmt.Sprintf("https://api.hubapi.com/crm/v3/pipelines/%s?hapikey=%s", objectType, apiKey)
+
+	var response pipelinesResponse
+	err := doJSONRequest(httpClient, "GET", url, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Results, nil
+}
+
+// getPipeline returns a single pipeline of an object type
+func getPipeline(httpClient IHTTPClient, apiKey, objectType, pipelineId string) (*Pipeline, error) {
+	url := fmt.Sprintf("https://api.hubapi.com/crm/v3/pipelines/%s/%s?hapikey=%s", objectType, pipelineId, apiKey)
+
+	var pipeline Pipeline
+	err := doJSONRequest(httpClient, "GET", url, nil, &pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pipeline, nil
+}
@@ -0,0 +1,141 @@
+package go_hubspot
+
+type IHubspotProductAPI interface {
+	CreateProduct(properties map[string]string) (*Product, error)
+	GetProduct(productId string) (*Product, error)
+	UpdateProduct(productId string, properties map[string]string) (*Product, error)
+	ArchiveProduct(productId string) error
+	BatchCreateProducts(properties []map[string]string) ([]Product, error)
+	BatchReadProducts(productIds []string) ([]Product, error)
+	BatchUpdateProducts(inputs []BatchUpdateInput) ([]Product, error)
+	BatchArchiveProducts(productIds []string) error
+}
+
+// HubspotProductAPI is the structure to interact with HubSpot Products API
+type HubspotProductAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+}
+
+// ProductProperties is a representation of the default properties of a product
+type ProductProperties struct {
+	Name                   string `json:"name"`
+	Description            string `json:"description"`
+	Price                  string `json:"price"`
+	Sku                    string `json:"hs_sku"`
+	CostOfGoodsSold        string `json:"hs_cost_of_goods_sold"`
+	RecurringBillingPeriod string `json:"hs_recurring_billing_period"`
+	CreateDate             string `json:"createdate"`
+	HsLastModifiedDate     string `json:"hs_lastmodifieddate"`
+}
+
+// Product is a representation of a product in HubSpot
+type Product struct {
+	Id         string            `json:"id"`
+	Properties ProductProperties `json:"properties"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+	Archived   bool              `json:"archived"`
+}
+
+// productProperties are the properties requested when reading products
+var productProperties = []string{"name", "description", "price", "hs_sku", "hs_cost_of_goods_sold", "hs_recurring_billing_period"}
+
+// NewHubspotProductAPI creates new HubspotProductAPI with API key
+func NewHubspotProductAPI(apiKey string) HubspotProductAPI {
+	return NewHubspotProductAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotProductAPIWithClient creates new HubspotProductAPI with API key and HTTP client
+func NewHubspotProductAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotProductAPI {
+	return HubspotProductAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+	}
+}
+
+func (api HubspotProductAPI) objects() crmObjects {
+	return crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: "products"}
+}
+
+// CreateProduct creates a product with the given properties
+func (api HubspotProductAPI) CreateProduct(properties map[string]string) (*Product, error) {
+	var product Product
+	err := api.objects().create(objectCreationRequest{Properties: properties}, &product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// GetProduct returns the product with the given id
+func (api HubspotProductAPI) GetProduct(productId string) (*Product, error) {
+	var product Product
+	err := api.objects().get(productId, productProperties, &product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// UpdateProduct updates the given properties of a product
+func (api HubspotProductAPI) UpdateProduct(productId string, properties map[string]string) (*Product, error) {
+	var product Product
+	err := api.objects().update(productId, properties, &product)
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+// ArchiveProduct archives the product with the given id
+func (api HubspotProductAPI) ArchiveProduct(productId string) error {
+	return api.objects().archive(productId)
+}
+
+// BatchCreateProducts creates a product for each of the given property maps
+func (api HubspotProductAPI) BatchCreateProducts(properties []map[string]string) ([]Product, error) {
+	inputs := make([]objectCreationRequest, len(properties))
+	for i, objectProperties := range properties {
+		inputs[i] = objectCreationRequest{Properties: objectProperties}
+	}
+
+	raw, err := api.objects().batchCreate(inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	products := []Product{}
+	err = unmarshalAll(raw, &products)
+	return products, err
+}
+
+// BatchReadProducts returns the products with the given ids, products that are not found are omitted
+func (api HubspotProductAPI) BatchReadProducts(productIds []string) ([]Product, error) {
+	raw, _, err := api.objects().batchRead(productIds, productProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	products := []Product{}
+	err = unmarshalAll(raw, &products)
+	return products, err
+}
+
+// BatchUpdateProducts updates the properties of the given products
+func (api HubspotProductAPI) BatchUpdateProducts(inputs []BatchUpdateInput) ([]Product, error) {
+	raw, updateErrs := api.objects().batchUpdate(inputs)
+	err := firstBatchError(inputs, updateErrs)
+	if err != nil {
+		return nil, err
+	}
+
+	products := []Product{}
+	err = unmarshalAll(raw, &products)
+	return products, err
+}
+
+// BatchArchiveProducts archives the products with the given ids
+func (api HubspotProductAPI) BatchArchiveProducts(productIds []string) error {
+	return api.objects().batchArchive(productIds)
+}
@@ -0,0 +1,77 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockProductAPI(mockClient *IHTTPClientMock) HubspotProductAPI {
+	return HubspotProductAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+func TestBatchProducts(t *testing.T) {
+	products := []Product{
+		{Id: "1", Properties: ProductProperties{Name: "Workshop", Price: "100"}},
+		{Id: "2", Properties: ProductProperties{Name: "Mentoring", Price: "50"}},
+	}
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/products/batch/read?hapikey=api_key" {
+				var request batchReadRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := batchReadRequest{
+					Properties: productProperties,
+					Inputs:     []batchId{{"1"}, {"2"}},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected batch read request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 200, map[string][]Product{"results": products})
+			} else if url == "https://api.hubapi.com/crm/v3/objects/products/batch/update?hapikey=api_key" {
+				writeJSONResponse(t, w, 207, map[string]interface{}{
+					"results": products[:1],
+					"errors": []BatchError{
+						{Category: "VALIDATION_ERROR", Message: "Invalid price", Context: map[string][]string{"ids": {"2"}}},
+					},
+				})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockProductAPI(&mockHubspotHTTPClient)
+
+	got, err := api.BatchReadProducts([]string{"1", "2"})
+	if err != nil {
+		t.Errorf("BatchReadProducts returned an error: %s", err.Error())
+	}
+
+	if !cmp.Equal(products, got) {
+		t.Errorf("BatchReadProducts returned incorrect products, expected:\n%v\ngot:\n%v", products, got)
+	}
+
+	_, err = api.BatchUpdateProducts([]BatchUpdateInput{
+		{Id: "1", Properties: map[string]string{"price": "100"}},
+		{Id: "2", Properties: map[string]string{"price": "-"}},
+	})
+	if err == nil || err.Error() != "VALIDATION_ERROR: Invalid price" {
+		t.Errorf("Expected BatchUpdateProducts to return the batch error, got: %v", err)
+	}
+}
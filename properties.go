@@ -0,0 +1,36 @@
+package go_hubspot
+
+import (
+	"fmt"
+)
+
+// propertyDefinition is a representation of the definition of a CRM object property
+type propertyDefinition struct {
+	Name                 string `json:"name"`
+	Calculated           bool   `json:"calculated"`
+	ModificationMetadata struct {
+		ReadOnlyValue bool `json:"readOnlyValue"`
+	} `json:"modificationMetadata"`
+}
+
+type propertyDefinitionsResponse struct {
+	Results []propertyDefinition `json:"results"`
+}
+
+// writable returns whether the value of the property can be set through the API
+func (definition propertyDefinition) writable() bool {
+	return !definition.Calculated && !definition.ModificationMetadata.ReadOnlyValue
+}
+
+// getPropertyDefinitions returns the definitions of all the properties of an object type
+func getPropertyDefinitions(httpClient IHTTPClient, apiKey, objectType string) ([]propertyDefinition, error) {
+	url := fmt.Sprintf("https://api.hubapi.com/crm/v3/properties/%s?hapikey=%s", objectType, apiKey)
+
+	var response propertyDefinitionsResponse
+	err := doJSONRequest(httpClient, "GET", url, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Results, nil
+}
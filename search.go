@@ -0,0 +1,172 @@
+package go_hubspot
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// searchPageLimit is the maximum number of results HubSpot returns in a single search page
+const searchPageLimit = 100
+
+// SearchFilter is a filter on a single property of a search query
+// Operator is one of the HubSpot search operators, e.g. "EQ", "GT", "IN" or "HAS_PROPERTY".
+// Values is used instead of Value for the "IN" and "NOT_IN" operators, HighValue is the upper bound of the "BETWEEN" operator.
+type SearchFilter struct {
+	PropertyName string   `json:"propertyName"`
+	Operator     string   `json:"operator"`
+	Value        string   `json:"value,omitempty"`
+	HighValue    string   `json:"highValue,omitempty"`
+	Values       []string `json:"values,omitempty"`
+}
+
+// SearchSort is a sort on a single property of a search query
+// Direction is either "ASCENDING" or "DESCENDING"
+type SearchSort struct {
+	PropertyName string `json:"propertyName"`
+	Direction    string `json:"direction"`
+}
+
+// SearchQuery is a query to the HubSpot search API, all the filters must match for a result to be returned
+type SearchQuery struct {
+	Filters    []SearchFilter
+	Sorts      []SearchSort
+	Properties []string
+}
+
+type searchFilterGroup struct {
+	Filters []SearchFilter `json:"filters"`
+}
+
+// searchPageRequest is a representation of a request for a single page of search results
+type searchPageRequest struct {
+	FilterGroups []searchFilterGroup `json:"filterGroups"`
+	Sorts        []SearchSort        `json:"sorts,omitempty"`
+	Properties   []string            `json:"properties"`
+	Limit        int                 `json:"limit"`
+	After        string              `json:"after,omitempty"`
+}
+
+// searchPageResponse is a representation of a single page of search results
+type searchPageResponse struct {
+	Total   int                   `json:"total"`
+	Results []HubSpotSearchResult `json:"results"`
+	Paging  *Paging               `json:"paging"`
+}
+
+// SearchIterator iterates over all the results of a search query, fetching pages from HubSpot as needed
+//
+//	it := api.Search("companies", query)
+//	for it.Next() {
+//		result := it.Result()
+//	}
+//	if it.Err() != nil { ... }
+type SearchIterator struct {
+	apiKey     string
+	httpClient IHTTPClient
+	objectType string
+	query      SearchQuery
+
+	page    []HubSpotSearchResult
+	index   int
+	after   string
+	started bool
+	total   int
+	err     error
+}
+
+// newSearchIterator creates a SearchIterator for the given object type and query
+func newSearchIterator(apiKey string, httpClient IHTTPClient, objectType string, query SearchQuery) *SearchIterator {
+	return &SearchIterator{
+		apiKey:     apiKey,
+		httpClient: httpClient,
+		objectType: objectType,
+		query:      query,
+		index:      -1,
+	}
+}
+
+// Next advances the iterator to the next result, returns false when there are no more results or an error occurred
+func (it *SearchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.page) {
+		if it.started && it.after == "" {
+			return false
+		}
+
+		err := it.fetchPage()
+		if err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	return true
+}
+
+// Result returns the current result of the iterator
+func (it *SearchIterator) Result() HubSpotSearchResult {
+	if it.index < 0 || it.index >= len(it.page) {
+		return HubSpotSearchResult{}
+	}
+	return it.page[it.index]
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+// Total returns the total number of results reported by HubSpot, it is only known after the first call to Next
+func (it *SearchIterator) Total() int {
+	return it.total
+}
+
+// All consumes the iterator and returns all the remaining results
+func (it *SearchIterator) All() ([]HubSpotSearchResult, error) {
+	results := []HubSpotSearchResult{}
+	for it.Next() {
+		results = append(results, it.Result())
+	}
+	return results, it.Err()
+}
+
+// fetchPage fetches the next page of results from HubSpot
+func (it *SearchIterator) fetchPage() error {
+	url := fmt.Sprintf("https://api.hubapi.com/crm/v3/objects/%s/search?hapikey=%s", it.objectType, it.apiKey)
+
+	request := searchPageRequest{
+		FilterGroups: []searchFilterGroup{},
+		Sorts:        it.query.Sorts,
+		Properties:   it.query.Properties,
+		Limit:        searchPageLimit,
+		After:        it.after,
+	}
+
+	if len(it.query.Filters) > 0 {
+		request.FilterGroups = append(request.FilterGroups, searchFilterGroup{Filters: it.query.Filters})
+	}
+
+	log.Infof("Fetching a page of %s search results after '%s'", it.objectType, it.after)
+
+	var response searchPageResponse
+	err := doJSONRequest(it.httpClient, "POST", url, request, &response)
+	if err != nil {
+		return err
+	}
+
+	it.started = true
+	it.total = response.Total
+	it.page = response.Results
+	it.index = 0
+	it.after = ""
+	if response.Paging != nil {
+		it.after = response.Paging.Next["after"]
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package go_hubspot
+
+import (
+	"sort"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+)
+
+// matches returns whether properties match the filter, the same way HubSpot search does
+func (filter SearchFilter) matches(properties map[string]string) bool {
+	return crm.Filter(filter).Matches(properties)
+}
+
+// matches returns whether properties match all the filters of the query
+func (query SearchQuery) matches(properties map[string]string) bool {
+	for _, filter := range query.Filters {
+		if !filter.matches(properties) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortResults sorts results by the sorts of the query, the same way HubSpot search does, results are left in order when there are no sorts
+func (query SearchQuery) sortResults(results []HubSpotSearchResult) {
+	sorts := make([]crm.Sort, len(query.Sorts))
+	for i, searchSort := range query.Sorts {
+		sorts[i] = crm.Sort(searchSort)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return crm.Less(results[i].Properties, results[j].Properties, sorts)
+	})
+}
@@ -0,0 +1,42 @@
+package go_hubspot
+
+import (
+	"testing"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestSearchFilterMatches checks the filters against the cases the fake server of hubspottest is checked against too
+func TestSearchFilterMatches(t *testing.T) {
+	for _, c := range crm.FilterCases {
+		t.Run(c.Name, func(t *testing.T) {
+			filter := SearchFilter(c.Filter)
+			if actual := filter.matches(crm.FilterCaseProperties); actual != c.Expected {
+				t.Errorf("Filter %v, expected %t, got %t", filter, c.Expected, actual)
+			}
+		})
+	}
+}
+
+func TestSearchQuerySort(t *testing.T) {
+	results := []HubSpotSearchResult{
+		{Id: "1", Properties: map[string]string{"stage": "b", "amount": "10"}},
+		{Id: "2", Properties: map[string]string{"stage": "a", "amount": "9"}},
+		{Id: "3", Properties: map[string]string{"stage": "b", "amount": "100"}},
+	}
+
+	SearchQuery{Sorts: []SearchSort{
+		{PropertyName: "stage", Direction: "ASCENDING"},
+		{PropertyName: "amount", Direction: "DESCENDING"},
+	}}.sortResults(results)
+
+	ids := []string{}
+	for _, result := range results {
+		ids = append(ids, result.Id)
+	}
+
+	if !cmp.Equal([]string{"2", "3", "1"}, ids) {
+		t.Errorf("Unexpected order: %v", ids)
+	}
+}
@@ -0,0 +1,107 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSearchIterator(t *testing.T) {
+	query := SearchQuery{
+		Filters: []SearchFilter{
+			{PropertyName: "pipeline", Operator: "EQ", Value: "default"},
+		},
+		Sorts:      []SearchSort{{PropertyName: "createdate", Direction: "ASCENDING"}},
+		Properties: []string{"dealname"},
+	}
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url != "https://api.hubapi.com/crm/v3/objects/deals/search?hapikey=api_key" {
+				t.Errorf("Unexpected url %s", url)
+				return w.Result(), nil
+			}
+
+			var request searchPageRequest
+			readJSONRequest(t, req, &request)
+
+			expectedRequest := searchPageRequest{
+				FilterGroups: []searchFilterGroup{{Filters: query.Filters}},
+				Sorts:        query.Sorts,
+				Properties:   query.Properties,
+				Limit:        100,
+				After:        request.After,
+			}
+			if !cmp.Equal(expectedRequest, request) {
+				t.Errorf("Unexpected search request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+			}
+
+			if request.After == "" {
+				writeJSONResponse(t, w, 200, searchPageResponse{
+					Total:   3,
+					Results: []HubSpotSearchResult{{Id: "1"}, {Id: "2"}},
+					Paging:  &Paging{Next: map[string]string{"after": "2"}},
+				})
+			} else if request.After == "2" {
+				writeJSONResponse(t, w, 200, searchPageResponse{
+					Total:   3,
+					Results: []HubSpotSearchResult{{Id: "3"}},
+				})
+			} else {
+				t.Errorf("Unexpected page after %s", request.After)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockDealFlowAPI(&mockHubspotHTTPClient)
+
+	results, err := api.SearchDeals(query).All()
+	if err != nil {
+		t.Errorf("Search returned an error: %s", err.Error())
+		return
+	}
+
+	ids := []string{}
+	for _, result := range results {
+		ids = append(ids, result.Id)
+	}
+
+	if !cmp.Equal([]string{"1", "2", "3"}, ids) {
+		t.Errorf("Search returned incorrect results, expected: [1 2 3], got: %v", ids)
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 2 {
+		t.Errorf("Expected 2 calls to HubSpot API")
+	}
+}
+
+func TestSearchIteratorError(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			w := httptest.NewRecorder()
+			w.WriteHeader(400)
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockCRMAPI(&mockHubspotHTTPClient)
+
+	it := api.Search("companies", SearchQuery{})
+	if it.Next() {
+		t.Errorf("Expected the iteration to stop on an error")
+	}
+
+	if it.Err() == nil {
+		t.Errorf("Expected the iterator to return an error")
+	}
+}
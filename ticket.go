@@ -0,0 +1,196 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotTicketAPI interface {
+	CreateTicket(request TicketCreateRequest) (*Ticket, error)
+	GetTicket(ticketId string) (*Ticket, error)
+	UpdateTicket(ticketId string, properties map[string]string) error
+	AssociateTicket(ticketId, assocId, objectType, assocType string) error
+	MoveTicketToStage(ticketId, stageLabel string) error
+	SearchTickets(query SearchQuery) *SearchIterator
+	GetTicketPipelines() ([]Pipeline, error)
+	CreateTicketForOwner(request TicketCreateRequest, ownerEmail string) (*Ticket, error)
+	AssignTicketOwner(ticketId, ownerEmail string) error
+}
+
+// HubspotTicketAPI is the structure to interact with HubSpot Tickets API
+type HubspotTicketAPI struct {
+	APIKey     string
+	httpClient IHTTPClient
+	owners     *ownerCache
+}
+
+// Ticket is a representation of a ticket in HubSpot
+type Ticket struct {
+	Id         string            `json:"id"`
+	Properties map[string]string `json:"properties"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+	Archived   bool              `json:"archived"`
+}
+
+// TicketCreateRequest describes a ticket to create, with any number of associations
+// Associations default to the HubSpot defined ticket_to_<object type> labels, see ObjectAssociation.
+type TicketCreateRequest struct {
+	Subject      string
+	Content      string
+	Pipeline     string
+	Stage        string
+	OwnerId      string
+	Priority     string
+	Properties   map[string]string
+	Associations []ObjectAssociation
+}
+
+// properties returns the HubSpot properties of the ticket to create, fields that are not set are omitted
+func (request TicketCreateRequest) properties() map[string]string {
+	properties := map[string]string{}
+	for key, value := range request.Properties {
+		properties[key] = value
+	}
+
+	fields := map[string]string{
+		"subject":            request.Subject,
+		"content":            request.Content,
+		"hs_pipeline":        request.Pipeline,
+		"hs_pipeline_stage":  request.Stage,
+		"hubspot_owner_id":   request.OwnerId,
+		"hs_ticket_priority": request.Priority,
+	}
+	for key, value := range fields {
+		if value != "" {
+			properties[key] = value
+		}
+	}
+
+	return properties
+}
+
+// ticketProperties are the properties requested when reading tickets
+var ticketProperties = []string{
+	"subject",
+	"content",
+	"hs_pipeline",
+	"hs_pipeline_stage",
+	"hs_ticket_priority",
+	"hubspot_owner_id",
+	"createdate",
+	"hs_lastmodifieddate",
+}
+
+// NewHubspotTicketAPI creates new HubspotTicketAPI with API key
+func NewHubspotTicketAPI(apiKey string) HubspotTicketAPI {
+	return NewHubspotTicketAPIWithClient(apiKey, HTTPClient{})
+}
+
+// NewHubspotTicketAPIWithClient creates new HubspotTicketAPI with API key and HTTP client
+func NewHubspotTicketAPIWithClient(apiKey string, httpClient IHTTPClient) HubspotTicketAPI {
+	return HubspotTicketAPI{
+		APIKey:     apiKey,
+		httpClient: httpClient,
+		owners:     newOwnerCache(),
+	}
+}
+
+func (api HubspotTicketAPI) objects() crmObjects {
+	return crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: "tickets"}
+}
+
+// CreateTicket creates a ticket from the given request, and returns the ticket created in HubSpot
+// If the ticket cannot be associated it is archived, see CreateDeal.
+func (api HubspotTicketAPI) CreateTicket(request TicketCreateRequest) (*Ticket, error) {
+	log.Infof("Creating ticket '%s'", request.Subject)
+
+	var ticket Ticket
+	err := api.objects().createAssociated(request.properties(), request.Associations, &ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+// GetTicket returns the ticket with the given id
+func (api HubspotTicketAPI) GetTicket(ticketId string) (*Ticket, error) {
+	var ticket Ticket
+	err := api.objects().get(ticketId, ticketProperties, &ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ticket, nil
+}
+
+// UpdateTicket updates the ticket with the given id with the given properties
+func (api HubspotTicketAPI) UpdateTicket(ticketId string, properties map[string]string) error {
+	log.Infof("Updating ticket '%s'", ticketId)
+
+	return api.objects().update(ticketId, properties, nil)
+}
+
+// AssociateTicket associates a ticket with another object, e.g. a "contact", "company" or "deal", using the association label
+func (api HubspotTicketAPI) AssociateTicket(ticketId, assocId, objectType, assocType string) error {
+	return api.objects().associate(ticketId, objectType, assocId, assocType)
+}
+
+// MoveTicketToStage moves the ticket to the stage with the given label in the ticket's pipeline
+func (api HubspotTicketAPI) MoveTicketToStage(ticketId, stageLabel string) error {
+	ticket, err := api.GetTicket(ticketId)
+	if err != nil {
+		return err
+	}
+
+	pipelineId := ticket.Properties["hs_pipeline"]
+	pipeline, err := getPipeline(api.httpClient, api.APIKey, "tickets", pipelineId)
+	if err != nil {
+		return err
+	}
+
+	stage, ok := pipeline.StageByLabel(stageLabel)
+	if !ok {
+		return errors.New(fmt.Sprintf("There is no stage '%s' in pipeline '%s' of ticket '%s'", stageLabel, pipelineId, ticketId))
+	}
+
+	return api.UpdateTicket(ticketId, map[string]string{"hs_pipeline_stage": stage.Id})
+}
+
+// SearchTickets returns an iterator over all the tickets matching the search query
+func (api HubspotTicketAPI) SearchTickets(query SearchQuery) *SearchIterator {
+	return newSearchIterator(api.APIKey, api.httpClient, "tickets", query)
+}
+
+// GetTicketPipelines returns all the ticket pipelines with their stages
+func (api HubspotTicketAPI) GetTicketPipelines() ([]Pipeline, error) {
+	return getPipelines(api.httpClient, api.APIKey, "tickets")
+}
+
+func (api HubspotTicketAPI) ownerAPI() HubspotOwnerAPI {
+	return HubspotOwnerAPI{APIKey: api.APIKey, httpClient: api.httpClient, cache: api.owners}
+}
+
+// CreateTicketForOwner creates a ticket owned by the owner with the given email, see CreateTicket
+func (api HubspotTicketAPI) CreateTicketForOwner(request TicketCreateRequest, ownerEmail string) (*Ticket, error) {
+	owner, err := api.ownerAPI().GetOwnerByEmail(ownerEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	request.OwnerId = owner.Id
+	return api.CreateTicket(request)
+}
+
+// AssignTicketOwner makes the owner with the given email the owner of the ticket
+func (api HubspotTicketAPI) AssignTicketOwner(ticketId, ownerEmail string) error {
+	owner, err := api.ownerAPI().GetOwnerByEmail(ownerEmail)
+	if err != nil {
+		return err
+	}
+
+	return api.UpdateTicket(ticketId, map[string]string{"hubspot_owner_id": owner.Id})
+}
@@ -0,0 +1,177 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockTicketAPI(mockClient *IHTTPClientMock) HubspotTicketAPI {
+	return HubspotTicketAPI{
+		APIKey:     "api_key",
+		httpClient: mockClient,
+	}
+}
+
+func TestCreateTicket(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s", req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "https://api.hubapi.com/crm/v3/objects/tickets?hapikey=api_key" {
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := objectCreationRequest{
+					Properties: map[string]string{
+						"subject":           "Cannot log in",
+						"hs_pipeline":       "0",
+						"hs_pipeline_stage": "1",
+						"hubspot_owner_id":  "ownerId",
+					},
+					Associations: []inlineAssociation{
+						{
+							To:    inlineAssociationTo{Id: "contactId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 16}},
+						},
+						{
+							To:    inlineAssociationTo{Id: "companyId"},
+							Types: []associationTypeSpec{{"HUBSPOT_DEFINED", 339}},
+						},
+					},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected CreateTicket request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 201, Ticket{Id: "ticketId", Properties: request.Properties})
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockTicketAPI(&mockHubspotHTTPClient)
+
+	ticket, err := api.CreateTicket(TicketCreateRequest{
+		Subject:  "Cannot log in",
+		Pipeline: "0",
+		Stage:    "1",
+		OwnerId:  "ownerId",
+		Associations: []ObjectAssociation{
+			{ObjectType: "contacts", Id: "contactId"},
+			{ObjectType: "companies", Id: "companyId"},
+		},
+	})
+	if err != nil {
+		t.Errorf("CreateTicket returned an error: %s", err.Error())
+		return
+	}
+
+	if ticket.Id != "ticketId" {
+		t.Errorf("CreateTicket returned incorrect ticket: %v", ticket)
+	}
+}
+
+func TestMoveTicketToStage(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/objects/tickets/ticketId?hapikey=api_key&properties=subject%2Ccontent%2Chs_pipeline%2Chs_pipeline_stage%2Chs_ticket_priority%2Chubspot_owner_id%2Ccreatedate%2Chs_lastmodifieddate":
+				writeJSONResponse(t, w, 200, Ticket{
+					Id:         "ticketId",
+					Properties: map[string]string{"hs_pipeline": "0", "hs_pipeline_stage": "1"},
+				})
+			case "GET https://api.hubapi.com/crm/v3/pipelines/tickets/0?hapikey=api_key":
+				writeJSONResponse(t, w, 200, Pipeline{
+					Id: "0",
+					Stages: []PipelineStage{
+						{Id: "1", Label: "New"},
+						{Id: "2", Label: "Waiting on contact"},
+						{Id: "4", Label: "Closed"},
+					},
+				})
+			case "PATCH https://api.hubapi.com/crm/v3/objects/tickets/ticketId?hapikey=api_key":
+				var request dealUpdateRequest
+				readJSONRequest(t, req, &request)
+
+				if !cmp.Equal(map[string]string{"hs_pipeline_stage": "4"}, request.Properties) {
+					t.Errorf("Unexpected ticket update: %v", request.Properties)
+				}
+
+				writeJSONResponse(t, w, 200, Ticket{Id: "ticketId"})
+			default:
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockTicketAPI(&mockHubspotHTTPClient)
+
+	err := api.MoveTicketToStage("ticketId", "Closed")
+	if err != nil {
+		t.Errorf("MoveTicketToStage returned an error: %s", err.Error())
+	}
+
+	err = api.MoveTicketToStage("ticketId", "Unknown")
+	if err == nil {
+		t.Errorf("Expected MoveTicketToStage to fail for an unknown stage")
+	}
+
+	if len(mockHubspotHTTPClient.DoCalls()) != 5 {
+		t.Errorf("Expected 5 calls to HubSpot API, got %d", len(mockHubspotHTTPClient.DoCalls()))
+	}
+}
+
+func TestCreateTicketForOwner(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "GET https://api.hubapi.com/crm/v3/owners/?email=alice%40example.com&hapikey=api_key&limit=100":
+				writeJSONResponse(t, w, 200, ownersResponse{Results: []Owner{{Id: "ownerId", Email: "alice@example.com"}}})
+			case "POST https://api.hubapi.com/crm/v3/objects/tickets?hapikey=api_key":
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				if request.Properties["hubspot_owner_id"] != "ownerId" {
+					t.Errorf("Unexpected ticket properties: %v", request.Properties)
+				}
+
+				writeJSONResponse(t, w, 201, Ticket{Id: "ticketId", Properties: request.Properties})
+			default:
+				t.Errorf("Unexpected request %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockTicketAPI(&mockHubspotHTTPClient)
+
+	ticket, err := api.CreateTicketForOwner(TicketCreateRequest{Subject: "Broken link"}, "alice@example.com")
+	if err != nil {
+		t.Errorf("CreateTicketForOwner returned an error: %s", err.Error())
+		return
+	}
+
+	if ticket.Id != "ticketId" {
+		t.Errorf("CreateTicketForOwner returned incorrect ticket: %v", ticket)
+	}
+}
@@ -0,0 +1,437 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fuzzylabs/go-hubspot/internal/crm"
+	log "github.com/sirupsen/logrus"
+)
+
+// SyncDirection is the direction a value is copied in by a two-way sync
+type SyncDirection string
+
+const (
+	SyncBoth      SyncDirection = ""
+	SyncToHubSpot SyncDirection = "to HubSpot"
+	SyncToLocal   SyncDirection = "to local"
+)
+
+// ConflictPolicy decides which side wins when a field changed on both sides since the last sync
+type ConflictPolicy int
+
+const (
+	HubSpotWins ConflictPolicy = iota
+	LocalWins
+	// LastModifiedWins compares the ModifiedAt of the local record with the last modification date of the whole HubSpot object,
+	// "hs_lastmodifieddate" or "lastmodifieddate" for contacts, not of the conflicting property:
+	// an edit of any other property in HubSpot after the local change makes HubSpot win the conflict.
+	LastModifiedWins
+)
+
+// FieldMapping maps a field of local records to a property of HubSpot objects
+// Direction restricts the field to one-way sync, the field of the source side then always overwrites the other.
+// ToHubSpot and ToLocal optionally convert values between the local and HubSpot representations,
+// values are compared in their HubSpot representation.
+type FieldMapping struct {
+	Local     string
+	HubSpot   string
+	Direction SyncDirection
+	ToHubSpot func(string) string
+	ToLocal   func(string) string
+}
+
+func (f FieldMapping) toHubSpot(value string) string {
+	if f.ToHubSpot == nil {
+		return value
+	}
+	return f.ToHubSpot(value)
+}
+
+func (f FieldMapping) toLocal(value string) string {
+	if f.ToLocal == nil {
+		return value
+	}
+	return f.ToLocal(value)
+}
+
+// SyncMapping describes how local records are synced with the HubSpot objects of an object type, e.g. "companies"
+type SyncMapping struct {
+	ObjectType string
+	Fields     []FieldMapping
+	Policy     ConflictPolicy
+}
+
+// LocalRecord is a record of the local system, HubSpotId is the id of the HubSpot object it is linked to, if any
+type LocalRecord struct {
+	Id         string
+	HubSpotId  string
+	Fields     map[string]string
+	ModifiedAt time.Time
+}
+
+// LocalUpdate is a change to a local record, the new values of Fields, and its link to the HubSpot object with HubSpotId
+type LocalUpdate struct {
+	LocalId   string
+	HubSpotId string
+	Fields    map[string]string
+}
+
+// LocalSystem is the system HubSpot objects are synced with
+type LocalSystem interface {
+	// Records returns the local records synced with the HubSpot objects of an object type
+	Records(objectType string) ([]LocalRecord, error)
+	// Apply applies changes to the local records synced with the HubSpot objects of an object type
+	Apply(objectType string, updates []LocalUpdate) error
+}
+
+// SyncFieldChange is a value copied by a two-way sync, Conflict is set when the field changed on both sides
+type SyncFieldChange struct {
+	LocalId         string
+	HubSpotId       string
+	LocalField      string
+	HubSpotProperty string
+	Direction       SyncDirection
+	OldValue        string
+	NewValue        string
+	Conflict        bool
+}
+
+// SyncRecordError is a record that could not be synced
+type SyncRecordError struct {
+	LocalId   string
+	HubSpotId string
+	Err       error
+}
+
+// SyncCreation is a HubSpot object created for a local record that was not linked to one
+type SyncCreation struct {
+	LocalId   string
+	HubSpotId string
+}
+
+// SyncReport is the audit report of a two-way sync, the changes that were applied, or would be applied by a dry run
+type SyncReport struct {
+	ObjectType string
+	DryRun     bool
+	StartedAt  time.Time
+	Changes    []SyncFieldChange
+	Created    []SyncCreation
+	Errors     []SyncRecordError
+	Unchanged  int
+}
+
+// String returns a line for each change, creation and error of the report
+func (report SyncReport) String() string {
+	var b strings.Builder
+	object := crm.SingularObjectType(report.ObjectType)
+	for _, change := range report.Changes {
+		conflict := ""
+		if change.Conflict {
+			conflict = " (conflict)"
+		}
+		fmt.Fprintf(&b, "%s %s (local %s): %s '%s' -> '%s' %s%s\n",
+			object, change.HubSpotId, change.LocalId, change.HubSpotProperty, change.OldValue, change.NewValue, change.Direction, conflict)
+	}
+	for _, creation := range report.Created {
+		fmt.Fprintf(&b, "%s %s (local %s): created in HubSpot\n", object, creation.HubSpotId, creation.LocalId)
+	}
+	for _, recordErr := range report.Errors {
+		fmt.Fprintf(&b, "%s %s (local %s): failed: %s\n", object, recordErr.HubSpotId, recordErr.LocalId, recordErr.Err.Error())
+	}
+	return b.String()
+}
+
+// recordSync is the outcome of comparing a local record with its HubSpot object
+type recordSync struct {
+	local   LocalRecord
+	changes []SyncFieldChange
+	push    map[string]string
+	pull    map[string]string
+	agreed  map[string]string
+}
+
+// SyncObjects syncs the records of the local system with the HubSpot objects they are linked to, as described by mapping,
+// and returns the audit report of the sync. Nothing is changed when dryRun is set.
+//
+// The values both sides agreed on after each sync are kept in baseline, so that a field changed on one side only
+// is copied to the other, and the policy of the mapping only decides conflicts, fields changed on both sides.
+// Without a baseline, all the differences are conflicts. Local records not linked to a HubSpot object are created
+// in HubSpot, and linked to the new object. If linking fails, the error is returned with a report listing the created
+// objects, for the caller to link them rather than creating them again.
+func (api HubspotCRMAPI) SyncObjects(local LocalSystem, baseline MirrorStore, mapping SyncMapping, dryRun bool) (*SyncReport, error) {
+	report := SyncReport{ObjectType: mapping.ObjectType, DryRun: dryRun, StartedAt: time.Now()}
+
+	log.Infof("Syncing %s with HubSpot", mapping.ObjectType)
+
+	locals, err := local.Records(mapping.ObjectType)
+	if err != nil {
+		return nil, err
+	}
+
+	lastModified := crm.LastModifiedProperty(mapping.ObjectType)
+	properties := []string{lastModified}
+	for _, field := range mapping.Fields {
+		properties = append(properties, field.HubSpot)
+	}
+
+	ids := []string{}
+	unlinked := []LocalRecord{}
+	for _, record := range locals {
+		if record.HubSpotId == "" {
+			unlinked = append(unlinked, record)
+		} else {
+			ids = append(ids, record.HubSpotId)
+		}
+	}
+
+	raw, readErrs, err := api.syncObjects(mapping).batchRead(ids, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []HubSpotSearchResult{}
+	err = unmarshalAll(raw, &objects)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := map[string]HubSpotSearchResult{}
+	for _, object := range objects {
+		byId[object.Id] = object
+	}
+
+	syncs := []recordSync{}
+	for _, record := range locals {
+		if record.HubSpotId == "" {
+			continue
+		}
+
+		object, ok := byId[record.HubSpotId]
+		if !ok {
+			readErr := readErrs[record.HubSpotId]
+			if readErr == nil {
+				readErr = errors.New(fmt.Sprintf("%s %s not found", crm.SingularObjectType(mapping.ObjectType), record.HubSpotId))
+			}
+			report.Errors = append(report.Errors, SyncRecordError{record.Id, record.HubSpotId, readErr})
+			continue
+		}
+
+		var base *MirrorRecord
+		if baseline != nil {
+			base, err = baseline.Get(mapping.ObjectType, record.HubSpotId)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		s := compareRecord(mapping, record, object, base, lastModified)
+		if len(s.changes) == 0 {
+			report.Unchanged++
+		}
+		syncs = append(syncs, s)
+	}
+
+	if dryRun {
+		for _, s := range syncs {
+			report.Changes = append(report.Changes, s.changes...)
+		}
+		for _, record := range unlinked {
+			report.Created = append(report.Created, SyncCreation{LocalId: record.Id})
+		}
+		return &report, nil
+	}
+
+	return api.applySync(local, baseline, mapping, report, syncs, unlinked)
+}
+
+// compareRecord compares a local record with its HubSpot object, and decides which side each differing field is copied from
+func compareRecord(mapping SyncMapping, record LocalRecord, object HubSpotSearchResult, base *MirrorRecord, lastModified string) recordSync {
+	s := recordSync{
+		local:  record,
+		push:   map[string]string{},
+		pull:   map[string]string{},
+		agreed: map[string]string{},
+	}
+
+	hubSpotModified, _ := parseHubSpotTime(object.Properties[lastModified])
+
+	for _, field := range mapping.Fields {
+		localValue := field.toHubSpot(record.Fields[field.Local])
+		hubSpotValue := object.Properties[field.HubSpot]
+		if localValue == hubSpotValue {
+			s.agreed[field.HubSpot] = hubSpotValue
+			continue
+		}
+
+		direction := field.Direction
+		conflict := false
+		if direction == SyncBoth {
+			localChanged, hubSpotChanged := true, true
+			if base != nil {
+				if baseValue, ok := base.Properties[field.HubSpot]; ok {
+					localChanged = localValue != baseValue
+					hubSpotChanged = hubSpotValue != baseValue
+				}
+			}
+
+			switch {
+			case localChanged && !hubSpotChanged:
+				direction = SyncToHubSpot
+			case hubSpotChanged && !localChanged:
+				direction = SyncToLocal
+			default:
+				conflict = true
+				direction = mapping.Policy.resolve(record.ModifiedAt, hubSpotModified)
+			}
+		}
+
+		change := SyncFieldChange{
+			LocalId:         record.Id,
+			HubSpotId:       object.Id,
+			LocalField:      field.Local,
+			HubSpotProperty: field.HubSpot,
+			Direction:       direction,
+			Conflict:        conflict,
+		}
+		if direction == SyncToHubSpot {
+			change.OldValue, change.NewValue = hubSpotValue, localValue
+			s.push[field.HubSpot] = localValue
+			s.agreed[field.HubSpot] = localValue
+		} else {
+			change.OldValue, change.NewValue = record.Fields[field.Local], field.toLocal(hubSpotValue)
+			s.pull[field.Local] = change.NewValue
+			s.agreed[field.HubSpot] = hubSpotValue
+		}
+		s.changes = append(s.changes, change)
+	}
+
+	return s
+}
+
+// resolve returns the direction of a field that changed on both sides, given the modification times of the local record
+// and of the HubSpot object, HubSpot wins ties of LastModifiedWins
+func (policy ConflictPolicy) resolve(localModified, hubSpotModified time.Time) SyncDirection {
+	switch policy {
+	case LocalWins:
+		return SyncToHubSpot
+	case LastModifiedWins:
+		if localModified.After(hubSpotModified) {
+			return SyncToHubSpot
+		}
+		return SyncToLocal
+	default:
+		return SyncToLocal
+	}
+}
+
+// syncObjects returns the client of the HubSpot objects synced by a mapping
+func (api HubspotCRMAPI) syncObjects(mapping SyncMapping) crmObjects {
+	return crmObjects{apiKey: api.APIKey, httpClient: api.httpClient, objectType: mapping.ObjectType}
+}
+
+// applySync applies the changes to HubSpot and to the local system, creates the unlinked records in HubSpot,
+// and stores the agreed values of the records that were synced in the baseline
+// The created objects are linked to their local records, and their baseline stored, before anything else is applied,
+// so that they are not created again by the next sync when applying the other changes fails.
+func (api HubspotCRMAPI) applySync(local LocalSystem, baseline MirrorStore, mapping SyncMapping, report SyncReport, syncs []recordSync, unlinked []LocalRecord) (*SyncReport, error) {
+	inputs := []BatchUpdateInput{}
+	for _, s := range syncs {
+		if len(s.push) > 0 {
+			inputs = append(inputs, BatchUpdateInput{Id: s.local.HubSpotId, Properties: s.push})
+		}
+	}
+
+	objects := api.syncObjects(mapping)
+	_, updateErrs := objects.batchUpdate(inputs)
+
+	// The changes to local records are only reported once they are applied
+	updates := []LocalUpdate{}
+	pulled := []SyncFieldChange{}
+	baselines := []MirrorRecord{}
+	now := time.Now()
+	for _, s := range syncs {
+		if updateErr, failed := updateErrs[s.local.HubSpotId]; failed {
+			report.Errors = append(report.Errors, SyncRecordError{s.local.Id, s.local.HubSpotId, updateErr})
+			continue
+		}
+
+		for _, change := range s.changes {
+			if change.Direction == SyncToLocal {
+				pulled = append(pulled, change)
+			} else {
+				report.Changes = append(report.Changes, change)
+			}
+		}
+		if len(s.pull) > 0 {
+			updates = append(updates, LocalUpdate{LocalId: s.local.Id, HubSpotId: s.local.HubSpotId, Fields: s.pull})
+		}
+		baselines = append(baselines, MirrorRecord{Id: s.local.HubSpotId, Properties: s.agreed, UpdatedAt: now})
+	}
+
+	links := []LocalUpdate{}
+	createdBaselines := []MirrorRecord{}
+	for _, record := range unlinked {
+		properties := map[string]string{}
+		agreed := map[string]string{}
+		for _, field := range mapping.Fields {
+			if field.Direction == SyncToLocal {
+				continue
+			}
+			value := field.toHubSpot(record.Fields[field.Local])
+			agreed[field.HubSpot] = value
+			if value != "" {
+				properties[field.HubSpot] = value
+			}
+		}
+
+		var created HubSpotSearchResult
+		err := objects.create(objectCreationRequest{Properties: properties}, &created)
+		if err != nil {
+			report.Errors = append(report.Errors, SyncRecordError{LocalId: record.Id, Err: err})
+			continue
+		}
+
+		report.Created = append(report.Created, SyncCreation{LocalId: record.Id, HubSpotId: created.Id})
+		links = append(links, LocalUpdate{LocalId: record.Id, HubSpotId: created.Id, Fields: map[string]string{}})
+		createdBaselines = append(createdBaselines, MirrorRecord{Id: created.Id, Properties: agreed, UpdatedAt: now})
+	}
+
+	if len(links) > 0 {
+		err := local.Apply(mapping.ObjectType, links)
+		if err != nil {
+			return &report, fmt.Errorf("Failed to link %d %s created in HubSpot: %w", len(links), mapping.ObjectType, err)
+		}
+
+		if baseline != nil {
+			err = baseline.Put(mapping.ObjectType, createdBaselines)
+			if err != nil {
+				return &report, err
+			}
+		}
+	}
+
+	if len(updates) > 0 {
+		err := local.Apply(mapping.ObjectType, updates)
+		if err != nil {
+			for _, update := range updates {
+				report.Errors = append(report.Errors, SyncRecordError{update.LocalId, update.HubSpotId, err})
+			}
+			return &report, err
+		}
+		report.Changes = append(report.Changes, pulled...)
+	}
+
+	if baseline != nil && len(baselines) > 0 {
+		err := baseline.Put(mapping.ObjectType, baselines)
+		if err != nil {
+			return &report, err
+		}
+	}
+
+	log.Infof("Synced %s: %d changes, %d created, %d errors", mapping.ObjectType, len(report.Changes), len(report.Created), len(report.Errors))
+
+	return &report, nil
+}
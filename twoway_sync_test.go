@@ -0,0 +1,274 @@
+package go_hubspot
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+// mockLocalSystem is a LocalSystem holding records in memory, and recording the updates applied to them
+type mockLocalSystem struct {
+	records []LocalRecord
+	applied []LocalUpdate
+	// errs are returned by the successive calls to Apply, the updates are applied when the error is nil
+	errs []error
+}
+
+func (l *mockLocalSystem) Records(objectType string) ([]LocalRecord, error) {
+	return l.records, nil
+}
+
+func (l *mockLocalSystem) Apply(objectType string, updates []LocalUpdate) error {
+	if len(l.errs) > 0 {
+		err := l.errs[0]
+		l.errs = l.errs[1:]
+		if err != nil {
+			return err
+		}
+	}
+
+	l.applied = append(l.applied, updates...)
+	return nil
+}
+
+func TestSyncObjects(t *testing.T) {
+	base := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "POST https://api.hubapi.com/crm/v3/objects/companies/batch/read?hapikey=api_key":
+				var request batchReadRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := batchReadRequest{
+					Properties: []string{"hs_lastmodifieddate", "name", "domain", "hs_lead_status"},
+					Inputs:     []batchId{{"100"}, {"101"}, {"102"}, {"103"}},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected batch read request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				modified := base.Format(time.RFC3339)
+				writeJSONResponse(t, w, 207, batchResponse{
+					Results: []HubSpotSearchResult{
+						{Id: "100", Properties: map[string]string{"name": "Acme", "domain": "acme.com", "hs_lead_status": "NEW", "hs_lastmodifieddate": modified}},
+						{Id: "101", Properties: map[string]string{"name": "Globex", "domain": "globex.io", "hs_lead_status": "OPEN", "hs_lastmodifieddate": modified}},
+						{Id: "102", Properties: map[string]string{"name": "Initech Inc", "domain": "initech.com", "hs_lead_status": "NEW", "hs_lastmodifieddate": modified}},
+					},
+					Errors: []BatchError{{Category: "OBJECT_NOT_FOUND", Message: "Not found", Context: map[string][]string{"ids": {"103"}}}},
+				})
+			case "POST https://api.hubapi.com/crm/v3/objects/companies/batch/update?hapikey=api_key":
+				var request batchUpdateRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := batchUpdateRequest{Inputs: []BatchUpdateInput{
+					{Id: "100", Properties: map[string]string{"name": "Acme Ltd"}},
+					{Id: "102", Properties: map[string]string{"name": "Initech Corp"}},
+				}}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected batch update request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 200, batchResponse{Status: "COMPLETE"})
+			case "POST https://api.hubapi.com/crm/v3/objects/companies?hapikey=api_key":
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := objectCreationRequest{Properties: map[string]string{"name": "Hooli", "domain": "hooli.com"}}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected creation request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 201, HubSpotSearchResult{Id: "104", Properties: request.Properties})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	local := &mockLocalSystem{records: []LocalRecord{
+		// Renamed locally
+		{Id: "a", HubSpotId: "100", Fields: map[string]string{"name": "Acme Ltd", "website": "acme.com", "status": "new"}},
+		// Domain changed in HubSpot, the lead status only syncs from HubSpot
+		{Id: "b", HubSpotId: "101", Fields: map[string]string{"name": "Globex", "website": "globex.com", "status": "new"}},
+		// Renamed on both sides, the local change is more recent
+		{Id: "c", HubSpotId: "102", Fields: map[string]string{"name": "Initech Corp", "website": "initech.com", "status": "new"}, ModifiedAt: base.Add(time.Hour)},
+		// Deleted from HubSpot
+		{Id: "d", HubSpotId: "103", Fields: map[string]string{"name": "Umbrella"}},
+		// Not in HubSpot yet
+		{Id: "e", Fields: map[string]string{"name": "Hooli", "website": "hooli.com", "status": "new"}},
+	}}
+
+	baseline := NewMemoryMirrorStore()
+	_ = baseline.Put("companies", []MirrorRecord{
+		{Id: "100", Properties: map[string]string{"name": "Acme", "domain": "acme.com"}},
+		{Id: "101", Properties: map[string]string{"name": "Globex", "domain": "globex.com"}},
+		{Id: "102", Properties: map[string]string{"name": "Initech", "domain": "initech.com"}},
+	})
+
+	mapping := SyncMapping{
+		ObjectType: "companies",
+		Policy:     LastModifiedWins,
+		Fields: []FieldMapping{
+			{Local: "name", HubSpot: "name"},
+			{Local: "website", HubSpot: "domain"},
+			{Local: "status", HubSpot: "hs_lead_status", Direction: SyncToLocal, ToHubSpot: strings.ToUpper, ToLocal: strings.ToLower},
+		},
+	}
+
+	api := HubspotCRMAPI{APIKey: "api_key", httpClient: &mockHubspotHTTPClient}
+
+	// A dry run reports the changes without applying them
+	report, err := api.SyncObjects(local, baseline, mapping, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if len(report.Changes) != 4 || len(local.applied) != 0 || len(mockHubspotHTTPClient.DoCalls()) != 1 {
+		t.Errorf("Unexpected dry run report %v", report)
+	}
+
+	report, err = api.SyncObjects(local, baseline, mapping, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	expectedChanges := []SyncFieldChange{
+		{LocalId: "a", HubSpotId: "100", LocalField: "name", HubSpotProperty: "name", Direction: SyncToHubSpot, OldValue: "Acme", NewValue: "Acme Ltd"},
+		{LocalId: "c", HubSpotId: "102", LocalField: "name", HubSpotProperty: "name", Direction: SyncToHubSpot, OldValue: "Initech Inc", NewValue: "Initech Corp", Conflict: true},
+		{LocalId: "b", HubSpotId: "101", LocalField: "website", HubSpotProperty: "domain", Direction: SyncToLocal, OldValue: "globex.com", NewValue: "globex.io"},
+		{LocalId: "b", HubSpotId: "101", LocalField: "status", HubSpotProperty: "hs_lead_status", Direction: SyncToLocal, OldValue: "new", NewValue: "open"},
+	}
+	if !cmp.Equal(expectedChanges, report.Changes) {
+		t.Errorf("Unexpected changes, expected:\n%v\ngot:\n%v", expectedChanges, report.Changes)
+	}
+	if !cmp.Equal([]SyncCreation{{LocalId: "e", HubSpotId: "104"}}, report.Created) {
+		t.Errorf("Unexpected creations %v", report.Created)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].LocalId != "d" {
+		t.Errorf("Unexpected errors %v", report.Errors)
+	}
+	if report.Unchanged != 0 {
+		t.Errorf("Unexpected number of unchanged records %d", report.Unchanged)
+	}
+
+	expectedUpdates := []LocalUpdate{
+		{LocalId: "e", HubSpotId: "104", Fields: map[string]string{}},
+		{LocalId: "b", HubSpotId: "101", Fields: map[string]string{"website": "globex.io", "status": "open"}},
+	}
+	if !cmp.Equal(expectedUpdates, local.applied) {
+		t.Errorf("Unexpected local updates, expected:\n%v\ngot:\n%v", expectedUpdates, local.applied)
+	}
+
+	baselines, _ := baseline.All("companies")
+	expectedBaselines := []MirrorRecord{
+		{Id: "100", Properties: map[string]string{"name": "Acme Ltd", "domain": "acme.com", "hs_lead_status": "NEW"}},
+		{Id: "101", Properties: map[string]string{"name": "Globex", "domain": "globex.io", "hs_lead_status": "OPEN"}},
+		{Id: "102", Properties: map[string]string{"name": "Initech Corp", "domain": "initech.com", "hs_lead_status": "NEW"}},
+		{Id: "104", Properties: map[string]string{"name": "Hooli", "domain": "hooli.com"}},
+	}
+	if !cmp.Equal(expectedBaselines, baselines, cmpopts.IgnoreFields(MirrorRecord{}, "UpdatedAt")) {
+		t.Errorf("Unexpected baselines, expected:\n%v\ngot:\n%v", expectedBaselines, baselines)
+	}
+
+	expectedReport := `company 100 (local a): name 'Acme' -> 'Acme Ltd' to HubSpot
+company 102 (local c): name 'Initech Inc' -> 'Initech Corp' to HubSpot (conflict)
+company 101 (local b): domain 'globex.com' -> 'globex.io' to local
+company 101 (local b): hs_lead_status 'new' -> 'open' to local
+company 104 (local e): created in HubSpot
+company 103 (local d): failed: OBJECT_NOT_FOUND: Not found
+`
+	if report.String() != expectedReport {
+		t.Errorf("Unexpected report, expected:\n%s\ngot:\n%s", expectedReport, report.String())
+	}
+}
+
+func TestSyncObjectsApplyFailure(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "POST https://api.hubapi.com/crm/v3/objects/companies/batch/read?hapikey=api_key":
+				writeJSONResponse(t, w, 200, batchResponse{
+					Results: []HubSpotSearchResult{{Id: "100", Properties: map[string]string{"name": "Acme Ltd"}}},
+				})
+			case "POST https://api.hubapi.com/crm/v3/objects/companies?hapikey=api_key":
+				var request objectCreationRequest
+				readJSONRequest(t, req, &request)
+
+				writeJSONResponse(t, w, 201, HubSpotSearchResult{Id: "104", Properties: request.Properties})
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	mapping := SyncMapping{ObjectType: "companies", Fields: []FieldMapping{{Local: "name", HubSpot: "name"}}}
+	api := HubspotCRMAPI{APIKey: "api_key", httpClient: &mockHubspotHTTPClient}
+	applyErr := errors.New("database unavailable")
+
+	// The created objects are linked, with their baseline, even when applying the other updates fails
+	local := &mockLocalSystem{
+		records: []LocalRecord{
+			{Id: "a", HubSpotId: "100", Fields: map[string]string{"name": "Acme"}},
+			{Id: "e", Fields: map[string]string{"name": "Hooli"}},
+		},
+		errs: []error{nil, applyErr},
+	}
+	baseline := NewMemoryMirrorStore()
+
+	report, err := api.SyncObjects(local, baseline, mapping, false)
+	if !errors.Is(err, applyErr) {
+		t.Errorf("Expected the apply error, got %v", err)
+	}
+	if report == nil || !cmp.Equal([]SyncCreation{{LocalId: "e", HubSpotId: "104"}}, report.Created) {
+		t.Fatalf("Unexpected report %v", report)
+	}
+	if !cmp.Equal([]LocalUpdate{{LocalId: "e", HubSpotId: "104", Fields: map[string]string{}}}, local.applied) {
+		t.Errorf("Unexpected local updates %v", local.applied)
+	}
+	created, _ := baseline.Get("companies", "104")
+	if created == nil || created.Properties["name"] != "Hooli" {
+		t.Errorf("Unexpected baseline of the created company %v", created)
+	}
+
+	// The changes that could not be applied locally are reported as errors of their records
+	if len(report.Changes) != 0 {
+		t.Errorf("Expected no changes to be reported, got %v", report.Changes)
+	}
+	if len(report.Errors) != 1 || report.Errors[0].LocalId != "a" || !errors.Is(report.Errors[0].Err, applyErr) {
+		t.Errorf("Unexpected errors %v", report.Errors)
+	}
+
+	// When linking fails, the created objects are reported for the caller to link them
+	local = &mockLocalSystem{
+		records: []LocalRecord{{Id: "e", Fields: map[string]string{"name": "Hooli"}}},
+		errs:    []error{applyErr},
+	}
+
+	report, err = api.SyncObjects(local, NewMemoryMirrorStore(), mapping, false)
+	if !errors.Is(err, applyErr) {
+		t.Errorf("Expected the apply error, got %v", err)
+	}
+	if report == nil || !cmp.Equal([]SyncCreation{{LocalId: "e", HubSpotId: "104"}}, report.Created) {
+		t.Errorf("Unexpected report %v", report)
+	}
+}
@@ -0,0 +1,448 @@
+package go_hubspot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// webhookMaxBodySize is the maximum size of a webhook request body, HubSpot sends at most 100 events per request
+const webhookMaxBodySize = 10 << 20
+
+// defaultWebhookMaxAge is how old a v3 signed request can be before it is rejected, as recommended by HubSpot
+const defaultWebhookMaxAge = 5 * time.Minute
+
+// webhookClockSkew is how far in the future the timestamp of a v3 signed request can be, to allow for clock differences
+const webhookClockSkew = time.Minute
+
+// WebhookEventBase holds the fields common to all webhook events
+// ObjectType is the object type of the subscription, e.g. "contact" or "deal", and Kind the event, e.g. "propertyChange".
+type WebhookEventBase struct {
+	EventId          int64
+	SubscriptionId   int64
+	PortalId         int64
+	AppId            int64
+	OccurredAt       time.Time
+	SubscriptionType string
+	ObjectType       string
+	Kind             string
+	AttemptNumber    int
+	ObjectId         int64
+	ChangeSource     string
+	SourceId         string
+}
+
+// Event returns the fields common to all webhook events
+func (e WebhookEventBase) Event() WebhookEventBase {
+	return e
+}
+
+// WebhookEvent is any of the typed webhook events: CreationEvent, DeletionEvent, PropertyChangeEvent,
+// AssociationChangeEvent, MergeEvent or OtherEvent
+type WebhookEvent interface {
+	Event() WebhookEventBase
+}
+
+// CreationEvent is sent when an object is created
+type CreationEvent struct {
+	WebhookEventBase
+}
+
+// DeletionEvent is sent when an object is deleted
+type DeletionEvent struct {
+	WebhookEventBase
+}
+
+// PropertyChangeEvent is sent when a property of an object changes
+type PropertyChangeEvent struct {
+	WebhookEventBase
+	PropertyName  string
+	PropertyValue string
+}
+
+// AssociationChangeEvent is sent when an association between two objects is created or removed
+type AssociationChangeEvent struct {
+	WebhookEventBase
+	AssociationType      string
+	FromObjectId         int64
+	ToObjectId           int64
+	AssociationRemoved   bool
+	IsPrimaryAssociation bool
+}
+
+// MergeEvent is sent when objects are merged, NewObjectId is the id of the object resulting from the merge
+type MergeEvent struct {
+	WebhookEventBase
+	PrimaryObjectId         int64
+	MergedObjectIds         []int64
+	NewObjectId             int64
+	NumberOfPropertiesMoved int
+}
+
+// OtherEvent is any other event, e.g. "restore" or "privacyDeletion"
+type OtherEvent struct {
+	WebhookEventBase
+}
+
+// webhookEventJSON is a representation of a webhook event sent by HubSpot
+type webhookEventJSON struct {
+	EventId                 int64   `json:"eventId"`
+	SubscriptionId          int64   `json:"subscriptionId"`
+	PortalId                int64   `json:"portalId"`
+	AppId                   int64   `json:"appId"`
+	OccurredAt              int64   `json:"occurredAt"`
+	SubscriptionType        string  `json:"subscriptionType"`
+	AttemptNumber           int     `json:"attemptNumber"`
+	ObjectId                int64   `json:"objectId"`
+	ChangeSource            string  `json:"changeSource"`
+	SourceId                string  `json:"sourceId"`
+	PropertyName            string  `json:"propertyName"`
+	PropertyValue           string  `json:"propertyValue"`
+	AssociationType         string  `json:"associationType"`
+	FromObjectId            int64   `json:"fromObjectId"`
+	ToObjectId              int64   `json:"toObjectId"`
+	AssociationRemoved      bool    `json:"associationRemoved"`
+	IsPrimaryAssociation    bool    `json:"isPrimaryAssociation"`
+	PrimaryObjectId         int64   `json:"primaryObjectId"`
+	MergedObjectIds         []int64 `json:"mergedObjectIds"`
+	NewObjectId             int64   `json:"newObjectId"`
+	NumberOfPropertiesMoved int     `json:"numberOfPropertiesMoved"`
+}
+
+// typed returns the typed event of the event sent by HubSpot
+func (e webhookEventJSON) typed() WebhookEvent {
+	base := WebhookEventBase{
+		EventId:          e.EventId,
+		SubscriptionId:   e.SubscriptionId,
+		PortalId:         e.PortalId,
+		AppId:            e.AppId,
+		OccurredAt:       time.Unix(0, e.OccurredAt*int64(time.Millisecond)).UTC(),
+		SubscriptionType: e.SubscriptionType,
+		AttemptNumber:    e.AttemptNumber,
+		ObjectId:         e.ObjectId,
+		ChangeSource:     e.ChangeSource,
+		SourceId:         e.SourceId,
+	}
+
+	parts := strings.SplitN(e.SubscriptionType, ".", 2)
+	base.ObjectType = parts[0]
+	if len(parts) == 2 {
+		base.Kind = parts[1]
+	}
+
+	switch base.Kind {
+	case "creation":
+		return CreationEvent{base}
+	case "deletion":
+		return DeletionEvent{base}
+	case "propertyChange":
+		return PropertyChangeEvent{base, e.PropertyName, e.PropertyValue}
+	case "associationChange":
+		return AssociationChangeEvent{base, e.AssociationType, e.FromObjectId, e.ToObjectId, e.AssociationRemoved, e.IsPrimaryAssociation}
+	case "merge":
+		return MergeEvent{base, e.PrimaryObjectId, e.MergedObjectIds, e.NewObjectId, e.NumberOfPropertiesMoved}
+	default:
+		return OtherEvent{base}
+	}
+}
+
+// DecodeWebhookEvents decodes a batch of webhook events sent by HubSpot into typed events
+func DecodeWebhookEvents(body []byte) ([]WebhookEvent, error) {
+	var raw []webhookEventJSON
+	err := json.Unmarshal(body, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]WebhookEvent, len(raw))
+	for i, event := range raw {
+		events[i] = event.typed()
+	}
+
+	return events, nil
+}
+
+// newWebhookEventJSON returns the representation HubSpot sends of a typed event
+func newWebhookEventJSON(event WebhookEvent) webhookEventJSON {
+	base := event.Event()
+	e := webhookEventJSON{
+		EventId:          base.EventId,
+		SubscriptionId:   base.SubscriptionId,
+		PortalId:         base.PortalId,
+		AppId:            base.AppId,
+		OccurredAt:       base.OccurredAt.UnixNano() / int64(time.Millisecond),
+		SubscriptionType: base.SubscriptionType,
+		AttemptNumber:    base.AttemptNumber,
+		ObjectId:         base.ObjectId,
+		ChangeSource:     base.ChangeSource,
+		SourceId:         base.SourceId,
+	}
+
+	switch typed := event.(type) {
+	case PropertyChangeEvent:
+		e.PropertyName = typed.PropertyName
+		e.PropertyValue = typed.PropertyValue
+	case AssociationChangeEvent:
+		e.AssociationType = typed.AssociationType
+		e.FromObjectId = typed.FromObjectId
+		e.ToObjectId = typed.ToObjectId
+		e.AssociationRemoved = typed.AssociationRemoved
+		e.IsPrimaryAssociation = typed.IsPrimaryAssociation
+	case MergeEvent:
+		e.PrimaryObjectId = typed.PrimaryObjectId
+		e.MergedObjectIds = typed.MergedObjectIds
+		e.NewObjectId = typed.NewObjectId
+		e.NumberOfPropertiesMoved = typed.NumberOfPropertiesMoved
+	}
+
+	return e
+}
+
+// WebhookHandler is an http.Handler receiving HubSpot webhooks, it validates their signature,
+// decodes the events and dispatches them to the registered callbacks, in the order they were sent.
+// Callbacks must be registered before the handler serves requests. If a callback returns an error,
+// the handler responds with an error so that HubSpot sends the batch again.
+//
+// PublicURL is the URL HubSpot sends webhooks to, it is used to validate v2 and v3 signatures,
+// and defaults to the URL of the request, which is not the URL HubSpot called when behind a proxy.
+// MaxAge is how old a v3 signed request can be, it defaults to 5 minutes. Requests dated more than a minute ahead are rejected.
+type WebhookHandler struct {
+	ClientSecret string
+	PublicURL    string
+	MaxAge       time.Duration
+
+	now                 func() time.Time
+	onEvent             []func(WebhookEvent) error
+	onCreation          []func(CreationEvent) error
+	onDeletion          []func(DeletionEvent) error
+	onPropertyChange    []func(PropertyChangeEvent) error
+	onAssociationChange []func(AssociationChangeEvent) error
+	onMerge             []func(MergeEvent) error
+}
+
+// NewWebhookHandler creates new WebhookHandler with the client secret of the HubSpot app
+func NewWebhookHandler(clientSecret string) *WebhookHandler {
+	return &WebhookHandler{
+		ClientSecret: clientSecret,
+		MaxAge:       defaultWebhookMaxAge,
+		now:          time.Now,
+	}
+}
+
+// OnEvent registers a callback for all events, it is called before the callbacks for specific event kinds
+func (h *WebhookHandler) OnEvent(callback func(WebhookEvent) error) {
+	h.onEvent = append(h.onEvent, callback)
+}
+
+// OnCreation registers a callback for creation events
+func (h *WebhookHandler) OnCreation(callback func(CreationEvent) error) {
+	h.onCreation = append(h.onCreation, callback)
+}
+
+// OnDeletion registers a callback for deletion events
+func (h *WebhookHandler) OnDeletion(callback func(DeletionEvent) error) {
+	h.onDeletion = append(h.onDeletion, callback)
+}
+
+// OnPropertyChange registers a callback for property change events
+func (h *WebhookHandler) OnPropertyChange(callback func(PropertyChangeEvent) error) {
+	h.onPropertyChange = append(h.onPropertyChange, callback)
+}
+
+// OnAssociationChange registers a callback for association change events
+func (h *WebhookHandler) OnAssociationChange(callback func(AssociationChangeEvent) error) {
+	h.onAssociationChange = append(h.onAssociationChange, callback)
+}
+
+// OnMerge registers a callback for merge events
+func (h *WebhookHandler) OnMerge(callback func(MergeEvent) error) {
+	h.onMerge = append(h.onMerge, callback)
+}
+
+// ServeHTTP validates and dispatches a batch of webhook events
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, webhookMaxBodySize))
+	if err != nil {
+		http.Error(w, "Could not read request body", http.StatusBadRequest)
+		return
+	}
+
+	err = h.ValidateSignature(r, body)
+	if err != nil {
+		log.Warnf("Rejected a webhook request: %s", err.Error())
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	events, err := DecodeWebhookEvents(body)
+	if err != nil {
+		http.Error(w, "Invalid events", http.StatusBadRequest)
+		return
+	}
+
+	for _, event := range events {
+		err = h.dispatch(event)
+		if err != nil {
+			log.Errorf("Failed to handle webhook event %d: %s", event.Event().EventId, err.Error())
+			http.Error(w, "Failed to handle events", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dispatch calls the callbacks registered for an event
+func (h *WebhookHandler) dispatch(event WebhookEvent) error {
+	for _, callback := range h.onEvent {
+		if err := callback(event); err != nil {
+			return err
+		}
+	}
+
+	switch e := event.(type) {
+	case CreationEvent:
+		for _, callback := range h.onCreation {
+			if err := callback(e); err != nil {
+				return err
+			}
+		}
+	case DeletionEvent:
+		for _, callback := range h.onDeletion {
+			if err := callback(e); err != nil {
+				return err
+			}
+		}
+	case PropertyChangeEvent:
+		for _, callback := range h.onPropertyChange {
+			if err := callback(e); err != nil {
+				return err
+			}
+		}
+	case AssociationChangeEvent:
+		for _, callback := range h.onAssociationChange {
+			if err := callback(e); err != nil {
+				return err
+			}
+		}
+	case MergeEvent:
+		for _, callback := range h.onMerge {
+			if err := callback(e); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateSignature validates the v1, v2 or v3 signature of a webhook request with the given body
+func (h *WebhookHandler) ValidateSignature(r *http.Request, body []byte) error {
+	if signature := r.Header.Get("X-HubSpot-Signature-v3"); signature != "" {
+		return h.validateV3(r, body, signature)
+	}
+
+	signature := r.Header.Get("X-HubSpot-Signature")
+	if signature == "" {
+		return errors.New("The request is not signed")
+	}
+
+	var source string
+	switch version := r.Header.Get("X-HubSpot-Signature-Version"); version {
+	case "v1", "":
+		source = h.ClientSecret + string(body)
+	case "v2":
+		source = h.ClientSecret + r.Method + h.requestURL(r) + string(body)
+	default:
+		return errors.New(fmt.Sprintf("Unknown signature version '%s'", version))
+	}
+
+	expected := sha256.Sum256([]byte(source))
+	if !hmac.Equal([]byte(hex.EncodeToString(expected[:])), []byte(strings.ToLower(signature))) {
+		return errors.New("The signature does not match")
+	}
+
+	return nil
+}
+
+func (h *WebhookHandler) validateV3(r *http.Request, body []byte, signature string) error {
+	timestamp := r.Header.Get("X-HubSpot-Request-Timestamp")
+	millis, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New(fmt.Sprintf("Invalid request timestamp '%s'", timestamp))
+	}
+
+	maxAge := h.MaxAge
+	if maxAge == 0 {
+		maxAge = defaultWebhookMaxAge
+	}
+
+	now := time.Now
+	if h.now != nil {
+		now = h.now
+	}
+
+	age := now().Sub(time.Unix(0, millis*int64(time.Millisecond)))
+	if age > maxAge {
+		return errors.New(fmt.Sprintf("The request is %s old", age))
+	}
+	if age < -webhookClockSkew {
+		return errors.New(fmt.Sprintf("The request is %s in the future", -age))
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.ClientSecret))
+	mac.Write([]byte(r.Method + decodeSignedURL(h.requestURL(r)) + string(body) + timestamp))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return errors.New("The signature does not match")
+	}
+
+	return nil
+}
+
+// requestURL returns the URL HubSpot sent the request to
+func (h *WebhookHandler) requestURL(r *http.Request) string {
+	if h.PublicURL != "" {
+		if r.URL.RawQuery != "" && !strings.Contains(h.PublicURL, "?") {
+			return h.PublicURL + "?" + r.URL.RawQuery
+		}
+		return h.PublicURL
+	}
+
+	scheme := "https"
+	if r.TLS == nil {
+		scheme = "http"
+	}
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	}
+
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// signedURLReplacer decodes the characters HubSpot decodes in the URL of v3 signatures
+var signedURLReplacer = strings.NewReplacer(
+	"%3A", ":", "%2F", "/", "%3F", "?", "%40", "@", "%21", "!", "%24", "$",
+	"%27", "'", "%28", "(", "%29", ")", "%2A", "*", "%2C", ",", "%3B", ";",
+)
+
+func decodeSignedURL(url string) string {
+	return signedURLReplacer.Replace(url)
+}
@@ -0,0 +1,218 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Defaults of WebhookProcessor
+const (
+	defaultWebhookInitialBackoff = 10 * time.Second
+	defaultWebhookMaxBackoff     = time.Hour
+	defaultWebhookMaxAttempts    = 10
+	// HubSpot retries webhooks for up to 3 days
+	defaultWebhookDedupeWindow = 72 * time.Hour
+)
+
+// WebhookProcessor processes webhook events exactly once and in order for each object, despite HubSpot
+// sending events again and out of order. Events are persisted in Store before HubSpot is acknowledged,
+// duplicates are ignored, and the events of each object are handled in the order they occurred.
+//
+// Events received less than OrderingDelay ago are held back, so that events of the same object sent late
+// can be ordered before them. When Handler fails, the event is retried after a backoff doubling from
+// InitialBackoff up to MaxBackoff, and the later events of the same object wait for it. After MaxAttempts
+// failures the event is given up on, OnGiveUp is called with it, and the next events of the object are processed
+// from the next call to ProcessPending.
+// The ids of processed events are remembered for DedupeWindow.
+type WebhookProcessor struct {
+	Store          WebhookEventStore
+	Handler        func(WebhookEvent) error
+	OrderingDelay  time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	MaxAttempts    int
+	DedupeWindow   time.Duration
+	OnGiveUp       func(PendingWebhookEvent)
+
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewWebhookProcessor creates new WebhookProcessor handling the events persisted in store with handler
+func NewWebhookProcessor(store WebhookEventStore, handler func(WebhookEvent) error) *WebhookProcessor {
+	return &WebhookProcessor{
+		Store:          store,
+		Handler:        handler,
+		InitialBackoff: defaultWebhookInitialBackoff,
+		MaxBackoff:     defaultWebhookMaxBackoff,
+		MaxAttempts:    defaultWebhookMaxAttempts,
+		DedupeWindow:   defaultWebhookDedupeWindow,
+		now:            time.Now,
+	}
+}
+
+func (p *WebhookProcessor) currentTime() time.Time {
+	if p.now != nil {
+		return p.now()
+	}
+	return time.Now()
+}
+
+// HandleEvent persists an event to be processed, it is meant to be registered with WebhookHandler.OnEvent,
+// so that HubSpot sends the event again if it could not be persisted
+func (p *WebhookProcessor) HandleEvent(event WebhookEvent) error {
+	return p.Enqueue([]WebhookEvent{event})
+}
+
+// Enqueue persists events to be processed, the events already pending or processed are ignored
+func (p *WebhookProcessor) Enqueue(events []WebhookEvent) error {
+	receivedAt := p.currentTime()
+
+	pending := make([]PendingWebhookEvent, len(events))
+	for i, event := range events {
+		pending[i] = PendingWebhookEvent{Event: event, ReceivedAt: receivedAt}
+	}
+
+	added, err := p.Store.Enqueue(pending)
+	if err != nil {
+		return err
+	}
+
+	if added < len(events) {
+		log.Infof("Ignored %d webhook events already received", len(events)-added)
+	}
+
+	return nil
+}
+
+// webhookObjectKey identifies the object an event is about, association events are about the object they are from
+func webhookObjectKey(event WebhookEvent) string {
+	base := event.Event()
+	objectId := base.ObjectId
+	if association, ok := event.(AssociationChangeEvent); ok && objectId == 0 {
+		objectId = association.FromObjectId
+	}
+
+	return fmt.Sprintf("%s:%d", base.ObjectType, objectId)
+}
+
+// backoff returns how long to wait before retrying an event that failed the given number of times
+func (p *WebhookProcessor) backoff(attempts int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// ProcessPending handles the pending events that are due, in order for each object, and returns the number of events handled successfully
+func (p *WebhookProcessor) ProcessPending() (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.currentTime()
+
+	pending, err := p.Store.Pending()
+	if err != nil {
+		return 0, err
+	}
+
+	objects := map[string][]PendingWebhookEvent{}
+	keys := []string{}
+	for _, event := range pending {
+		key := webhookObjectKey(event.Event)
+		if _, ok := objects[key]; !ok {
+			keys = append(keys, key)
+		}
+		objects[key] = append(objects[key], event)
+	}
+
+	handled := 0
+	for _, key := range keys {
+		events := objects[key]
+		sort.SliceStable(events, func(i, j int) bool {
+			a, b := events[i].Event.Event(), events[j].Event.Event()
+			if !a.OccurredAt.Equal(b.OccurredAt) {
+				return a.OccurredAt.Before(b.OccurredAt)
+			}
+			return a.EventId < b.EventId
+		})
+
+		for _, event := range events {
+			if now.Sub(event.ReceivedAt) < p.OrderingDelay || event.NextAttempt.After(now) {
+				break
+			}
+
+			ok, err := p.process(event, now)
+			if err != nil {
+				return handled, err
+			}
+			if !ok {
+				break
+			}
+			handled++
+		}
+	}
+
+	if p.DedupeWindow > 0 {
+		err = p.Store.Prune(now.Add(-p.DedupeWindow))
+		if err != nil {
+			return handled, err
+		}
+	}
+
+	return handled, nil
+}
+
+// process handles an event, and returns whether the next events of the same object can be processed
+func (p *WebhookProcessor) process(event PendingWebhookEvent, now time.Time) (bool, error) {
+	eventId := event.Event.Event().EventId
+
+	handlerErr := p.Handler(event.Event)
+	if handlerErr == nil {
+		return true, p.Store.Done(eventId, now)
+	}
+
+	event.Attempts++
+	event.LastError = handlerErr.Error()
+
+	if p.MaxAttempts > 0 && event.Attempts >= p.MaxAttempts {
+		log.Errorf("Giving up on webhook event %d after %d attempts: %s", eventId, event.Attempts, event.LastError)
+		if p.OnGiveUp != nil {
+			p.OnGiveUp(event)
+		}
+		return false, p.Store.Done(eventId, now)
+	}
+
+	event.NextAttempt = now.Add(p.backoff(event.Attempts))
+	log.Warnf("Failed to process webhook event %d, retrying at %s: %s", eventId, event.NextAttempt, event.LastError)
+
+	return false, p.Store.Update(event)
+}
+
+// Run processes the pending events every interval, until stop is closed
+func (p *WebhookProcessor) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := p.ProcessPending()
+		if err != nil {
+			log.Errorf("Failed to process webhook events: %s", err.Error())
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
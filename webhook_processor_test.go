@@ -0,0 +1,149 @@
+package go_hubspot
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func propertyChange(eventId, objectId int64, occurredAt int64, value string) WebhookEvent {
+	return PropertyChangeEvent{
+		WebhookEventBase: WebhookEventBase{
+			EventId:          eventId,
+			ObjectType:       "deal",
+			Kind:             "propertyChange",
+			SubscriptionType: "deal.propertyChange",
+			ObjectId:         objectId,
+			OccurredAt:       time.Unix(occurredAt, 0),
+		},
+		PropertyName:  "dealstage",
+		PropertyValue: value,
+	}
+}
+
+func TestWebhookProcessorOrderAndDedupe(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	handled := []int64{}
+	processor := NewWebhookProcessor(NewMemoryWebhookEventStore(), func(event WebhookEvent) error {
+		handled = append(handled, event.Event().EventId)
+		return nil
+	})
+	processor.now = func() time.Time { return now }
+	processor.OrderingDelay = 10 * time.Second
+
+	// Events of deal 1 arrive out of order, and event 2 is sent twice
+	err := processor.Enqueue([]WebhookEvent{propertyChange(2, 1, 20, "closedwon"), propertyChange(3, 2, 5, "qualified")})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	now = now.Add(5 * time.Second)
+	_ = processor.HandleEvent(propertyChange(1, 1, 10, "contractsent"))
+	_ = processor.HandleEvent(propertyChange(2, 1, 20, "closedwon"))
+
+	count, err := processor.ProcessPending()
+	if err != nil || count != 0 {
+		t.Errorf("Events were processed before the ordering delay: %d, %v", count, err)
+	}
+
+	now = now.Add(10 * time.Second)
+	count, err = processor.ProcessPending()
+	if err != nil || count != 3 {
+		t.Errorf("Unexpected ProcessPending result %d, %v", count, err)
+	}
+
+	if !cmp.Equal([]int64{1, 2, 3}, handled) {
+		t.Errorf("Unexpected order of events %v", handled)
+	}
+
+	// An event sent again after it was processed is ignored
+	_ = processor.HandleEvent(propertyChange(2, 1, 20, "closedwon"))
+	now = now.Add(time.Minute)
+	count, _ = processor.ProcessPending()
+	if count != 0 || len(handled) != 3 {
+		t.Errorf("A duplicate event was processed: %v", handled)
+	}
+}
+
+func TestWebhookProcessorRetry(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	failures := 2
+	handled := []int64{}
+	processor := NewWebhookProcessor(NewMemoryWebhookEventStore(), func(event WebhookEvent) error {
+		if event.Event().EventId == 1 && failures > 0 {
+			failures--
+			return errors.New("unavailable")
+		}
+		handled = append(handled, event.Event().EventId)
+		return nil
+	})
+	processor.now = func() time.Time { return now }
+	processor.InitialBackoff = time.Second
+
+	_ = processor.Enqueue([]WebhookEvent{
+		propertyChange(1, 1, 10, "contractsent"),
+		propertyChange(2, 1, 20, "closedwon"),
+		propertyChange(3, 2, 30, "qualified"),
+	})
+
+	// Event 2 waits for event 1 of the same deal, event 3 of another deal does not
+	_, _ = processor.ProcessPending()
+	if !cmp.Equal([]int64{3}, handled) {
+		t.Errorf("Unexpected events handled %v", handled)
+	}
+
+	pending, _ := processor.Store.Pending()
+	if len(pending) != 2 || pending[0].Attempts != 1 || !pending[0].NextAttempt.Equal(now.Add(time.Second)) {
+		t.Errorf("Unexpected pending events %v", pending)
+	}
+
+	// The second attempt fails, and is retried after twice the backoff
+	now = now.Add(time.Second)
+	_, _ = processor.ProcessPending()
+	now = now.Add(time.Second)
+	_, _ = processor.ProcessPending()
+	if !cmp.Equal([]int64{3}, handled) {
+		t.Errorf("An event was retried before its backoff: %v", handled)
+	}
+
+	now = now.Add(time.Second)
+	_, _ = processor.ProcessPending()
+	if !cmp.Equal([]int64{3, 1, 2}, handled) {
+		t.Errorf("Unexpected events handled %v", handled)
+	}
+}
+
+func TestWebhookProcessorGiveUp(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	processor := NewWebhookProcessor(NewMemoryWebhookEventStore(), func(event WebhookEvent) error {
+		return errors.New("invalid")
+	})
+	processor.now = func() time.Time { return now }
+	processor.InitialBackoff = time.Second
+	processor.MaxAttempts = 2
+
+	var givenUp []PendingWebhookEvent
+	processor.OnGiveUp = func(event PendingWebhookEvent) {
+		givenUp = append(givenUp, event)
+	}
+
+	_ = processor.Enqueue([]WebhookEvent{propertyChange(1, 1, 10, "closedwon")})
+	for i := 0; i < 3; i++ {
+		_, _ = processor.ProcessPending()
+		now = now.Add(time.Minute)
+	}
+
+	if len(givenUp) != 1 || givenUp[0].Attempts != 2 || givenUp[0].LastError != "invalid" {
+		t.Errorf("Unexpected events given up on %v", givenUp)
+	}
+
+	pending, _ := processor.Store.Pending()
+	if len(pending) != 0 {
+		t.Errorf("Unexpected pending events %v", pending)
+	}
+}
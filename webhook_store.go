@@ -0,0 +1,349 @@
+package go_hubspot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WebhookEventStore persists the webhook events waiting to be processed, and the ids of the processed events
+// so that events sent again by HubSpot are ignored. Implementations must be safe for concurrent use.
+type WebhookEventStore interface {
+	// Enqueue stores the events whose id is neither pending nor processed, and returns how many were stored
+	Enqueue(events []PendingWebhookEvent) (int, error)
+	// Pending returns the events waiting to be processed, in the order they were received
+	Pending() ([]PendingWebhookEvent, error)
+	// Update stores the new state of a pending event
+	Update(event PendingWebhookEvent) error
+	// Done removes a pending event, and remembers its id was processed at the given time
+	Done(eventId int64, at time.Time) error
+	// Prune forgets the ids of the events processed before the given time
+	Prune(before time.Time) error
+}
+
+// PendingWebhookEvent is a webhook event waiting to be processed
+// Attempts is the number of times processing it failed, and NextAttempt when it can be retried.
+type PendingWebhookEvent struct {
+	Event       WebhookEvent
+	ReceivedAt  time.Time
+	Attempts    int
+	NextAttempt time.Time
+	LastError   string
+}
+
+type pendingWebhookEventJSON struct {
+	Event       webhookEventJSON `json:"event"`
+	ReceivedAt  time.Time        `json:"receivedAt"`
+	Attempts    int              `json:"attempts"`
+	NextAttempt time.Time        `json:"nextAttempt"`
+	LastError   string           `json:"lastError,omitempty"`
+}
+
+// MarshalJSON encodes the event as HubSpot sent it
+func (e PendingWebhookEvent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pendingWebhookEventJSON{
+		Event:       newWebhookEventJSON(e.Event),
+		ReceivedAt:  e.ReceivedAt,
+		Attempts:    e.Attempts,
+		NextAttempt: e.NextAttempt,
+		LastError:   e.LastError,
+	})
+}
+
+// UnmarshalJSON decodes the event into its typed event
+func (e *PendingWebhookEvent) UnmarshalJSON(data []byte) error {
+	var raw pendingWebhookEventJSON
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	*e = PendingWebhookEvent{
+		Event:       raw.Event.typed(),
+		ReceivedAt:  raw.ReceivedAt,
+		Attempts:    raw.Attempts,
+		NextAttempt: raw.NextAttempt,
+		LastError:   raw.LastError,
+	}
+
+	return nil
+}
+
+// webhookStoreState is the state shared by the in-memory and file-backed stores
+type webhookStoreState struct {
+	Pending map[int64]PendingWebhookEvent `json:"pending"`
+	Done    map[int64]time.Time           `json:"done"`
+}
+
+func newWebhookStoreState() webhookStoreState {
+	return webhookStoreState{
+		Pending: map[int64]PendingWebhookEvent{},
+		Done:    map[int64]time.Time{},
+	}
+}
+
+// clone returns a copy of the state that can be changed without changing the original
+func (s webhookStoreState) clone() webhookStoreState {
+	state := newWebhookStoreState()
+	for eventId, event := range s.Pending {
+		state.Pending[eventId] = event
+	}
+	for eventId, at := range s.Done {
+		state.Done[eventId] = at
+	}
+	return state
+}
+
+func (s *webhookStoreState) enqueue(events []PendingWebhookEvent) int {
+	added := 0
+	for _, event := range events {
+		eventId := event.Event.Event().EventId
+		if _, ok := s.Pending[eventId]; ok {
+			continue
+		}
+		if _, ok := s.Done[eventId]; ok {
+			continue
+		}
+
+		s.Pending[eventId] = event
+		added++
+	}
+
+	return added
+}
+
+func (s *webhookStoreState) pending() []PendingWebhookEvent {
+	events := make([]PendingWebhookEvent, 0, len(s.Pending))
+	for _, event := range s.Pending {
+		events = append(events, event)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].ReceivedAt.Equal(events[j].ReceivedAt) {
+			return events[i].ReceivedAt.Before(events[j].ReceivedAt)
+		}
+		return events[i].Event.Event().EventId < events[j].Event.Event().EventId
+	})
+
+	return events
+}
+
+func (s *webhookStoreState) update(event PendingWebhookEvent) error {
+	eventId := event.Event.Event().EventId
+	if _, ok := s.Pending[eventId]; !ok {
+		return errors.New(fmt.Sprintf("Webhook event %d is not pending", eventId))
+	}
+
+	s.Pending[eventId] = event
+	return nil
+}
+
+func (s *webhookStoreState) done(eventId int64, at time.Time) {
+	delete(s.Pending, eventId)
+	s.Done[eventId] = at
+}
+
+func (s *webhookStoreState) prune(before time.Time) {
+	for eventId, at := range s.Done {
+		if at.Before(before) {
+			delete(s.Done, eventId)
+		}
+	}
+}
+
+// MemoryWebhookEventStore is a WebhookEventStore keeping events in memory, they are lost when the process stops
+type MemoryWebhookEventStore struct {
+	mu    sync.Mutex
+	state webhookStoreState
+}
+
+// NewMemoryWebhookEventStore creates an empty MemoryWebhookEventStore
+func NewMemoryWebhookEventStore() *MemoryWebhookEventStore {
+	return &MemoryWebhookEventStore{state: newWebhookStoreState()}
+}
+
+// Enqueue stores the events whose id is neither pending nor processed, and returns how many were stored
+func (s *MemoryWebhookEventStore) Enqueue(events []PendingWebhookEvent) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.enqueue(events), nil
+}
+
+// Pending returns the events waiting to be processed, in the order they were received
+func (s *MemoryWebhookEventStore) Pending() ([]PendingWebhookEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.pending(), nil
+}
+
+// Update stores the new state of a pending event
+func (s *MemoryWebhookEventStore) Update(event PendingWebhookEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.update(event)
+}
+
+// Done removes a pending event, and remembers its id was processed at the given time
+func (s *MemoryWebhookEventStore) Done(eventId int64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.done(eventId, at)
+	return nil
+}
+
+// Prune forgets the ids of the events processed before the given time
+func (s *MemoryWebhookEventStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state.prune(before)
+	return nil
+}
+
+// FileWebhookEventStore is a WebhookEventStore keeping events in a JSON file, so that they survive restarts
+// The file is rewritten atomically on every change, it suits the volume of webhooks of a single app.
+type FileWebhookEventStore struct {
+	mu    sync.Mutex
+	path  string
+	state webhookStoreState
+}
+
+// NewFileWebhookEventStore creates a FileWebhookEventStore with the events stored in the file at path, if it exists
+func NewFileWebhookEventStore(path string) (*FileWebhookEventStore, error) {
+	store := FileWebhookEventStore{path: path, state: newWebhookStoreState()}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &store.state)
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("Error while reading webhook events from '%s': %s", path, err.Error()))
+	}
+	if store.state.Pending == nil {
+		store.state.Pending = map[int64]PendingWebhookEvent{}
+	}
+	if store.state.Done == nil {
+		store.state.Done = map[int64]time.Time{}
+	}
+
+	return &store, nil
+}
+
+// save writes the new state to the store file, and keeps it in memory only once it is written
+func (s *FileWebhookEventStore) save(state webhookStoreState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(s.path, data)
+	if err != nil {
+		return err
+	}
+
+	s.state = state
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file, and renames it to path, so that path is never partially written
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Enqueue stores the events whose id is neither pending nor processed, and returns how many were stored
+func (s *FileWebhookEventStore) Enqueue(events []PendingWebhookEvent) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state.clone()
+	added := state.enqueue(events)
+	if added == 0 {
+		return 0, nil
+	}
+
+	err := s.save(state)
+	if err != nil {
+		return 0, err
+	}
+
+	return added, nil
+}
+
+// Pending returns the events waiting to be processed, in the order they were received
+func (s *FileWebhookEventStore) Pending() ([]PendingWebhookEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.state.pending(), nil
+}
+
+// Update stores the new state of a pending event
+func (s *FileWebhookEventStore) Update(event PendingWebhookEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state.clone()
+	err := state.update(event)
+	if err != nil {
+		return err
+	}
+
+	return s.save(state)
+}
+
+// Done removes a pending event, and remembers its id was processed at the given time
+func (s *FileWebhookEventStore) Done(eventId int64, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state.clone()
+	state.done(eventId, at)
+	return s.save(state)
+}
+
+// Prune forgets the ids of the events processed before the given time
+func (s *FileWebhookEventStore) Prune(before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.state.clone()
+	state.prune(before)
+	if len(state.Done) == len(s.state.Done) {
+		return nil
+	}
+
+	return s.save(state)
+}
@@ -0,0 +1,143 @@
+package go_hubspot
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFileWebhookEventStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.json")
+	receivedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	events, err := DecodeWebhookEvents([]byte(webhookBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewFileWebhookEventStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	pending := make([]PendingWebhookEvent, len(events))
+	for i, event := range events {
+		pending[i] = PendingWebhookEvent{Event: event, ReceivedAt: receivedAt}
+	}
+
+	added, err := store.Enqueue(pending)
+	if err != nil || added != len(events) {
+		t.Fatalf("Unexpected Enqueue result %d, %v", added, err)
+	}
+
+	pending[1].Attempts = 2
+	pending[1].NextAttempt = receivedAt.Add(time.Minute)
+	pending[1].LastError = "unavailable"
+	if err := store.Update(pending[1]); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if err := store.Done(1, receivedAt); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	// The events are read back from the file, typed as they were received
+	reopened, err := NewFileWebhookEventStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	reloaded, err := reopened.Pending()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if !cmp.Equal(pending[1:], reloaded) {
+		t.Errorf("Unexpected pending events, expected:\n%v\ngot:\n%v", pending[1:], reloaded)
+	}
+
+	added, err = reopened.Enqueue(pending[:2])
+	if err != nil || added != 0 {
+		t.Errorf("Pending and processed events were enqueued again: %d, %v", added, err)
+	}
+
+	if err := reopened.Prune(receivedAt.Add(time.Second)); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	added, err = reopened.Enqueue(pending[:1])
+	if err != nil || added != 1 {
+		t.Errorf("A pruned event was not enqueued: %d, %v", added, err)
+	}
+
+	if err := reopened.Update(PendingWebhookEvent{Event: OtherEvent{WebhookEventBase{EventId: 99}}}); err == nil {
+		t.Errorf("Expected an error updating an event that is not pending")
+	}
+}
+
+func TestFileWebhookEventStoreWriteFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webhooks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "events.json")
+	receivedAt := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	events, err := DecodeWebhookEvents([]byte(webhookBody))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := NewFileWebhookEventStore(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	pending := []PendingWebhookEvent{{Event: events[0], ReceivedAt: receivedAt}}
+	if _, err := store.Enqueue(pending); err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	// The store file cannot be written while its directory is missing
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	failed := pending[0]
+	failed.Attempts = 1
+	if err := store.Update(failed); err == nil {
+		t.Errorf("Expected an error updating the event")
+	}
+	if err := store.Done(events[0].Event().EventId, receivedAt); err == nil {
+		t.Errorf("Expected an error marking the event as done")
+	}
+	if added, err := store.Enqueue([]PendingWebhookEvent{{Event: events[1], ReceivedAt: receivedAt}}); err == nil || added != 0 {
+		t.Errorf("Expected an error enqueuing the event, got %d, %v", added, err)
+	}
+
+	// Nothing that failed to be written is kept in memory
+	remaining, err := store.Pending()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if !cmp.Equal(pending, remaining) {
+		t.Errorf("Unexpected pending events, expected:\n%v\ngot:\n%v", pending, remaining)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	added, err := store.Enqueue([]PendingWebhookEvent{{Event: events[1], ReceivedAt: receivedAt}})
+	if err != nil || added != 1 {
+		t.Errorf("Unexpected Enqueue result %d, %v", added, err)
+	}
+}
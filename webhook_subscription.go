@@ -0,0 +1,199 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/url"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type IHubspotWebhookAPI interface {
+	GetWebhookSettings() (*WebhookSettings, error)
+	UpdateWebhookSettings(settings WebhookSettings) (*WebhookSettings, error)
+	ListWebhookSubscriptions() ([]WebhookSubscription, error)
+	GetWebhookSubscription(subscriptionId string) (*WebhookSubscription, error)
+	CreateWebhookSubscription(eventType, propertyName string, active bool) (*WebhookSubscription, error)
+	ActivateWebhookSubscription(subscriptionId string) (*WebhookSubscription, error)
+	DeactivateWebhookSubscription(subscriptionId string) (*WebhookSubscription, error)
+	DeleteWebhookSubscription(subscriptionId string) error
+}
+
+// HubspotWebhookAPI is the structure to interact with HubSpot Webhooks API of an app,
+// APIKey is the developer API key of the account owning the app
+type HubspotWebhookAPI struct {
+	APIKey     string
+	AppId      string
+	httpClient IHTTPClient
+}
+
+// Throttling periods of webhook settings
+const (
+	WebhookThrottlingSecondly      = "SECONDLY"
+	WebhookThrottlingRollingMinute = "ROLLING_MINUTE"
+)
+
+// WebhookThrottling limits the number of webhook requests HubSpot sends concurrently, per Period
+type WebhookThrottling struct {
+	MaxConcurrentRequests int    `json:"maxConcurrentRequests"`
+	Period                string `json:"period,omitempty"`
+}
+
+// WebhookSettings is a representation of the webhook settings of an app, TargetURL is the URL HubSpot sends webhooks to
+type WebhookSettings struct {
+	TargetURL  string            `json:"targetUrl"`
+	Throttling WebhookThrottling `json:"throttling"`
+	CreatedAt  string            `json:"createdAt,omitempty"`
+	UpdatedAt  string            `json:"updatedAt,omitempty"`
+}
+
+// WebhookSubscription is a subscription of an app to an event type, e.g. "deal.propertyChange",
+// PropertyName is the property the subscription is for, for property change events
+type WebhookSubscription struct {
+	Id           string `json:"id"`
+	EventType    string `json:"eventType"`
+	PropertyName string `json:"propertyName,omitempty"`
+	Active       bool   `json:"active"`
+	CreatedAt    string `json:"createdAt"`
+	UpdatedAt    string `json:"updatedAt"`
+}
+
+type webhookSubscriptionRequest struct {
+	EventType    string `json:"eventType"`
+	PropertyName string `json:"propertyName,omitempty"`
+	Active       bool   `json:"active"`
+}
+
+type webhookSubscriptionUpdate struct {
+	Active bool `json:"active"`
+}
+
+type webhookSubscriptionsResponse struct {
+	Results []WebhookSubscription `json:"results"`
+}
+
+// NewHubspotWebhookAPI creates new HubspotWebhookAPI with developer API key and app id
+func NewHubspotWebhookAPI(apiKey string, appId string) HubspotWebhookAPI {
+	return NewHubspotWebhookAPIWithClient(apiKey, appId, HTTPClient{})
+}
+
+// NewHubspotWebhookAPIWithClient creates new HubspotWebhookAPI with developer API key, app id and HTTP client
+func NewHubspotWebhookAPIWithClient(apiKey string, appId string, httpClient IHTTPClient) HubspotWebhookAPI {
+	return HubspotWebhookAPI{
+		APIKey:     apiKey,
+		AppId:      appId,
+		httpClient: httpClient,
+	}
+}
+
+func (api HubspotWebhookAPI) url(path string) string {
+	query := url.Values{}
+	query.Set("hapikey", api.APIKey)
+
+	return fmt.Sprintf("https://api.hubapi.com/webhooks/v3/%s%s?%s", api.AppId, path, query.Encode())
+}
+
+// GetWebhookSettings returns the webhook settings of the app
+func (api HubspotWebhookAPI) GetWebhookSettings() (*WebhookSettings, error) {
+	var settings WebhookSettings
+	err := doJSONRequest(api.httpClient, "GET", api.url("/settings"), nil, &settings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// UpdateWebhookSettings updates the target URL and throttling of the webhooks of the app
+func (api HubspotWebhookAPI) UpdateWebhookSettings(settings WebhookSettings) (*WebhookSettings, error) {
+	log.Infof("Setting the webhook target URL of app %s to '%s'", api.AppId, settings.TargetURL)
+
+	request := WebhookSettings{TargetURL: settings.TargetURL, Throttling: settings.Throttling}
+	if request.Throttling.Period == "" {
+		request.Throttling.Period = WebhookThrottlingSecondly
+	}
+
+	var updated WebhookSettings
+	err := doJSONRequest(api.httpClient, "PUT", api.url("/settings"), request, &updated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// ListWebhookSubscriptions returns all the event subscriptions of the app
+func (api HubspotWebhookAPI) ListWebhookSubscriptions() ([]WebhookSubscription, error) {
+	var response webhookSubscriptionsResponse
+	err := doJSONRequest(api.httpClient, "GET", api.url("/subscriptions"), nil, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Results == nil {
+		return []WebhookSubscription{}, nil
+	}
+
+	return response.Results, nil
+}
+
+// GetWebhookSubscription returns the event subscription with the given id
+func (api HubspotWebhookAPI) GetWebhookSubscription(subscriptionId string) (*WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	err := doJSONRequest(api.httpClient, "GET", api.url("/subscriptions/"+subscriptionId), nil, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// CreateWebhookSubscription subscribes the app to an event type, e.g. "contact.creation",
+// propertyName is required for property change events, e.g. "dealstage" for "deal.propertyChange", and "" otherwise
+func (api HubspotWebhookAPI) CreateWebhookSubscription(eventType, propertyName string, active bool) (*WebhookSubscription, error) {
+	log.Infof("Subscribing app %s to %s %s", api.AppId, eventType, propertyName)
+
+	request := webhookSubscriptionRequest{
+		EventType:    eventType,
+		PropertyName: propertyName,
+		Active:       active,
+	}
+
+	var subscription WebhookSubscription
+	err := doJSONRequest(api.httpClient, "POST", api.url("/subscriptions"), request, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// ActivateWebhookSubscription activates an event subscription, HubSpot sends its events once it is active
+func (api HubspotWebhookAPI) ActivateWebhookSubscription(subscriptionId string) (*WebhookSubscription, error) {
+	log.Infof("Activating webhook subscription %s", subscriptionId)
+
+	return api.setWebhookSubscriptionActive(subscriptionId, true)
+}
+
+// DeactivateWebhookSubscription deactivates an event subscription, HubSpot stops sending its events
+func (api HubspotWebhookAPI) DeactivateWebhookSubscription(subscriptionId string) (*WebhookSubscription, error) {
+	log.Infof("Deactivating webhook subscription %s", subscriptionId)
+
+	return api.setWebhookSubscriptionActive(subscriptionId, false)
+}
+
+func (api HubspotWebhookAPI) setWebhookSubscriptionActive(subscriptionId string, active bool) (*WebhookSubscription, error) {
+	var subscription WebhookSubscription
+	err := doJSONRequest(api.httpClient, "PATCH", api.url("/subscriptions/"+subscriptionId), webhookSubscriptionUpdate{Active: active}, &subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// DeleteWebhookSubscription deletes an event subscription
+func (api HubspotWebhookAPI) DeleteWebhookSubscription(subscriptionId string) error {
+	log.Infof("Deleting webhook subscription %s", subscriptionId)
+
+	return doJSONRequest(api.httpClient, "DELETE", api.url("/subscriptions/"+subscriptionId), nil, nil)
+}
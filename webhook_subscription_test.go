@@ -0,0 +1,150 @@
+package go_hubspot
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func getMockWebhookAPI(mockClient *IHTTPClientMock) HubspotWebhookAPI {
+	return HubspotWebhookAPI{
+		APIKey:     "api_key",
+		AppId:      "1234",
+		httpClient: mockClient,
+	}
+}
+
+func TestUpdateWebhookSettings(t *testing.T) {
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			if url == "PUT https://api.hubapi.com/webhooks/v3/1234/settings?hapikey=api_key" {
+				var request WebhookSettings
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := WebhookSettings{
+					TargetURL:  "https://example.com/webhooks",
+					Throttling: WebhookThrottling{MaxConcurrentRequests: 10, Period: "SECONDLY"},
+				}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected UpdateWebhookSettings request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				request.CreatedAt = "2021-01-01T00:00:00Z"
+				writeJSONResponse(t, w, 200, request)
+			} else {
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockWebhookAPI(&mockHubspotHTTPClient)
+	settings, err := api.UpdateWebhookSettings(WebhookSettings{
+		TargetURL:  "https://example.com/webhooks",
+		Throttling: WebhookThrottling{MaxConcurrentRequests: 10},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	if settings.TargetURL != "https://example.com/webhooks" || settings.CreatedAt != "2021-01-01T00:00:00Z" {
+		t.Errorf("Unexpected settings: %v", settings)
+	}
+}
+
+func TestWebhookSubscriptions(t *testing.T) {
+	subscription := WebhookSubscription{Id: "42", EventType: "deal.propertyChange", PropertyName: "dealstage"}
+
+	mockHubspotHTTPClient := IHTTPClientMock{
+		GetFunc: func(url string) (resp *http.Response, err error) { return nil, nil },
+		DoFunc: func(req *http.Request) (resp *http.Response, err error) {
+			url := fmt.Sprintf("%s %s", req.Method, req.URL)
+
+			w := httptest.NewRecorder()
+			switch url {
+			case "POST https://api.hubapi.com/webhooks/v3/1234/subscriptions?hapikey=api_key":
+				var request webhookSubscriptionRequest
+				readJSONRequest(t, req, &request)
+
+				expectedRequest := webhookSubscriptionRequest{EventType: "deal.propertyChange", PropertyName: "dealstage", Active: false}
+				if !cmp.Equal(expectedRequest, request) {
+					t.Errorf("Unexpected CreateWebhookSubscription request, expected:\n%v\ngot:\n%v", expectedRequest, request)
+				}
+
+				writeJSONResponse(t, w, 201, subscription)
+			case "GET https://api.hubapi.com/webhooks/v3/1234/subscriptions?hapikey=api_key":
+				writeJSONResponse(t, w, 200, webhookSubscriptionsResponse{Results: []WebhookSubscription{subscription}})
+			case "GET https://api.hubapi.com/webhooks/v3/1234/subscriptions/42?hapikey=api_key":
+				writeJSONResponse(t, w, 200, subscription)
+			case "PATCH https://api.hubapi.com/webhooks/v3/1234/subscriptions/42?hapikey=api_key":
+				var request webhookSubscriptionUpdate
+				readJSONRequest(t, req, &request)
+
+				subscription.Active = request.Active
+				writeJSONResponse(t, w, 200, subscription)
+			case "DELETE https://api.hubapi.com/webhooks/v3/1234/subscriptions/42?hapikey=api_key":
+				w.WriteHeader(204)
+			default:
+				t.Errorf("Unexpected url %s", url)
+			}
+
+			return w.Result(), nil
+		},
+	}
+
+	api := getMockWebhookAPI(&mockHubspotHTTPClient)
+
+	created, err := api.CreateWebhookSubscription("deal.propertyChange", "dealstage", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if created.Id != "42" || created.Active {
+		t.Errorf("Unexpected subscription: %v", created)
+	}
+
+	activated, err := api.ActivateWebhookSubscription("42")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if !activated.Active {
+		t.Errorf("Subscription was not activated: %v", activated)
+	}
+
+	subscriptions, err := api.ListWebhookSubscriptions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	expectedSubscriptions := []WebhookSubscription{{Id: "42", EventType: "deal.propertyChange", PropertyName: "dealstage", Active: true}}
+	if !cmp.Equal(expectedSubscriptions, subscriptions) {
+		t.Errorf("Unexpected subscriptions, expected:\n%v\ngot:\n%v", expectedSubscriptions, subscriptions)
+	}
+
+	deactivated, err := api.DeactivateWebhookSubscription("42")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if deactivated.Active {
+		t.Errorf("Subscription was not deactivated: %v", deactivated)
+	}
+
+	fetched, err := api.GetWebhookSubscription("42")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+	if fetched.Active {
+		t.Errorf("Unexpected subscription: %v", fetched)
+	}
+
+	err = api.DeleteWebhookSubscription("42")
+	if err != nil {
+		t.Errorf("Unexpected error: %s", err.Error())
+	}
+}
@@ -0,0 +1,290 @@
+package go_hubspot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const webhookBody = `[
+	{"eventId": 1, "subscriptionId": 10, "portalId": 100, "appId": 1000, "occurredAt": 1600000000000, "subscriptionType": "contact.creation", "attemptNumber": 0, "objectId": 123, "changeSource": "CRM"},
+	{"eventId": 2, "subscriptionId": 11, "portalId": 100, "appId": 1000, "occurredAt": 1600000001000, "subscriptionType": "deal.propertyChange", "attemptNumber": 0, "objectId": 456, "propertyName": "dealstage", "propertyValue": "closedwon", "changeSource": "CRM"},
+	{"eventId": 3, "subscriptionId": 12, "portalId": 100, "appId": 1000, "occurredAt": 1600000002000, "subscriptionType": "contact.associationChange", "attemptNumber": 0, "associationType": "CONTACT_TO_COMPANY", "fromObjectId": 123, "toObjectId": 789, "associationRemoved": true, "isPrimaryAssociation": false},
+	{"eventId": 4, "subscriptionId": 13, "portalId": 100, "appId": 1000, "occurredAt": 1600000003000, "subscriptionType": "contact.merge", "attemptNumber": 1, "objectId": 123, "primaryObjectId": 123, "mergedObjectIds": [124], "newObjectId": 125, "numberOfPropertiesMoved": 3},
+	{"eventId": 5, "subscriptionId": 14, "portalId": 100, "appId": 1000, "occurredAt": 1600000004000, "subscriptionType": "company.deletion", "attemptNumber": 0, "objectId": 789},
+	{"eventId": 6, "subscriptionId": 15, "portalId": 100, "appId": 1000, "occurredAt": 1600000005000, "subscriptionType": "contact.restore", "attemptNumber": 0, "objectId": 124}
+]`
+
+func signV3(secret, method, url, body, timestamp string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(method + url + body + timestamp))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signSHA256(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:])
+}
+
+func newWebhookRequest(body string) *http.Request {
+	return httptest.NewRequest("POST", "https://example.com/webhooks?source=hubspot", strings.NewReader(body))
+}
+
+func TestDecodeWebhookEvents(t *testing.T) {
+	events, err := DecodeWebhookEvents([]byte(webhookBody))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err.Error())
+	}
+
+	base := func(eventId, subscriptionId int64, occurredAt int64, subscriptionType, objectType, kind string, attemptNumber int, objectId int64, changeSource string) WebhookEventBase {
+		return WebhookEventBase{
+			EventId:          eventId,
+			SubscriptionId:   subscriptionId,
+			PortalId:         100,
+			AppId:            1000,
+			OccurredAt:       time.Unix(occurredAt, 0).UTC(),
+			SubscriptionType: subscriptionType,
+			ObjectType:       objectType,
+			Kind:             kind,
+			AttemptNumber:    attemptNumber,
+			ObjectId:         objectId,
+			ChangeSource:     changeSource,
+		}
+	}
+
+	expectedEvents := []WebhookEvent{
+		CreationEvent{base(1, 10, 1600000000, "contact.creation", "contact", "creation", 0, 123, "CRM")},
+		PropertyChangeEvent{base(2, 11, 1600000001, "deal.propertyChange", "deal", "propertyChange", 0, 456, "CRM"), "dealstage", "closedwon"},
+		AssociationChangeEvent{base(3, 12, 1600000002, "contact.associationChange", "contact", "associationChange", 0, 0, ""), "CONTACT_TO_COMPANY", 123, 789, true, false},
+		MergeEvent{base(4, 13, 1600000003, "contact.merge", "contact", "merge", 1, 123, ""), 123, []int64{124}, 125, 3},
+		DeletionEvent{base(5, 14, 1600000004, "company.deletion", "company", "deletion", 0, 789, "")},
+		OtherEvent{base(6, 15, 1600000005, "contact.restore", "contact", "restore", 0, 124, "")},
+	}
+	if !cmp.Equal(expectedEvents, events) {
+		t.Errorf("Unexpected events, expected:\n%v\ngot:\n%v", expectedEvents, events)
+	}
+}
+
+func TestWebhookHandlerDispatch(t *testing.T) {
+	handler := NewWebhookHandler("secret")
+
+	all := []int64{}
+	handler.OnEvent(func(event WebhookEvent) error {
+		all = append(all, event.Event().EventId)
+		return nil
+	})
+
+	var created []CreationEvent
+	var deleted []DeletionEvent
+	var changed []PropertyChangeEvent
+	var associations []AssociationChangeEvent
+	var merged []MergeEvent
+	handler.OnCreation(func(event CreationEvent) error { created = append(created, event); return nil })
+	handler.OnDeletion(func(event DeletionEvent) error { deleted = append(deleted, event); return nil })
+	handler.OnPropertyChange(func(event PropertyChangeEvent) error { changed = append(changed, event); return nil })
+	handler.OnAssociationChange(func(event AssociationChangeEvent) error { associations = append(associations, event); return nil })
+	handler.OnMerge(func(event MergeEvent) error { merged = append(merged, event); return nil })
+
+	req := newWebhookRequest(webhookBody)
+	req.Header.Set("X-HubSpot-Signature", signSHA256("secret"+webhookBody))
+	req.Header.Set("X-HubSpot-Signature-Version", "v1")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Unexpected status %d: %s", w.Code, w.Body.String())
+	}
+	if !cmp.Equal([]int64{1, 2, 3, 4, 5, 6}, all) {
+		t.Errorf("Unexpected events dispatched to OnEvent: %v", all)
+	}
+	if len(created) != 1 || created[0].ObjectId != 123 {
+		t.Errorf("Unexpected creation events: %v", created)
+	}
+	if len(deleted) != 1 || deleted[0].ObjectType != "company" {
+		t.Errorf("Unexpected deletion events: %v", deleted)
+	}
+	if len(changed) != 1 || changed[0].PropertyName != "dealstage" || changed[0].PropertyValue != "closedwon" {
+		t.Errorf("Unexpected property change events: %v", changed)
+	}
+	if len(associations) != 1 || !associations[0].AssociationRemoved {
+		t.Errorf("Unexpected association change events: %v", associations)
+	}
+	if len(merged) != 1 || merged[0].NewObjectId != 125 {
+		t.Errorf("Unexpected merge events: %v", merged)
+	}
+}
+
+func TestWebhookHandlerCallbackError(t *testing.T) {
+	handler := NewWebhookHandler("secret")
+
+	calls := 0
+	handler.OnCreation(func(event CreationEvent) error {
+		calls++
+		return errors.New("database unavailable")
+	})
+	handler.OnPropertyChange(func(event PropertyChangeEvent) error {
+		t.Errorf("Unexpected call after a failed callback")
+		return nil
+	})
+
+	req := newWebhookRequest(webhookBody)
+	req.Header.Set("X-HubSpot-Signature", signSHA256("secret"+webhookBody))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Unexpected status %d, expected %d", w.Code, http.StatusInternalServerError)
+	}
+	if calls != 1 {
+		t.Errorf("Unexpected number of calls %d", calls)
+	}
+}
+
+func TestWebhookHandlerSignatures(t *testing.T) {
+	now := time.Unix(1600000100, 0)
+	timestamp := strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10)
+	old := strconv.FormatInt(now.Add(-6*time.Minute).UnixNano()/int64(time.Millisecond), 10)
+	skewed := strconv.FormatInt(now.Add(30*time.Second).UnixNano()/int64(time.Millisecond), 10)
+	future := strconv.FormatInt(now.Add(10*time.Minute).UnixNano()/int64(time.Millisecond), 10)
+	url := "https://example.com/webhooks?source=hubspot"
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected int
+	}{
+		{
+			name:     "unsigned",
+			headers:  map[string]string{},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "v1",
+			headers:  map[string]string{"X-HubSpot-Signature": signSHA256("secret" + webhookBody), "X-HubSpot-Signature-Version": "v1"},
+			expected: http.StatusNoContent,
+		},
+		{
+			name:     "v1 wrong secret",
+			headers:  map[string]string{"X-HubSpot-Signature": signSHA256("other" + webhookBody), "X-HubSpot-Signature-Version": "v1"},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "v2",
+			headers:  map[string]string{"X-HubSpot-Signature": signSHA256("secret" + "POST" + url + webhookBody), "X-HubSpot-Signature-Version": "v2"},
+			expected: http.StatusNoContent,
+		},
+		{
+			name:     "v2 wrong url",
+			headers:  map[string]string{"X-HubSpot-Signature": signSHA256("secret" + "POST" + "https://example.com/other" + webhookBody), "X-HubSpot-Signature-Version": "v2"},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "unknown version",
+			headers:  map[string]string{"X-HubSpot-Signature": signSHA256("secret" + webhookBody), "X-HubSpot-Signature-Version": "v9"},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "v3",
+			headers:  map[string]string{"X-HubSpot-Signature-v3": signV3("secret", "POST", url, webhookBody, timestamp), "X-HubSpot-Request-Timestamp": timestamp},
+			expected: http.StatusNoContent,
+		},
+		{
+			name:     "v3 expired",
+			headers:  map[string]string{"X-HubSpot-Signature-v3": signV3("secret", "POST", url, webhookBody, old), "X-HubSpot-Request-Timestamp": old},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "v3 clock skew",
+			headers:  map[string]string{"X-HubSpot-Signature-v3": signV3("secret", "POST", url, webhookBody, skewed), "X-HubSpot-Request-Timestamp": skewed},
+			expected: http.StatusNoContent,
+		},
+		{
+			name:     "v3 future",
+			headers:  map[string]string{"X-HubSpot-Signature-v3": signV3("secret", "POST", url, webhookBody, future), "X-HubSpot-Request-Timestamp": future},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "v3 tampered timestamp",
+			headers:  map[string]string{"X-HubSpot-Signature-v3": signV3("secret", "POST", url, webhookBody, old), "X-HubSpot-Request-Timestamp": timestamp},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "v3 missing timestamp",
+			headers:  map[string]string{"X-HubSpot-Signature-v3": signV3("secret", "POST", url, webhookBody, timestamp)},
+			expected: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			handler := NewWebhookHandler("secret")
+			handler.now = func() time.Time { return now }
+
+			req := newWebhookRequest(webhookBody)
+			for name, value := range test.headers {
+				req.Header.Set(name, value)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != test.expected {
+				t.Errorf("Unexpected status %d, expected %d", w.Code, test.expected)
+			}
+		})
+	}
+}
+
+func TestWebhookHandlerPublicURL(t *testing.T) {
+	now := time.Unix(1600000100, 0)
+	timestamp := strconv.FormatInt(now.UnixNano()/int64(time.Millisecond), 10)
+
+	handler := NewWebhookHandler("secret")
+	handler.PublicURL = "https://hooks.example.com/hubspot"
+	handler.now = func() time.Time { return now }
+
+	// The request reaches the handler through a proxy, with a different host and path
+	req := httptest.NewRequest("POST", "http://internal:8080/webhooks?email=a%40b.com", strings.NewReader(webhookBody))
+	signature := signV3("secret", "POST", "https://hooks.example.com/hubspot?email=a@b.com", webhookBody, timestamp)
+	req.Header.Set("X-HubSpot-Signature-v3", signature)
+	req.Header.Set("X-HubSpot-Request-Timestamp", timestamp)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Unexpected status %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestWebhookHandlerInvalidRequests(t *testing.T) {
+	handler := NewWebhookHandler("secret")
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "https://example.com/webhooks", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Unexpected status %d for a GET request", w.Code)
+	}
+
+	body := `{"eventId": 1}`
+	req := newWebhookRequest(body)
+	req.Header.Set("X-HubSpot-Signature", signSHA256("secret"+body))
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Unexpected status %d for a body that is not an array of events", w.Code)
+	}
+}